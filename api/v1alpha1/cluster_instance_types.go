@@ -25,6 +25,7 @@ type InstanceObject interface {
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="ClusterTemplate",type=string,JSONPath=`.spec.template.name`
 // +kubebuilder:printcolumn:name="AppliedResources",type=string,JSONPath=`.status.lastAppliedObjectsCount`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // ClusterInstance is the Schema for the instances API
 type ClusterInstance struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -0,0 +1,31 @@
+package v1alpha1
+
+// Condition types set on the status of Instance, ClusterInstance, Workspace and User.
+const (
+	// ConditionTypeReady is true when the resource is fully reconciled and available.
+	// It is the condition to wait for by `kubectl wait --for=condition=Ready`.
+	ConditionTypeReady = "Ready"
+	// ConditionTypeSynced is true when the child resources are applied as desired
+	ConditionTypeSynced = "Synced"
+	// ConditionTypeTemplateResolved is true when the referenced template is found and built
+	ConditionTypeTemplateResolved = "TemplateResolved"
+	// ConditionTypeRoutingReady is true when the routing to the workspace is configured
+	ConditionTypeRoutingReady = "RoutingReady"
+	// ConditionTypeAddonsReady is true when all the addons of the user are applied
+	ConditionTypeAddonsReady = "AddonsReady"
+)
+
+// Condition reasons
+const (
+	ConditionReasonTemplateFound    = "TemplateFound"
+	ConditionReasonTemplateNotFound = "TemplateNotFound"
+	ConditionReasonBuildFailed      = "BuildFailed"
+	ConditionReasonSynced           = "Synced"
+	ConditionReasonSyncFailed       = "SyncFailed"
+	ConditionReasonReady            = "Ready"
+	ConditionReasonNotReady         = "NotReady"
+	ConditionReasonStopped          = "Stopped"
+	ConditionReasonProgressing      = "Progressing"
+	ConditionReasonAddonsFailed     = "AddonsFailed"
+	ConditionReasonStartTimeout     = "StartTimeout"
+)
@@ -19,6 +19,14 @@ const (
 	ResourceAnnEnumDeletePolicyDelete = "delete"
 	// ResourceAnnEnumDeletePolicyKeep is keep policy, which controller do not do garbage collenction and do not attach owner references
 	ResourceAnnEnumDeletePolicyKeep = "keep"
+
+	// ResourceAnnKeySyncWave is an annotation key on the resources in Template to specify the order to be applied.
+	// Resources are applied in ascending order of the wave (default 0), and the next wave is applied after all the resources in the wave become healthy.
+	ResourceAnnKeySyncWave = "cosmo-workspace.github.io/sync-wave"
+
+	// InstanceAnnKeyReconcilePaused is an annotation key on Instance to pause applying the child resources.
+	// It is set while the other controllers modify the child resources directly, such as restoring the workspace volumes.
+	InstanceAnnKeyReconcilePaused = "cosmo-workspace.github.io/reconcile-paused"
 )
 
 func init() {
@@ -31,6 +39,7 @@ func init() {
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Template",type=string,JSONPath=`.spec.template.name`
 // +kubebuilder:printcolumn:name="AppliedResources",type=string,JSONPath=`.status.lastAppliedObjectsCount`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // Instance is the Schema for the instances API
 type Instance struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -74,12 +83,32 @@ type InstanceSpec struct {
 	Template TemplateRef       `json:"template"`
 	Vars     map[string]string `json:"vars,omitempty"`
 	Override OverrideSpec      `json:"override,omitempty"`
+	// SecretVarsRef is a reference to the Secret which stores the values of the secret vars.
+	// +kubebuilder:validation:Optional
+	SecretVarsRef *SecretVarsReference `json:"secretVarsRef,omitempty"`
+	// SyncPolicy configures how the child resources are synced.
+	// It is merged with the SyncPolicy of the Template.
+	// +kubebuilder:validation:Optional
+	SyncPolicy *SyncPolicy `json:"syncPolicy,omitempty"`
+}
+
+// SecretVarsReference is a reference to the Secret in the same namespace which stores the values of the secret vars.
+// The keys of the Secret are the var names without braces.
+type SecretVarsReference struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Vars are the names of the vars stored in the Secret
+	// +kubebuilder:validation:Optional
+	Vars []string `json:"vars,omitempty"`
 }
 
 // TemplateRef defines template to use in Instance creation
 type TemplateRef struct {
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
+	// Revision is the name of TemplateRevision to pin. The current Template is used if empty.
+	// +kubebuilder:validation:Optional
+	Revision string `json:"revision,omitempty"`
 }
 
 // OverrideSpec defines overrides to transform built objects
@@ -93,6 +122,35 @@ type Json6902 struct {
 	Patch  string    `json:"patch,omitempty"`
 }
 
+// SyncPolicy defines how the child resources are compared and applied
+type SyncPolicy struct {
+	// IgnoreDifferences are the fields of the child resources which are not compared and not overwritten.
+	// Use it for the fields mutated by others such as HPA or other operators.
+	// +kubebuilder:validation:Optional
+	IgnoreDifferences []IgnoreDifference `json:"ignoreDifferences,omitempty"`
+	// DisableForceApply disables to force the server-side apply.
+	// Field manager conflicts are reported as events of the Instance instead of taking over the fields.
+	// +kubebuilder:validation:Optional
+	DisableForceApply bool `json:"disableForceApply,omitempty"`
+}
+
+// IgnoreDifference defines the fields to ignore in the child resources
+type IgnoreDifference struct {
+	// +kubebuilder:validation:Optional
+	Group string `json:"group,omitempty"`
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+	// Name is the resource name. All the resources of the kind are matched if empty.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+	// JSONPointers are RFC6901 JSON pointers such as "/spec/replicas"
+	// +kubebuilder:validation:Optional
+	JSONPointers []string `json:"jsonPointers,omitempty"`
+	// JSONPaths are JSONPath expressions such as ".spec.template.spec.containers[?(@.name=="sidecar")].image"
+	// +kubebuilder:validation:Optional
+	JSONPaths []string `json:"jsonPaths,omitempty"`
+}
+
 // InstanceStatus has status of Instance
 type InstanceStatus struct {
 	TemplateName            string      `json:"templateName,omitempty"`
@@ -100,12 +158,28 @@ type InstanceStatus struct {
 	LastApplied             []ObjectRef `json:"lastApplied,omitempty"`
 	LastAppliedObjectsCount int         `json:"lastAppliedObjectsCount,omitempty"`
 	TemplateObjectsCount    int         `json:"templateObjectsCount,omitempty"`
+	// LastFullSyncTime is the time when all the child resources are compared with the desired state by dry-run.
+	// Between the full syncs, the resources not changed since the last apply are skipped.
+	LastFullSyncTime *metav1.Time `json:"lastFullSyncTime,omitempty"`
+	// SyncWave is the sync wave waiting for its resources to become healthy. nil if all the waves are applied.
+	SyncWave *int `json:"syncWave,omitempty"`
+	// ObservedGeneration is the generation of the Instance observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are TemplateResolved, Synced and Ready
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // ObjectRef is a reference of resource which is created by the Instance
 type ObjectRef struct {
 	corev1.ObjectReference `json:",inline"`
 	CreationTimestamp      *metav1.Time `json:"creationTimestamp,omitempty"`
+	// Generation is the generation of the resource last applied
+	Generation int64 `json:"generation,omitempty"`
+	// BuiltHash is the hash of the object built from the template last applied
+	BuiltHash string `json:"builtHash,omitempty"`
 }
 
 func (r *ObjectRef) SetName(name string) {
@@ -0,0 +1,61 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// UserResourceQuotaName is the name of ResourceQuota created in the user namespace
+	UserResourceQuotaName = "cosmo-user-quota"
+	// UserLimitRangeName is the name of LimitRange created in the user namespace
+	UserLimitRangeName = "cosmo-user-limitrange"
+
+	// LabelKeyQuotaProfile is a label key on ResourceQuota and LimitRange to record the source QuotaProfile
+	LabelKeyQuotaProfile = "cosmo-workspace.github.io/quota-profile"
+)
+
+func init() {
+	SchemeBuilder.Register(&QuotaProfile{}, &QuotaProfileList{})
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Cluster",shortName=qp
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Roles",type=string,JSONPath=`.spec.roles`
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=`.spec.priority`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// QuotaProfile is the Schema for the quotaprofiles API.
+// The user controller applies the ResourceQuota and the LimitRange of the matched profile into the user namespace.
+type QuotaProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec QuotaProfileSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// QuotaProfileList contains a list of QuotaProfile
+type QuotaProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []QuotaProfile `json:"items"`
+}
+
+// QuotaProfileSpec defines the desired state of QuotaProfile
+type QuotaProfileSpec struct {
+	// Roles is a list of UserRole names the profile is applied to. Wildcard is available like "team-*".
+	// The profile is applied to all users if empty.
+	// +kubebuilder:validation:Optional
+	Roles []string `json:"roles,omitempty"`
+	// Priority decides which profile is applied when the user matches multiple profiles.
+	// The profile with the highest priority is applied, and ties are broken by the name.
+	// +kubebuilder:validation:Optional
+	Priority int32 `json:"priority,omitempty"`
+	// ResourceQuota is the spec of the ResourceQuota in the user namespace
+	// +kubebuilder:validation:Optional
+	ResourceQuota *corev1.ResourceQuotaSpec `json:"resourceQuota,omitempty"`
+	// LimitRange is the spec of the LimitRange in the user namespace
+	// +kubebuilder:validation:Optional
+	LimitRange *corev1.LimitRangeSpec `json:"limitRange,omitempty"`
+}
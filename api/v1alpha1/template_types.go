@@ -17,6 +17,11 @@ const (
 	TemplateAnnKeyUserRoles = "cosmo-workspace.github.io/userroles"
 	// TemplateAnnKeyRequiredAddons is a annotation key for Template which requires useraddons
 	TemplateAnnKeyRequiredAddons = "cosmo-workspace.github.io/required-useraddons"
+	// TemplateAnnKeyRenderMode is an annotation key on Template to select how the rawYaml is rendered.
+	// The default is the literal replacement of the vars.
+	TemplateAnnKeyRenderMode = "cosmo-workspace.github.io/render-mode"
+	// TemplateRenderModeGoTemplate renders the rawYaml as Go text/template
+	TemplateRenderModeGoTemplate = "gotemplate"
 )
 
 func init() {
@@ -52,14 +57,49 @@ type TemplateSpec struct {
 	Description  string            `json:"description,omitempty"`
 	RequiredVars []RequiredVarSpec `json:"requiredVars,omitempty"`
 	RawYaml      string            `json:"rawYaml,omitempty"`
+	// SyncPolicy configures how the resources built from the template are synced by default.
+	// +kubebuilder:validation:Optional
+	SyncPolicy *SyncPolicy `json:"syncPolicy,omitempty"`
 }
 
 // RequiredVarSpec defines a required var spec for template
 type RequiredVarSpec struct {
 	Var     string `json:"var"`
 	Default string `json:"default,omitempty"`
+	// Type is a type of the var value. Default is string.
+	// +kubebuilder:validation:Optional
+	Type VarType `json:"type,omitempty"`
+	// Description is a human readable description of the var
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+	// Pattern is a regular expression which the var value must match
+	// +kubebuilder:validation:Optional
+	Pattern string `json:"pattern,omitempty"`
+	// Minimum is the minimum value of the var typed int
+	// +kubebuilder:validation:Optional
+	Minimum *int64 `json:"minimum,omitempty"`
+	// Maximum is the maximum value of the var typed int
+	// +kubebuilder:validation:Optional
+	Maximum *int64 `json:"maximum,omitempty"`
+	// AllowedValues is a list of the values allowed for the var. Required for the var typed enum.
+	// +kubebuilder:validation:Optional
+	AllowedValues []string `json:"allowedValues,omitempty"`
+	// Secret indicates the value is stored in a Secret instead of the vars in the spec
+	// +kubebuilder:validation:Optional
+	Secret bool `json:"secret,omitempty"`
 }
 
+// VarType is a type of template var
+// +kubebuilder:validation:Enum=string;int;bool;enum
+type VarType string
+
+const (
+	VarTypeString VarType = "string"
+	VarTypeInt    VarType = "int"
+	VarTypeBool   VarType = "bool"
+	VarTypeEnum   VarType = "enum"
+)
+
 func (t *Template) GetScope() meta.RESTScope {
 	return meta.RESTScopeNamespace
 }
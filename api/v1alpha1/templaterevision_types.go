@@ -0,0 +1,67 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TemplateAnnKeyPinRevision is an annotation key on Template to record the revisions of the Template
+	// and to pin the revision on the new Workspaces
+	TemplateAnnKeyPinRevision = "cosmo-workspace.github.io/pin-revision"
+	// TemplateAnnKeyRevisionHistoryLimit is an annotation key on Template for the number of revisions to keep
+	TemplateAnnKeyRevisionHistoryLimit = "cosmo-workspace.github.io/revision-history-limit"
+
+	// TemplateRevisionAnnKeyHash is an annotation key on TemplateRevision to record the hash of the source Template
+	TemplateRevisionAnnKeyHash = "templaterevision.cosmo-workspace.github.io/hash"
+
+	// DefaultRevisionHistoryLimit is the default number of revisions to keep
+	DefaultRevisionHistoryLimit = 10
+)
+
+// TemplateRevisionName returns the name of TemplateRevision
+func TemplateRevisionName(tmplName string, revision int64) string {
+	return fmt.Sprintf("%s-%d", tmplName, revision)
+}
+
+func init() {
+	SchemeBuilder.Register(&TemplateRevision{}, &TemplateRevisionList{})
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Cluster",shortName=tmplrev
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Template",type=string,JSONPath=`.spec.template`
+// +kubebuilder:printcolumn:name="Revision",type=integer,JSONPath=`.spec.revision`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// TemplateRevision is an immutable snapshot of Template
+type TemplateRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+	Spec TemplateRevisionSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// TemplateRevisionList contains a list of TemplateRevision
+type TemplateRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemplateRevision `json:"items"`
+}
+
+// TemplateRevisionSpec defines the snapshot of Template
+type TemplateRevisionSpec struct {
+	// Template is the name of the source Template
+	// +kubebuilder:validation:Required
+	Template string `json:"template"`
+	// Revision is the sequence number of the revision
+	// +kubebuilder:validation:Required
+	Revision int64 `json:"revision"`
+	// Annotations are the annotations of the source Template
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// TemplateSpec is the spec of the source Template
+	TemplateSpec TemplateSpec `json:"templateSpec,omitempty"`
+}
@@ -25,6 +25,9 @@ const (
 // NamespaceLabelKeyUserName is a label key on namespace created b User
 const NamespaceLabelKeyUserName = "cosmo-workspace.github.io/user"
 
+// UserAnnKeySyncedRoles is an annotation key on User to record the roles synced from external groups
+const UserAnnKeySyncedRoles = "cosmo-workspace.github.io/synced-roles"
+
 // UserAddonTemplateAnnKeyDefault is an annotation key on UserAddon Template to notify controller to create the UserAddon for all Users
 const UserAddonTemplateAnnKeyDefaultUserAddon = "useraddon.cosmo-workspace.github.io/default"
 
@@ -56,6 +59,7 @@ func UserNameByNamespace(namespace string) string {
 // +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.status.namespace.name`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Addons",type=string,JSONPath=`.spec.addons[*].template.name`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // User is the Schema for the workspaces API
 type User struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -85,6 +89,15 @@ type UserStatus struct {
 	Namespace        ObjectRef             `json:"namespace,omitempty"`
 	Addons           []ObjectRef           `json:"addons,omitempty"`
 	SharedWorkspaces []ObjectRef           `json:"sharedWorkspaces,omitempty"`
+	// QuotaProfile is the name of QuotaProfile applied to the user namespace
+	QuotaProfile string `json:"quotaProfile,omitempty"`
+	// ObservedGeneration is the generation of the User observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are Synced, AddonsReady and Ready
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 type UserAddon struct {
@@ -149,15 +162,15 @@ func HasPrivilegedRole(roles []UserRole) bool {
 	return false
 }
 
-// +kubebuilder:validation:enum=password-secret;ldap
+// +kubebuilder:validation:enum=password-secret;ldap;oidc;webhook
 // UserAuthType enums
 type UserAuthType string
 
 const (
 	UserAuthTypePasswordSecert UserAuthType = "password-secret"
 	UserAuthTypeLDAP           UserAuthType = "ldap"
-	// UserAuthTypeOIDC    = "oidc"
-	// UserAuthTypeWebhook = "webhook"
+	UserAuthTypeOIDC           UserAuthType = "oidc"
+	UserAuthTypeWebhook        UserAuthType = "webhook"
 )
 
 func (t UserAuthType) IsValid() bool {
@@ -166,6 +179,10 @@ func (t UserAuthType) IsValid() bool {
 		return true
 	case UserAuthTypeLDAP:
 		return true
+	case UserAuthTypeOIDC:
+		return true
+	case UserAuthTypeWebhook:
+		return true
 	default:
 		return false
 	}
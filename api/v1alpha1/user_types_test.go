@@ -233,6 +233,16 @@ func TestUserAuthType_IsValid(t *testing.T) {
 			tr:   "ldap",
 			want: true,
 		},
+		{
+			name: "✅ oidc",
+			tr:   "oidc",
+			want: true,
+		},
+		{
+			name: "✅ webhook",
+			tr:   "webhook",
+			want: true,
+		},
 		{
 			name: "❌ xxxx is invalid",
 			tr:   "xxxx",
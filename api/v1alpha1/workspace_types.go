@@ -7,6 +7,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -14,12 +15,19 @@ func init() {
 	SchemeBuilder.Register(&Workspace{}, &WorkspaceList{})
 }
 
+// WorkspaceSecretVarsName returns the name of Secret which stores the secret vars of the workspace
+func WorkspaceSecretVarsName(wsName string) string {
+	return fmt.Sprintf("%s-secret-vars", wsName)
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=ws
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Template",type=string,JSONPath=`.spec.template.name`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.reason`,priority=1
 // Workspace is the Schema for the workspaces API
 type Workspace struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -40,25 +48,122 @@ type WorkspaceList struct {
 // WorkspaceSpec defines the desired state of Workspace
 type WorkspaceSpec struct {
 	// +kubebuilder:validation:Required
-	Template TemplateRef       `json:"template"`
-	Replicas *int64            `json:"replicas,omitempty"`
-	Vars     map[string]string `json:"vars,omitempty"`
-	Network  []NetworkRule     `json:"network,omitempty"`
+	Template TemplateRef        `json:"template"`
+	Replicas *int64             `json:"replicas,omitempty"`
+	Vars     map[string]string  `json:"vars,omitempty"`
+	Network  []NetworkRule      `json:"network,omitempty"`
+	Schedule *WorkspaceSchedule `json:"schedule,omitempty"`
+	// SecretVarsRef is a reference to the Secret which stores the values of the secret vars.
+	// +kubebuilder:validation:Optional
+	SecretVarsRef *SecretVarsReference `json:"secretVarsRef,omitempty"`
 }
 
+// WorkspaceSchedule defines the daily window to start and stop the workspace automatically
+type WorkspaceSchedule struct {
+	// TimeZone is IANA time zone name such as "Asia/Tokyo". Default is UTC.
+	// +kubebuilder:validation:Optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// Days are the days of the week on which the schedule is active. Empty means every day.
+	// +kubebuilder:validation:Optional
+	Days []ScheduleDay `json:"days,omitempty"`
+	// StartTime is the time to start the workspace in "HH:MM" format.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime,omitempty"`
+	// StopTime is the time to stop the workspace in "HH:MM" format.
+	// If StopTime is earlier than StartTime, the workspace is stopped on the next day.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StopTime string `json:"stopTime,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Mon;Tue;Wed;Thu;Fri;Sat;Sun
+type ScheduleDay string
+
+const (
+	ScheduleDayMonday    ScheduleDay = "Mon"
+	ScheduleDayTuesday   ScheduleDay = "Tue"
+	ScheduleDayWednesday ScheduleDay = "Wed"
+	ScheduleDayThursday  ScheduleDay = "Thu"
+	ScheduleDayFriday    ScheduleDay = "Fri"
+	ScheduleDaySaturday  ScheduleDay = "Sat"
+	ScheduleDaySunday    ScheduleDay = "Sun"
+)
+
 // WorkspaceStatus has status of Workspace
 type WorkspaceStatus struct {
 	Instance ObjectRef         `json:"instance,omitempty"`
 	Phase    string            `json:"phase,omitempty"`
 	URLs     map[string]string `json:"urls,omitempty"`
 	Config   Config            `json:"config,omitempty"`
+	// Reason is a brief reason of the phase such as CrashLoopBackOff or StartTimeout
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable message of the phase from the workspace pods
+	Message string `json:"message,omitempty"`
+	// StartingSince is the time when the workspace started starting. nil if it is running or stopped.
+	StartingSince *metav1.Time `json:"startingSince,omitempty"`
+	// ObservedGeneration is the generation of the Workspace observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are TemplateResolved, Synced, RoutingReady and Ready
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // Config defines workspace-dependent configuration
 type Config struct {
+	// DeploymentName is the name of the main workload, which is a StatefulSet if WorkloadKind is StatefulSet
 	DeploymentName      string `json:"deploymentName,omitempty"`
 	ServiceName         string `json:"serviceName,omitempty"`
 	ServiceMainPortName string `json:"mainServicePortName,omitempty"`
+	// WorkloadKind is the kind of the main workload. Deployment if empty
+	WorkloadKind string `json:"workloadKind,omitempty"`
+	// AdditionalWorkloads are the workloads suspended and resumed together with the main workload
+	AdditionalWorkloads []WorkloadRef `json:"additionalWorkloads,omitempty"`
+}
+
+// MainWorkload returns the reference to the main workload
+func (c Config) MainWorkload() WorkloadRef {
+	kind := c.WorkloadKind
+	if kind == "" {
+		kind = WorkloadKindDeployment
+	}
+	return WorkloadRef{Kind: kind, Name: c.DeploymentName}
+}
+
+// Workloads returns the main workload and the additional workloads
+func (c Config) Workloads() []WorkloadRef {
+	refs := make([]WorkloadRef, 0, len(c.AdditionalWorkloads)+1)
+	if c.DeploymentName != "" {
+		refs = append(refs, c.MainWorkload())
+	}
+	return append(refs, c.AdditionalWorkloads...)
+}
+
+// WorkloadRef is a reference to a scalable workload in the template
+type WorkloadRef struct {
+	// Kind is Deployment or StatefulSet
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+func (r WorkloadRef) GroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: r.Kind}
+}
+
+func (r WorkloadRef) String() string {
+	return r.Kind + "/" + r.Name
+}
+
+const (
+	WorkloadKindDeployment  = "Deployment"
+	WorkloadKindStatefulSet = "StatefulSet"
+)
+
+// IsValidWorkloadKind returns true if the kind is supported as a workspace workload
+func IsValidWorkloadKind(kind string) bool {
+	return kind == WorkloadKindDeployment || kind == WorkloadKindStatefulSet
 }
 
 const (
@@ -66,9 +171,23 @@ const (
 	WorkspaceTemplateAnnKeyDeploymentName  = "workspace.cosmo-workspace.github.io/deployment"
 	WorkspaceTemplateAnnKeyServiceName     = "workspace.cosmo-workspace.github.io/service"
 	WorkspaceTemplateAnnKeyServiceMainPort = "workspace.cosmo-workspace.github.io/service-main-port"
+	// WorkspaceTemplateAnnKeyWorkloadKind is the kind of the main workload, Deployment or StatefulSet
+	WorkspaceTemplateAnnKeyWorkloadKind = "workspace.cosmo-workspace.github.io/workload-kind"
+	// WorkspaceTemplateAnnKeyAdditionalWorkloads is the comma separated list of the additional workloads in "KIND/NAME" format
+	WorkspaceTemplateAnnKeyAdditionalWorkloads = "workspace.cosmo-workspace.github.io/additional-workloads"
 
 	WorkspaceAnnKeyLastStoppedAt = "workspace.cosmo-workspace.github.io/last-stopped-at"
 	WorkspaceAnnKeyLastStartedAt = "workspace.cosmo-workspace.github.io/last-started-at"
+
+	// WorkspaceAnnKeyLastAccessedAt is updated when the proxied access to the workspace is reported
+	WorkspaceAnnKeyLastAccessedAt = "workspace.cosmo-workspace.github.io/last-accessed-at"
+	// WorkspaceAnnKeyIdleTimeout is idle duration to suspend the workspace automatically.
+	// It can be set on both Template and Workspace. Workspace's one takes precedence. "0" disables auto-suspend.
+	WorkspaceAnnKeyIdleTimeout = "workspace.cosmo-workspace.github.io/idle-timeout"
+	// WorkspaceAnnKeyScheduleLastAppliedAt is the time of the last schedule transition applied to the workspace
+	WorkspaceAnnKeyScheduleLastAppliedAt = "workspace.cosmo-workspace.github.io/schedule-last-applied-at"
+	// WorkspaceAnnKeyClonedFrom is the source workspace in "namespace/name" format if the workspace is cloned
+	WorkspaceAnnKeyClonedFrom = "workspace.cosmo-workspace.github.io/cloned-from"
 )
 
 const (
@@ -78,8 +197,15 @@ const (
 	WorkspaceTemplateVarServiceMainPortName = "{{WORKSPACE_SERVICE_MAIN_PORT_NAME}}"
 )
 
+const (
+	NetworkRuleProtocolHTTP = "http"
+	// NetworkRuleProtocolTCP is routed by the hostname in TLS SNI and the TLS is passed through to the workspace
+	NetworkRuleProtocolTCP = "tcp"
+)
+
 // NetworkRule is an abstract network configuration rule for workspace
 type NetworkRule struct {
+	// Protocol is http or tcp
 	Protocol         string   `json:"protocol"`
 	PortNumber       int32    `json:"portNumber"`
 	CustomHostPrefix string   `json:"customHostPrefix,omitempty"`
@@ -93,9 +219,16 @@ func HTTPUniqueKey(host, httpPath string) string {
 	return fmt.Sprintf("http://%s%s", host, httpPath)
 }
 
+func TCPUniqueKey(host string) string {
+	return fmt.Sprintf("tcp://%s", host)
+}
+
 func (r *NetworkRule) UniqueKey() string {
-	if r.Protocol == "http" {
+	switch r.Protocol {
+	case NetworkRuleProtocolHTTP:
 		return HTTPUniqueKey(r.HostPrefix(), r.HTTPPath)
+	case NetworkRuleProtocolTCP:
+		return TCPUniqueKey(r.HostPrefix())
 	}
 	return r.HostPrefix()
 }
@@ -126,11 +259,11 @@ func (r *NetworkRule) portName() string {
 }
 
 func (r *NetworkRule) Default() {
-	if r.HTTPPath == "" {
-		r.HTTPPath = "/"
-	}
 	if r.Protocol == "" {
-		r.Protocol = "http"
+		r.Protocol = NetworkRuleProtocolHTTP
+	}
+	if r.Protocol == NetworkRuleProtocolHTTP && r.HTTPPath == "" {
+		r.HTTPPath = "/"
 	}
 }
 
@@ -112,6 +112,19 @@ func TestNetworkRule_Default(t *testing.T) {
 				Public:           false,
 			},
 		},
+		{
+			name: "✅ path is empty in tcp",
+			netRule: NetworkRule{
+				Protocol:   "tcp",
+				PortNumber: 5432,
+				Public:     true,
+			},
+			want: NetworkRule{
+				Protocol:   "tcp",
+				PortNumber: 5432,
+				Public:     true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -125,6 +138,32 @@ func TestNetworkRule_Default(t *testing.T) {
 	}
 }
 
+func TestNetworkRule_UniqueKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		netRule NetworkRule
+		want    string
+	}{
+		{
+			name:    "✅ http",
+			netRule: NetworkRule{Protocol: "http", PortNumber: 8080, HTTPPath: "/path"},
+			want:    "http://port8080/path",
+		},
+		{
+			name:    "✅ tcp",
+			netRule: NetworkRule{Protocol: "tcp", PortNumber: 5432, CustomHostPrefix: "db"},
+			want:    "tcp://db",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.netRule.UniqueKey(); got != tt.want {
+				t.Errorf("NetworkRule.UniqueKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNetworkRule_portName(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,89 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WorkspaceSnapshotAnnKeyRestoreRequestedAt is the time when the restore is requested.
+	// The controller restores the volumes if it is newer than status.lastRestoredAt.
+	WorkspaceSnapshotAnnKeyRestoreRequestedAt = "workspacesnapshot.cosmo-workspace.github.io/restore-requested-at"
+	// WorkspaceSnapshotAnnKeyRestoredFrom is an annotation on the PVC restored from the snapshot
+	WorkspaceSnapshotAnnKeyRestoredFrom = "workspacesnapshot.cosmo-workspace.github.io/restored-from"
+
+	// LabelKeyWorkspaceName is a workspace name label on the WorkspaceSnapshot and the VolumeSnapshots
+	LabelKeyWorkspaceName = "cosmo-workspace.github.io/workspace"
+)
+
+const (
+	WorkspaceSnapshotPhasePending = "Pending"
+	WorkspaceSnapshotPhaseReady   = "Ready"
+	WorkspaceSnapshotPhaseFailed  = "Failed"
+)
+
+func init() {
+	SchemeBuilder.Register(&WorkspaceSnapshot{}, &WorkspaceSnapshotList{})
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=wss
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Workspace",type=string,JSONPath=`.spec.workspace`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="LastRestoredAt",type=date,JSONPath=`.status.lastRestoredAt`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// WorkspaceSnapshot is the Schema for the workspacesnapshots API
+type WorkspaceSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkspaceSnapshotSpec   `json:"spec,omitempty"`
+	Status WorkspaceSnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// WorkspaceSnapshotList contains a list of WorkspaceSnapshot
+type WorkspaceSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkspaceSnapshot `json:"items"`
+}
+
+// WorkspaceSnapshotSpec defines the desired state of WorkspaceSnapshot
+type WorkspaceSnapshotSpec struct {
+	// Workspace is the name of the workspace in the same namespace
+	// +kubebuilder:validation:Required
+	Workspace string `json:"workspace"`
+	// VolumeSnapshotClassName is the name of VolumeSnapshotClass. Default class is used if empty.
+	// +kubebuilder:validation:Optional
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// WorkspaceSnapshotStatus has status of WorkspaceSnapshot
+type WorkspaceSnapshotStatus struct {
+	Phase          string           `json:"phase,omitempty"`
+	Message        string           `json:"message,omitempty"`
+	Volumes        []VolumeSnapshot `json:"volumes,omitempty"`
+	LastRestoredAt *metav1.Time     `json:"lastRestoredAt,omitempty"`
+}
+
+// VolumeSnapshot is a snapshot of the PVC in the workspace
+type VolumeSnapshot struct {
+	// PersistentVolumeClaimName is the name of the source PVC
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+	// VolumeSnapshotName is the name of snapshot.storage.k8s.io/v1 VolumeSnapshot
+	VolumeSnapshotName string `json:"volumeSnapshotName"`
+	ReadyToUse         bool   `json:"readyToUse"`
+	// Labels of the source PVC to restore
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations of the source PVC managed by cosmo to restore
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// ClaimSpec of the source PVC to restore
+	ClaimSpec corev1.PersistentVolumeClaimSpec `json:"claimSpec,omitempty"`
+}
+
+func (s *WorkspaceSnapshot) IsReady() bool {
+	return s.Status.Phase == WorkspaceSnapshotPhaseReady
+}
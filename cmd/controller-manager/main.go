@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"reflect"
+	"time"
 
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -14,18 +15,21 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	klog "k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	traefikv1 "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
 
 	"github.com/cosmo-workspace/cosmo/internal/controllers"
 	"github.com/cosmo-workspace/cosmo/internal/webhooks"
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
 	"github.com/cosmo-workspace/cosmo/pkg/workspace"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
@@ -33,17 +37,20 @@ import (
 )
 
 const (
-	controllerFieldManager string = "cosmo-instance-controller"
+	controllerFieldManager string = kosmo.InstanceControllerFieldManager
 )
 
 const (
-	instController        string = "cosmo-instance-controller"
-	clusterInstController string = "cosmo-cluster-instance-controller"
-	tmplController        string = "cosmo-template-controller"
-	clusterTmplController string = "cosmo-cluster-template-controller"
-	userController        string = "cosmo-user-controller"
-	wsController          string = "cosmo-workspace-controller"
-	wsStatController      string = "cosmo-workspace-status-controller"
+	instController          string = "cosmo-instance-controller"
+	clusterInstController   string = "cosmo-cluster-instance-controller"
+	tmplController          string = "cosmo-template-controller"
+	clusterTmplController   string = "cosmo-cluster-template-controller"
+	userController          string = "cosmo-user-controller"
+	wsController            string = "cosmo-workspace-controller"
+	wsStatController        string = "cosmo-workspace-status-controller"
+	wsAutoSuspendController string = "cosmo-workspace-autosuspend-controller"
+	wsScheduleController    string = "cosmo-workspace-schedule-controller"
+	wsSnapshotController    string = "cosmo-workspace-snapshot-controller"
 )
 
 var (
@@ -58,20 +65,30 @@ var (
 type option struct {
 	ZapOpts zap.Options
 
-	Port                     int
-	MetricsAddr              string
-	ProbeAddr                string
-	EnableLeaderElection     bool
-	StatusCheckIntervals     int64
-	CertDir                  string
-	WorkspaceURLBaseProtocol string
-	TraefikIngressRouteCfg   workspace.TraefikIngressRouteConfig
+	Port                   int
+	MetricsAddr            string
+	ProbeAddr              string
+	EnableLeaderElection   bool
+	StatusCheckIntervals   int64
+	CertDir                string
+	RoutingProvider        string
+	RoutingCfg             workspace.RoutingConfig
+	TraefikIngressRouteCfg workspace.TraefikIngressRouteConfig
+	GatewayHTTPRouteCfg    workspace.GatewayHTTPRouteConfig
+	GatewayNamespace       string
+	IngressCfg             workspace.IngressConfig
+	WorkspaceIdleTimeout   time.Duration
+	ActivityMinInterval    time.Duration
+	ActivityToken          string `secret:"true"`
+	InstanceFullResync     time.Duration
+	WorkspaceStartTimeout  time.Duration
 }
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(cosmov1alpha1.AddToScheme(scheme))
 	utilruntime.Must(traefikv1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -93,6 +110,12 @@ MIT 2023 cosmo-workspace/cosmo
 			printVersion(cmd)
 			printOptions()
 
+			routing, err := routingProvider()
+			if err != nil {
+				setupLog.Error(err, "invalid routing provider options")
+				os.Exit(1)
+			}
+
 			mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 				Scheme: scheme,
 				Metrics: server.Options{
@@ -115,7 +138,9 @@ MIT 2023 cosmo-workspace/cosmo
 				Client:   mgr.GetClient(),
 				Recorder: mgr.GetEventRecorderFor(instController),
 				Scheme:   mgr.GetScheme(),
-				Domain:   o.TraefikIngressRouteCfg.Domain,
+				Domain:   o.RoutingCfg.Domain,
+
+				FullResyncInterval: o.InstanceFullResync,
 			}).SetupWithManager(mgr, controllerFieldManager); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", instController)
 				os.Exit(1)
@@ -133,7 +158,9 @@ MIT 2023 cosmo-workspace/cosmo
 				Client:   mgr.GetClient(),
 				Recorder: mgr.GetEventRecorderFor(clusterInstController),
 				Scheme:   mgr.GetScheme(),
-				Domain:   o.TraefikIngressRouteCfg.Domain,
+				Domain:   o.RoutingCfg.Domain,
+
+				FullResyncInterval: o.InstanceFullResync,
 			}).SetupWithManager(mgr, controllerFieldManager); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", clusterInstController)
 				os.Exit(1)
@@ -152,18 +179,44 @@ MIT 2023 cosmo-workspace/cosmo
 				Recorder: mgr.GetEventRecorderFor(wsController),
 				Scheme:   mgr.GetScheme(),
 
-				TraefikIngressRouteCfg: &o.TraefikIngressRouteCfg,
-				URLBaseProtocol:        o.WorkspaceURLBaseProtocol,
+				Routing: routing,
 			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", wsController)
 				os.Exit(1)
 			}
 			if err = (&controllers.WorkspaceStatusReconciler{
+				Client:              mgr.GetClient(),
+				Recorder:            mgr.GetEventRecorderFor(wsStatController),
+				Scheme:              mgr.GetScheme(),
+				StatusCheckInterval: time.Duration(o.StatusCheckIntervals) * time.Second,
+				StartTimeout:        o.WorkspaceStartTimeout,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", wsStatController)
+				os.Exit(1)
+			}
+			if err = (&controllers.WorkspaceAutoSuspendReconciler{
+				Client:      mgr.GetClient(),
+				Recorder:    mgr.GetEventRecorderFor(wsAutoSuspendController),
+				Scheme:      mgr.GetScheme(),
+				IdleTimeout: o.WorkspaceIdleTimeout,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", wsAutoSuspendController)
+				os.Exit(1)
+			}
+			if err = (&controllers.WorkspaceScheduleReconciler{
 				Client:   mgr.GetClient(),
-				Recorder: mgr.GetEventRecorderFor(wsStatController),
+				Recorder: mgr.GetEventRecorderFor(wsScheduleController),
 				Scheme:   mgr.GetScheme(),
 			}).SetupWithManager(mgr); err != nil {
-				setupLog.Error(err, "unable to create controller", "controller", wsStatController)
+				setupLog.Error(err, "unable to create controller", "controller", wsScheduleController)
+				os.Exit(1)
+			}
+			if err = (&controllers.WorkspaceSnapshotReconciler{
+				Client:   mgr.GetClient(),
+				Recorder: mgr.GetEventRecorderFor(wsSnapshotController),
+				Scheme:   mgr.GetScheme(),
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", wsSnapshotController)
 				os.Exit(1)
 			}
 			if err = (&controllers.UserReconciler{
@@ -198,6 +251,7 @@ MIT 2023 cosmo-workspace/cosmo
 				Client:  mgr.GetClient(),
 				Log:     clog.NewLogger(ctrl.Log.WithName("WorkspaceValidationWebhook")),
 				Decoder: admission.NewDecoder(mgr.GetScheme()),
+				Routing: routing,
 			}).SetupWebhookWithManager(mgr)
 
 			(&webhooks.UserMutationWebhookHandler{
@@ -217,6 +271,18 @@ MIT 2023 cosmo-workspace/cosmo
 				Decoder: admission.NewDecoder(mgr.GetScheme()),
 			}).SetupWebhookWithManager(mgr)
 
+			// Receiving workspace activity from traefik plugin
+			if o.ActivityToken != "" {
+				(&webhooks.WorkspaceActivityHandler{
+					Client:      mgr.GetClient(),
+					Log:         clog.NewLogger(ctrl.Log.WithName("WorkspaceActivityHandler")),
+					MinInterval: o.ActivityMinInterval,
+					Token:       o.ActivityToken,
+				}).SetupWebhookWithManager(mgr)
+			} else {
+				setupLog.Info("workspace activity endpoint is disabled because --workspace-activity-token is not set")
+			}
+
 			// Serving traefik plugin
 			mgr.GetWebhookServer().Register(
 				"/traefik-plugins.tar.gz",
@@ -253,21 +319,39 @@ MIT 2023 cosmo-workspace/cosmo
 	rootCmd.PersistentFlags().AddGoFlagSet(goflags)
 
 	rootCmd.PersistentFlags().IntVar(&o.Port, "port", 9443, "Port for webhook server")
-	rootCmd.PersistentFlags().Int64Var(&o.StatusCheckIntervals, "statuscheck-interval-seconds", 5, "Status check interval seconds")
+	rootCmd.PersistentFlags().Int64Var(&o.StatusCheckIntervals, "statuscheck-interval-seconds", 5, "Initial interval seconds to check the status of the workspaces not running. The interval is doubled up to 5 minutes while the workspace is starting")
 	rootCmd.PersistentFlags().StringVar(&o.MetricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	rootCmd.PersistentFlags().StringVar(&o.ProbeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	rootCmd.PersistentFlags().StringVar(&o.CertDir, "cert-dir", "/tmp/k8s-webhook-server/serving-certs", "Certificate dir. The server key and certificate must be named tls.key and tls.crt")
-	rootCmd.PersistentFlags().StringVar(&o.WorkspaceURLBaseProtocol, "workspace-urlbase-protocol", "https", "http or https")
-	rootCmd.PersistentFlags().StringVar(&o.TraefikIngressRouteCfg.HostBase, "workspace-urlbase-host", "{{NETRULE}}-{{WORKSPACE}}-{{USER}}", "host template. {{NETRULE}}, {{WORKSPACE}} and {{USER}} are replaced for each URL. you can customize like `{{NETRULE}}-{{WORKSPACE}}-{{USER}}-k3d`")
-	rootCmd.PersistentFlags().StringVar(&o.TraefikIngressRouteCfg.Domain, "workspace-urlbase-domain", "example.com", "domain for workspace url")
+	rootCmd.PersistentFlags().StringVar(&o.RoutingCfg.URLProtocol, "workspace-urlbase-protocol", "https", "http or https")
+	rootCmd.PersistentFlags().StringVar(&o.RoutingCfg.HostBase, "workspace-urlbase-host", "{{NETRULE}}-{{WORKSPACE}}-{{USER}}", "host template. {{NETRULE}}, {{WORKSPACE}} and {{USER}} are replaced for each URL. you can customize like `{{NETRULE}}-{{WORKSPACE}}-{{USER}}-k3d`")
+	rootCmd.PersistentFlags().StringVar(&o.RoutingCfg.Domain, "workspace-urlbase-domain", "example.com", "domain for workspace url")
+	rootCmd.PersistentFlags().DurationVar(&o.WorkspaceIdleTimeout, "workspace-idle-timeout", 0, "Default idle duration to suspend workspaces automatically. 0 disables auto-suspend. It can be overridden by the annotation on Template or Workspace")
+	rootCmd.PersistentFlags().DurationVar(&o.InstanceFullResync, "instance-full-resync-interval", 10*time.Minute, "Interval to compare all the child resources of instances with the desired state by dry-run. The resources not changed since the last apply are skipped between the full resyncs. 0 disables skipping")
+	rootCmd.PersistentFlags().DurationVar(&o.WorkspaceStartTimeout, "workspace-start-timeout", 10*time.Minute, "Duration to mark the workspaces not running as Failed. 0 disables the timeout")
+	rootCmd.PersistentFlags().DurationVar(&o.ActivityMinInterval, "workspace-activity-min-interval", time.Minute, "Minimum interval to record the last accessed time of workspaces")
+	rootCmd.PersistentFlags().StringVar(&o.ActivityToken, "workspace-activity-token", "", "Shared token to authenticate the workspace activity reports from cosmoauth middleware. The endpoint is disabled if empty.")
 	rootCmd.PersistentFlags().BoolVar(&o.EnableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 
+	rootCmd.PersistentFlags().StringVar(&o.RoutingProvider, "routing-provider", workspace.RoutingProviderTraefik, fmt.Sprintf("Provider of the routing resources for workspace network rules. One of %v", workspace.RoutingProviders))
+
 	rootCmd.PersistentFlags().StringSliceVar(&o.TraefikIngressRouteCfg.Entrypoints, "traefik-entrypoints", []string{"web"}, "Traefik ingress entrypoint")
 	rootCmd.PersistentFlags().StringVar(&o.TraefikIngressRouteCfg.AuthenMiddleware.Name, "traefik-authen-middleware", "cosmo-auth", "Traefik authen middleware")
 	rootCmd.PersistentFlags().StringVar(&o.TraefikIngressRouteCfg.AuthenMiddleware.Namespace, "traefik-authen-middleware-namespace", "cosmo-system", "Traefik authen middleware namespace")
 	rootCmd.PersistentFlags().StringVar(&o.TraefikIngressRouteCfg.UserNameHeaderMiddleware.Name, "traefik-username-header-middleware", "cosmo-username-headers", "Traefik username header middleware")
+	rootCmd.PersistentFlags().StringSliceVar(&o.TraefikIngressRouteCfg.TCPEntrypoints, "traefik-tcp-entrypoints", []string{"websecure"}, "Traefik entrypoint for the network rules with tcp protocol. TLS is passed through to the workspace")
+	rootCmd.PersistentFlags().Int32Var(&o.RoutingCfg.TCPPort, "traefik-tcp-entrypoint-port", 443, "External port of the Traefik tcp entrypoint used in the URLs of the network rules with tcp protocol")
+
+	rootCmd.PersistentFlags().StringVar((*string)(&o.GatewayHTTPRouteCfg.ParentRef.Name), "gateway-name", "", "Name of the Gateway which HTTPRoutes are attached to when routing-provider is gateway-api")
+	rootCmd.PersistentFlags().StringVar(&o.GatewayNamespace, "gateway-namespace", "", "Namespace of the Gateway which HTTPRoutes are attached to when routing-provider is gateway-api")
+	rootCmd.PersistentFlags().StringVar((*string)(&o.GatewayHTTPRouteCfg.AuthFilter.Group), "gateway-auth-filter-group", "", "API group of the HTTPRoute extension filter to authenticate private network rules when routing-provider is gateway-api")
+	rootCmd.PersistentFlags().StringVar((*string)(&o.GatewayHTTPRouteCfg.AuthFilter.Kind), "gateway-auth-filter-kind", "", "Kind of the HTTPRoute extension filter to authenticate private network rules when routing-provider is gateway-api")
+	rootCmd.PersistentFlags().StringVar((*string)(&o.GatewayHTTPRouteCfg.AuthFilter.Name), "gateway-auth-filter-name", "", "Name of the HTTPRoute extension filter to authenticate private network rules when routing-provider is gateway-api. The filter must be in each user namespace. Private network rules are not routed if empty")
+
+	rootCmd.PersistentFlags().StringVar(&o.IngressCfg.IngressClassName, "ingress-class", "", "IngressClass of Ingresses when routing-provider is ingress")
+	rootCmd.PersistentFlags().StringToStringVar(&o.IngressCfg.AuthAnnotations, "ingress-auth-annotations", nil, "Annotations on Ingresses to authenticate private network rules when routing-provider is ingress (e.g. nginx.ingress.kubernetes.io/auth-url=https://auth.example.com/?users={{USERS}}). {{USERS}} is replaced with the owner and shared users. Private network rules are not routed if empty")
 
 	if err := rootCmd.Execute(); err != nil {
 		setupLog.Error(err, "problem executing command")
@@ -275,6 +359,32 @@ MIT 2023 cosmo-workspace/cosmo
 	}
 }
 
+func routingProvider() (workspace.RoutingProvider, error) {
+	switch o.RoutingProvider {
+	case workspace.RoutingProviderTraefik:
+		o.TraefikIngressRouteCfg.RoutingConfig = o.RoutingCfg
+		return &o.TraefikIngressRouteCfg, nil
+
+	case workspace.RoutingProviderGatewayAPI:
+		o.GatewayHTTPRouteCfg.RoutingConfig = o.RoutingCfg
+		if o.GatewayHTTPRouteCfg.ParentRef.Name == "" {
+			return nil, fmt.Errorf("gateway-name is required")
+		}
+		if o.GatewayNamespace != "" {
+			o.GatewayHTTPRouteCfg.ParentRef.Namespace = ptr.To(gatewayv1.Namespace(o.GatewayNamespace))
+		}
+		if o.GatewayHTTPRouteCfg.AuthFilter.Name != "" && o.GatewayHTTPRouteCfg.AuthFilter.Kind == "" {
+			return nil, fmt.Errorf("gateway-auth-filter-kind is required")
+		}
+		return &o.GatewayHTTPRouteCfg, nil
+
+	case workspace.RoutingProviderIngress:
+		o.IngressCfg.RoutingConfig = o.RoutingCfg
+		return &o.IngressCfg, nil
+	}
+	return nil, fmt.Errorf("unknown routing provider: %s", o.RoutingProvider)
+}
+
 func printOptions() {
 	rv := reflect.ValueOf(*o)
 	rt := rv.Type()
@@ -283,6 +393,10 @@ func printOptions() {
 	for i := 0; i < rt.NumField(); i++ {
 		options[i*2] = rt.Field(i).Name
 		options[i*2+1] = rv.Field(i).Interface()
+		// do not print secret values such as tokens
+		if rt.Field(i).Tag.Get("secret") == "true" && !rv.Field(i).IsZero() {
+			options[i*2+1] = "********"
+		}
 	}
 
 	setupLog.Info("options", options...)
@@ -36,6 +36,10 @@ For create generated Workspace Template, just do kubectl apply
 	
       kustomize build ./kubernetes/ | cosmoctl gen tmpl --name TEMPLATE_NAME | kubectl apply -f -
 
+  * Build the kustomization directory without kustomize binary
+
+      cosmoctl gen tmpl --name TEMPLATE_NAME --kustomize ./kubernetes/overlays/dev | kubectl apply -f -
+
   * Input merged config file (kustomize build ... or helm template ... etc.) and save it to file
 
       cosmoctl gen tmpl --name TEMPLATE_NAME -o cosmo-template.yaml < merged.yaml
@@ -54,6 +58,10 @@ For create generated UserAddon Template, just do kubectl apply
 	
       kustomize build ./kubernetes/ | cosmoctl gen addon --name TEMPLATE_NAME | kubectl apply -f -
 
+  * Build the kustomization directory without kustomize binary
+
+      cosmoctl gen addon --name TEMPLATE_NAME --kustomize ./kubernetes/overlays/dev | kubectl apply -f -
+
   * Input merged config file (kustomize build ... or helm template ... etc.) and save it to file
 
       cosmoctl gen addon --name TEMPLATE_NAME -o cosmo-template.yaml < merged.yaml
@@ -80,6 +88,26 @@ For create generated UserAddon Template, just do kubectl apply
 `,
 	}, o))
 
+	templateCmd.AddCommand(diffCmd(&cobra.Command{
+		Use:   "diff --file FILE",
+		Short: "Show changes of live workspaces by dry-run of the template",
+		Long: `Show changes of live workspaces by dry-run of the template
+
+For each workspace using the template, the resources are built from the input template and dry-run applied on server-side.
+The differences from the live resources are printed per workspace.
+Workspaces pinned to a template revision are not shown as they are not affected by the change.
+`,
+		Example: `
+  * Show changes before applying the new template
+
+      cosmoctl template diff -f cosmo-template.yaml
+
+  * Input from stdin not file.
+
+      cat cosmo-template.yaml | cosmoctl template diff -f - --domain example.com
+`,
+	}, o))
+
 	getCmd := &cobra.Command{
 		Use:     "get",
 		Short:   "Get Templates",
@@ -96,6 +124,35 @@ For create generated UserAddon Template, just do kubectl apply
 		Aliases: []string{"useraddon", "addons", "addon", "user-addon"},
 	}, o))
 
+	getCmd.AddCommand(getRevisionsCmd(&cobra.Command{
+		Use:     "revisions TEMPLATE_NAME",
+		Short:   "Get revisions of workspace template",
+		Aliases: []string{"revision", "rev"},
+	}, o))
+
+	templateCmd.AddCommand(rolloutCmd(&cobra.Command{
+		Use:   "rollout TEMPLATE_NAME",
+		Short: "Roll out the template revision to workspaces in batches",
+		Long: `Roll out the template revision to workspaces in batches
+
+The template must be annotated with "cosmo-workspace.github.io/pin-revision: true" to record revisions.
+Workspaces are migrated in batches with a pause, and the rollout stops if the running workspaces are not running after the pause.
+`,
+		Example: `
+  * Roll out the latest revision to all workspaces using the template
+	
+      cosmoctl template rollout TEMPLATE_NAME -k
+
+  * Roll out the specific revision in batches of 5 workspaces with 3 minutes pause
+
+      cosmoctl template rollout TEMPLATE_NAME -k --to-revision 2 --batch-size 5 --pause 3m
+
+  * Roll back the workspaces on the latest revision to the previous revision
+
+      cosmoctl template rollout TEMPLATE_NAME -k --undo
+`,
+	}, o))
+
 	templateCmd.AddCommand(getCmd)
 	templateCmd.AddCommand(generateCmd)
 	cmd.AddCommand(templateCmd)
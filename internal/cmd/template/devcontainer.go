@@ -0,0 +1,493 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+const (
+	devcontainerWorkloadName = "workspace"
+	devcontainerMainPortName = "main"
+
+	// DevcontainerVarImage is the template var for the container image when devcontainer.json does not specify the image
+	DevcontainerVarImage = "{{IMAGE}}"
+	// DevcontainerVarStorageSize is the template var for the size of the PersistentVolumeClaims
+	DevcontainerVarStorageSize = "{{STORAGE_SIZE}}"
+
+	devcontainerDefaultStorageSize = "20Gi"
+)
+
+// devcontainer is the subset of devcontainer.json used to generate the workspace template.
+// https://containers.dev/implementors/json_reference/
+type devcontainer struct {
+	Name              string            `json:"name"`
+	Image             string            `json:"image"`
+	Build             json.RawMessage   `json:"build"`
+	DockerComposeFile json.RawMessage   `json:"dockerComposeFile"`
+	ForwardPorts      []json.RawMessage `json:"forwardPorts"`
+	AppPort           json.RawMessage   `json:"appPort"`
+	ContainerEnv      map[string]string `json:"containerEnv"`
+	RemoteEnv         map[string]string `json:"remoteEnv"`
+	WorkspaceFolder   string            `json:"workspaceFolder"`
+	Mounts            []json.RawMessage `json:"mounts"`
+	OverrideCommand   *bool             `json:"overrideCommand"`
+	ContainerUser     string            `json:"containerUser"`
+	RemoteUser        string            `json:"remoteUser"`
+	RunArgs           []string          `json:"runArgs"`
+	Features          map[string]any    `json:"features"`
+	PostCreateCommand json.RawMessage   `json:"postCreateCommand"`
+	PostStartCommand  json.RawMessage   `json:"postStartCommand"`
+
+	// dir is the name of the directory which has the .devcontainer directory
+	dir string
+}
+
+type devcontainerMount struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+// loadDevcontainer loads devcontainer.json from the file or the directory.
+// In case of the directory, .devcontainer/devcontainer.json, .devcontainer.json or devcontainer.json is loaded.
+func loadDevcontainer(p string) (*devcontainer, error) {
+	st, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	file := p
+	if st.IsDir() {
+		file = ""
+		for _, f := range []string{filepath.Join(".devcontainer", "devcontainer.json"), ".devcontainer.json", "devcontainer.json"} {
+			if _, err := os.Stat(filepath.Join(p, f)); err == nil {
+				file = filepath.Join(p, f)
+				break
+			}
+		}
+		if file == "" {
+			return nil, fmt.Errorf("devcontainer.json is not found in %s", p)
+		}
+	}
+
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	var d devcontainer
+	if err := json.Unmarshal(stripJSONC(b), &d); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(abs)
+	if filepath.Base(dir) == ".devcontainer" {
+		dir = filepath.Dir(dir)
+	}
+	d.dir = filepath.Base(dir)
+	return &d, nil
+}
+
+// stripJSONC removes the comments and the trailing commas of JSON with comments
+func stripJSONC(b []byte) []byte {
+	return stripTrailingCommas(stripComments(b))
+}
+
+func stripComments(b []byte) []byte {
+	var out bytes.Buffer
+	inString, escaped := false, false
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		switch {
+		case inString:
+			out.WriteByte(c)
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '/' && i+1 < len(b) && b[i+1] == '/':
+			for i+1 < len(b) && b[i+1] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(b) && b[i+1] == '*':
+			end := bytes.Index(b[i+2:], []byte("*/"))
+			if end < 0 {
+				return out.Bytes()
+			}
+			i += end + 3
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.Bytes()
+}
+
+func stripTrailingCommas(b []byte) []byte {
+	var out bytes.Buffer
+	inString, escaped := false, false
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		switch {
+		case inString:
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == ',':
+			next := bytes.TrimLeft(b[i+1:], " \t\r\n")
+			if len(next) > 0 && (next[0] == '}' || next[0] == ']') {
+				continue
+			}
+		}
+		out.WriteByte(c)
+	}
+	return out.Bytes()
+}
+
+// ports returns the container ports to be forwarded. Ports on the other hosts are ignored.
+func (d *devcontainer) ports() ([]int32, []string) {
+	warnings := make([]string, 0)
+	raws := append([]json.RawMessage{}, d.ForwardPorts...)
+	if len(d.AppPort) > 0 {
+		var list []json.RawMessage
+		if err := json.Unmarshal(d.AppPort, &list); err == nil {
+			raws = append(raws, list...)
+		} else {
+			raws = append(raws, d.AppPort)
+		}
+	}
+
+	ports := make([]int32, 0, len(raws))
+	seen := make(map[int32]bool)
+	for _, raw := range raws {
+		var port int32
+		var n int32
+		var s string
+		if err := json.Unmarshal(raw, &n); err == nil {
+			port = n
+		} else if err := json.Unmarshal(raw, &s); err == nil {
+			// "host:port" or "hostPort:containerPort"
+			host, p, found := strings.Cut(s, ":")
+			if !found {
+				p = host
+			} else if _, err := strconv.Atoi(host); err != nil && host != "localhost" {
+				warnings = append(warnings, fmt.Sprintf("port %s on other host is not supported", s))
+				continue
+			}
+			i, err := strconv.ParseInt(p, 10, 32)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("invalid port %s", s))
+				continue
+			}
+			port = int32(i)
+		} else {
+			warnings = append(warnings, fmt.Sprintf("invalid port %s", string(raw)))
+			continue
+		}
+		if port <= 0 || seen[port] {
+			continue
+		}
+		seen[port] = true
+		ports = append(ports, port)
+	}
+	return ports, warnings
+}
+
+// mounts returns the volume mounts. Bind mounts are not supported.
+func (d *devcontainer) mounts() ([]devcontainerMount, []string) {
+	warnings := make([]string, 0)
+	mounts := make([]devcontainerMount, 0, len(d.Mounts))
+	for _, raw := range d.Mounts {
+		var m devcontainerMount
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			for _, kv := range strings.Split(s, ",") {
+				k, v, _ := strings.Cut(kv, "=")
+				switch strings.TrimSpace(k) {
+				case "source", "src":
+					m.Source = v
+				case "target", "destination", "dst":
+					m.Target = v
+				case "type":
+					m.Type = v
+				}
+			}
+		} else if err := json.Unmarshal(raw, &m); err != nil {
+			warnings = append(warnings, fmt.Sprintf("invalid mount %s", string(raw)))
+			continue
+		}
+		if m.Type != "volume" || m.Target == "" {
+			warnings = append(warnings, fmt.Sprintf("mount %s is not supported. only volume mount is supported", string(raw)))
+			continue
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts, warnings
+}
+
+// unsupportedWarnings returns the warnings for the properties which cannot be converted to the template
+func (d *devcontainer) unsupportedWarnings() []string {
+	warnings := make([]string, 0)
+	features := make([]string, 0, len(d.Features))
+	for f := range d.Features {
+		features = append(features, f)
+	}
+	sort.Strings(features)
+	for _, f := range features {
+		warnings = append(warnings, fmt.Sprintf("feature %s is not supported. install it in the image instead", f))
+	}
+	if len(d.Build) > 0 {
+		warnings = append(warnings, fmt.Sprintf("build is not supported. build and push the image, and set it to var %s", DevcontainerVarImage))
+	}
+	if len(d.DockerComposeFile) > 0 {
+		warnings = append(warnings, "dockerComposeFile is not supported")
+	}
+	if len(d.RunArgs) > 0 {
+		warnings = append(warnings, "runArgs is not supported")
+	}
+	if d.ContainerUser != "" || d.RemoteUser != "" {
+		warnings = append(warnings, "containerUser and remoteUser are not supported. the user of the image is used")
+	}
+	if len(d.PostCreateCommand) > 0 || len(d.PostStartCommand) > 0 {
+		warnings = append(warnings, "postCreateCommand and postStartCommand are not supported")
+	}
+	return warnings
+}
+
+// workspaceFolder returns the path of the workspace folder in the container
+func (d *devcontainer) workspaceFolder() string {
+	if d.WorkspaceFolder != "" {
+		return d.WorkspaceFolder
+	}
+	return path.Join("/workspaces", d.dir)
+}
+
+// Manifests returns the manifests of the workspace converted from devcontainer.json, the workspace config, the required vars and the warnings.
+func (d *devcontainer) Manifests() (string, cosmov1alpha1.Config, []string, []string, error) {
+	var cfg cosmov1alpha1.Config
+	warnings := d.unsupportedWarnings()
+
+	vars := []string{fmt.Sprintf("%s:%s", DevcontainerVarStorageSize, devcontainerDefaultStorageSize)}
+	image := d.Image
+	if image == "" {
+		image = DevcontainerVarImage
+		vars = append(vars, DevcontainerVarImage)
+	}
+
+	ports, w := d.ports()
+	warnings = append(warnings, w...)
+	if len(ports) == 0 {
+		return "", cfg, nil, warnings, errors.New("no forwardPorts in devcontainer.json. the main port of the workspace is required")
+	}
+
+	mounts, w := d.mounts()
+	warnings = append(warnings, w...)
+	mounts = append([]devcontainerMount{{Source: devcontainerWorkloadName, Target: d.workspaceFolder(), Type: "volume"}}, mounts...)
+	volumeNames := make(map[string]bool)
+
+	labels := map[string]string{"app.kubernetes.io/name": devcontainerWorkloadName}
+
+	container := corev1.Container{
+		Name:       devcontainerWorkloadName,
+		Image:      image,
+		WorkingDir: d.workspaceFolder(),
+	}
+	if d.OverrideCommand == nil || *d.OverrideCommand {
+		container.Command = []string{"/bin/sh", "-c", "trap 'exit 0' TERM; sleep infinity & wait"}
+	}
+	var skipped []string
+	container.Env, skipped = devcontainerEnv(d.ContainerEnv, d.RemoteEnv)
+	for _, k := range skipped {
+		warnings = append(warnings, fmt.Sprintf("env %s is skipped as variables in devcontainer.json are not supported", k))
+	}
+
+	svc := &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Name: devcontainerWorkloadName},
+		Spec:       corev1.ServiceSpec{Selector: labels},
+	}
+	for i, p := range ports {
+		name := fmt.Sprintf("port%d", p)
+		if i == 0 {
+			name = devcontainerMainPortName
+		}
+		container.Ports = append(container.Ports, corev1.ContainerPort{Name: name, ContainerPort: p, Protocol: corev1.ProtocolTCP})
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{Name: name, Port: p, Protocol: corev1.ProtocolTCP, TargetPort: intstr.FromString(name)})
+	}
+
+	pvcs := make([]runtime.Object, 0, len(mounts))
+	volumes := make([]corev1.Volume, 0, len(mounts))
+	for _, m := range mounts {
+		name := devcontainerVolumeName(m)
+		if name == "" || volumeNames[name] {
+			warnings = append(warnings, fmt.Sprintf("mount %s:%s is skipped as the volume name is empty or duplicated", m.Source, m.Target))
+			continue
+		}
+		volumeNames[name] = true
+		pvcs = append(pvcs, &corev1.PersistentVolumeClaim{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(devcontainerDefaultStorageSize)},
+				},
+			},
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name:         name,
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: name}},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: name, MountPath: m.Target})
+	}
+
+	deploy := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: devcontainerWorkloadName},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{container},
+					Volumes:    volumes,
+				},
+			},
+		},
+	}
+
+	docs := make([]string, 0, len(pvcs)+2)
+	for _, obj := range append([]runtime.Object{deploy, svc}, pvcs...) {
+		doc, err := devcontainerManifest(obj)
+		if err != nil {
+			return "", cfg, nil, warnings, err
+		}
+		docs = append(docs, doc)
+	}
+	// storage size is replaced after marshaling as Quantity cannot hold the var
+	manifests := strings.ReplaceAll(strings.Join(docs, "---\n"), "storage: "+devcontainerDefaultStorageSize, fmt.Sprintf("storage: '%s'", DevcontainerVarStorageSize))
+
+	cfg = cosmov1alpha1.Config{
+		DeploymentName:      devcontainerWorkloadName,
+		ServiceName:         devcontainerWorkloadName,
+		ServiceMainPortName: devcontainerMainPortName,
+	}
+	return manifests, cfg, vars, warnings, nil
+}
+
+// devcontainerEnv merges the envs and returns the env vars sorted by name and the names of skipped envs
+func devcontainerEnv(envs ...map[string]string) ([]corev1.EnvVar, []string) {
+	merged := make(map[string]string)
+	skipped := make([]string, 0)
+	for _, env := range envs {
+		for k, v := range env {
+			if strings.Contains(v, "${") {
+				// variables like ${localEnv:XXX} are resolved by devcontainer CLI and not available
+				skipped = append(skipped, k)
+				continue
+			}
+			merged[k] = v
+		}
+	}
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	env := make([]corev1.EnvVar, 0, len(keys))
+	for _, k := range keys {
+		env = append(env, corev1.EnvVar{Name: k, Value: merged[k]})
+	}
+	sort.Strings(skipped)
+	return env, skipped
+}
+
+func devcontainerVolumeName(m devcontainerMount) string {
+	name := m.Source
+	if name == "" {
+		name = path.Base(m.Target)
+	}
+	return sanitizeDNS1123Label(name)
+}
+
+// devcontainerTemplateName returns the template name derived from the project directory name
+func devcontainerTemplateName(dir string) (string, error) {
+	name := sanitizeDNS1123Label(dir)
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("invalid template name '%s': %s", name, strings.Join(errs, ", "))
+	}
+	return name, nil
+}
+
+// sanitizeDNS1123Label lowercases the name and replaces the characters not allowed in DNS-1123 label with '-'
+func sanitizeDNS1123Label(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(name))
+	return strings.Trim(name, "-")
+}
+
+// devcontainerManifest returns the yaml of the object without the empty status and creationTimestamp
+func devcontainerManifest(obj runtime.Object) (string, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err
+	}
+	delete(u, "status")
+	removeCreationTimestamp(u)
+	b, err := yaml.Marshal(u)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func removeCreationTimestamp(obj map[string]any) {
+	for k, v := range obj {
+		if k == "creationTimestamp" && v == nil {
+			delete(obj, k)
+			continue
+		}
+		if m, ok := v.(map[string]any); ok {
+			removeCreationTimestamp(m)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package template
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/template"
+)
+
+func TestStripJSONC(t *testing.T) {
+	input := `// comment
+{
+  "url": "http://example.com//path", /* block
+  comment */
+  "list": [1, 2,],
+  "str": "a,]",
+}`
+	var got map[string]any
+	if err := json.Unmarshal(stripJSONC([]byte(input)), &got); err != nil {
+		t.Fatalf("failed to unmarshal: %v: %s", err, stripJSONC([]byte(input)))
+	}
+	want := map[string]any{
+		"url":  "http://example.com//path",
+		"list": []any{float64(1), float64(2)},
+		"str":  "a,]",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("stripJSONC() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDevcontainer_Manifests(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "myproj")
+	if err := os.MkdirAll(filepath.Join(dir, ".devcontainer"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	input := `{
+  // devcontainer
+  "name": "My Project",
+  "forwardPorts": [8080, "3000", "db:5432"],
+  "containerEnv": {"FOO": "bar", "HOME2": "${localEnv:HOME}"},
+  "mounts": ["source=gocache,target=/go/pkg,type=volume", {"source": "/tmp", "target": "/tmp", "type": "bind"}],
+  "features": {"ghcr.io/devcontainers/features/go:1": {}},
+}`
+	if err := os.WriteFile(filepath.Join(dir, ".devcontainer", "devcontainer.json"), []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := loadDevcontainer(dir)
+	if err != nil {
+		t.Fatalf("loadDevcontainer() error = %v", err)
+	}
+	if d.dir != "myproj" {
+		t.Errorf("dir = %s, want myproj", d.dir)
+	}
+
+	manifests, cfg, vars, warnings, err := d.Manifests()
+	if err != nil {
+		t.Fatalf("Manifests() error = %v", err)
+	}
+
+	wantCfg := cosmov1alpha1.Config{DeploymentName: "workspace", ServiceName: "workspace", ServiceMainPortName: "main"}
+	if diff := cmp.Diff(wantCfg, cfg); diff != "" {
+		t.Errorf("Manifests() config mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"{{STORAGE_SIZE}}:20Gi", "{{IMAGE}}"}, vars); diff != "" {
+		t.Errorf("Manifests() vars mismatch (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 4 {
+		t.Errorf("Manifests() warnings = %v, want 4 warnings", warnings)
+	}
+
+	objects, err := template.NewRawYAMLBuilder(manifests).Build()
+	if err != nil {
+		t.Fatalf("failed to build manifests: %v\n%s", err, manifests)
+	}
+	kinds := make([]string, len(objects))
+	for i, o := range objects {
+		kinds[i] = o.GetKind() + "/" + o.GetName()
+	}
+	wantKinds := []string{"Deployment/workspace", "Service/workspace", "PersistentVolumeClaim/workspace", "PersistentVolumeClaim/gocache"}
+	if diff := cmp.Diff(wantKinds, kinds); diff != "" {
+		t.Errorf("Manifests() objects mismatch (-want +got):\n%s", diff)
+	}
+	for _, want := range []string{"image: '{{IMAGE}}'", "mountPath: /workspaces/myproj", "name: FOO", "storage: '{{STORAGE_SIZE}}'"} {
+		if !strings.Contains(manifests, want) {
+			t.Errorf("manifests do not contain %q:\n%s", want, manifests)
+		}
+	}
+
+	if err := completeWorkspaceConfig(&cfg, objects); err != nil {
+		t.Errorf("completeWorkspaceConfig() error = %v", err)
+	}
+}
+
+func TestDevcontainerTemplateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		dir     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "✅ valid name",
+			dir:  "myproj",
+			want: "myproj",
+		},
+		{
+			name: "✅ sanitized name",
+			dir:  "My_Project.v2 ",
+			want: "my-project-v2",
+		},
+		{
+			name:    "❌ no valid characters",
+			dir:     "___",
+			wantErr: true,
+		},
+		{
+			name:    "❌ too long",
+			dir:     strings.Repeat("a", 254),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := devcontainerTemplateName(tt.dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("devcontainerTemplateName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("devcontainerTemplateName() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
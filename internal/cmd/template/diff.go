@@ -0,0 +1,184 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/apiconv"
+	"github.com/cosmo-workspace/cosmo/pkg/cli"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	dashv1alpha1 "github.com/cosmo-workspace/cosmo/proto/gen/dashboard/v1alpha1"
+)
+
+type diffOption struct {
+	*cli.RootOptions
+
+	File   string
+	Domain string
+
+	input []byte
+	tmpl  cosmov1alpha1.Template
+}
+
+func diffCmd(cmd *cobra.Command, cliOpt *cli.RootOptions) *cobra.Command {
+	o := &diffOption{RootOptions: cliOpt}
+	cmd.RunE = cli.ConnectErrorHandler(o)
+
+	cmd.Flags().StringVarP(&o.File, "file", "f", "", "input COSMO Template file yaml path. when specified '-', input from Stdin")
+	cmd.Flags().StringVar(&o.Domain, "domain", "", "domain to replace {{DOMAIN}} in the template. set the same value as --workspace-urlbase-domain of controller-manager")
+
+	return cmd
+}
+
+func (o *diffOption) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Validate(cmd, args); err != nil {
+		return err
+	}
+	if o.File == "" {
+		return errors.New("--file is required")
+	}
+	return nil
+}
+
+func (o *diffOption) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	var input []byte
+	var err error
+	if o.File == "-" {
+		if isatty.IsTerminal(os.Stdin.Fd()) {
+			return fmt.Errorf("no input via stdin")
+		}
+		input, err = io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+	} else {
+		input, err = os.ReadFile(o.File)
+		if err != nil {
+			return fmt.Errorf("failed to read input file: %w", err)
+		}
+	}
+	if len(input) == 0 {
+		return fmt.Errorf("no input")
+	}
+	o.Logr.DebugAll().Info(string(input))
+	o.input = input
+
+	if err := yaml.Unmarshal(input, &o.tmpl); err != nil {
+		return fmt.Errorf("failed to unmarshal yaml: %w", err)
+	}
+	if o.tmpl.Name == "" {
+		return errors.New("template name is empty")
+	}
+
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return nil
+}
+
+func (o *diffOption) RunE(cmd *cobra.Command, args []string) error {
+	if err := o.Validate(cmd, args); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	if err := o.Complete(cmd, args); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(o.Ctx, time.Minute*3)
+	defer cancel()
+	ctx = clog.IntoContext(ctx, o.Logr)
+
+	var (
+		diffs []*dashv1alpha1.InstanceDiff
+		err   error
+	)
+	if o.UseKubeAPI {
+		diffs, err = o.DiffByKubeClient(ctx)
+	} else {
+		diffs, err = o.DiffWithDashClient(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(diffs) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), color.GreenString("No workspaces using template %s", o.tmpl.Name))
+		return nil
+	}
+	OutputDiff(cmd.OutOrStdout(), diffs)
+	return nil
+}
+
+func (o *diffOption) DiffByKubeClient(ctx context.Context) ([]*dashv1alpha1.InstanceDiff, error) {
+	diffs, err := o.KosmoClient.DiffTemplate(ctx, &o.tmpl, o.Domain)
+	if err != nil {
+		return nil, err
+	}
+	return apiconv.C2D_InstanceDiffs(diffs), nil
+}
+
+func (o *diffOption) DiffWithDashClient(ctx context.Context) ([]*dashv1alpha1.InstanceDiff, error) {
+	req := &dashv1alpha1.DiffWorkspaceTemplateRequest{
+		Raw: string(o.input),
+	}
+	if o.Domain != "" {
+		req.Domain = &o.Domain
+	}
+	c := o.CosmoDashClient
+	res, err := c.TemplateServiceClient.DiffWorkspaceTemplate(ctx, cli.NewRequestWithToken(req, o.CliConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect dashboard server: %w", err)
+	}
+	o.Logr.DebugAll().Info("TemplateServiceClient.DiffWorkspaceTemplate", "res", res)
+	return res.Msg.Items, nil
+}
+
+// OutputDiff prints the changed objects of each workspace and the summary
+func OutputDiff(w io.Writer, diffs []*dashv1alpha1.InstanceDiff) {
+	changed := 0
+	for _, d := range diffs {
+		fmt.Fprintf(w, "=== %s/%s\n", d.UserName, d.WorkspaceName)
+		if d.Error != "" {
+			fmt.Fprintln(w, color.RedString("ERROR: %s", d.Error))
+			continue
+		}
+
+		instChanged := false
+		for _, obj := range d.Objects {
+			ref := fmt.Sprintf("%s %s %s", obj.ApiVersion, obj.Kind, obj.Name)
+			switch {
+			case obj.Error != "":
+				fmt.Fprintln(w, color.RedString("! %s: %s", ref, obj.Error))
+			case obj.Created:
+				instChanged = true
+				fmt.Fprintln(w, color.GreenString("+ %s will be created", ref))
+			case obj.Diff != "":
+				instChanged = true
+				fmt.Fprintln(w, color.YellowString("~ %s will be updated", ref))
+				for _, l := range strings.Split(strings.TrimRight(obj.Diff, "\n"), "\n") {
+					fmt.Fprintf(w, "    %s\n", l)
+				}
+			}
+		}
+		if instChanged {
+			changed++
+		} else {
+			fmt.Fprintln(w, "no changes")
+		}
+	}
+	fmt.Fprintf(w, "\n%d of %d workspaces will be changed\n", changed, len(diffs))
+}
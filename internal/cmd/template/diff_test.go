@@ -0,0 +1,54 @@
+package template
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+
+	dashv1alpha1 "github.com/cosmo-workspace/cosmo/proto/gen/dashboard/v1alpha1"
+)
+
+func TestOutputDiff(t *testing.T) {
+	color.NoColor = true
+
+	diffs := []*dashv1alpha1.InstanceDiff{
+		{
+			UserName:      "tom",
+			WorkspaceName: "ws1",
+			Objects: []*dashv1alpha1.ObjectDiff{
+				{ApiVersion: "apps/v1", Kind: "Deployment", Name: "ws1-workspace", Diff: "-  replicas: 1\n+  replicas: 2\n"},
+				{ApiVersion: "v1", Kind: "Service", Name: "ws1-workspace", Created: true},
+				{ApiVersion: "v1", Kind: "ConfigMap", Name: "ws1-cm"},
+			},
+		},
+		{
+			UserName:      "tom",
+			WorkspaceName: "ws2",
+			Objects: []*dashv1alpha1.ObjectDiff{
+				{ApiVersion: "v1", Kind: "ConfigMap", Name: "ws2-cm"},
+			},
+		},
+		{
+			UserName:      "tom",
+			WorkspaceName: "ws3",
+			Error:         "failed to build objects",
+		},
+	}
+
+	var buf bytes.Buffer
+	OutputDiff(&buf, diffs)
+	got := buf.String()
+
+	for _, want := range []string{
+		"=== tom/ws1\n~ apps/v1 Deployment ws1-workspace will be updated\n    -  replicas: 1\n    +  replicas: 2\n+ v1 Service ws1-workspace will be created\n",
+		"=== tom/ws2\nno changes\n",
+		"=== tom/ws3\nERROR: failed to build objects\n",
+		"1 of 3 workspaces will be changed\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("OutputDiff() = %q, want to contain %q", got, want)
+		}
+	}
+}
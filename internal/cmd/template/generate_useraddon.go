@@ -18,6 +18,7 @@ type generateUserAddonOption struct {
 	RequiredVars       []string
 	Desc               string
 	NoHeader           bool
+	KustomizeDir       string
 	UserRoles          []string
 	RequiredUserAddons []string
 	SetDefault         bool
@@ -34,6 +35,7 @@ func generateUserAddonCmd(cmd *cobra.Command, cliOpt *cli.RootOptions) *cobra.Co
 	cmd.Flags().StringSliceVar(&o.RequiredVars, "var", []string{}, "template custom vars. format --var=VAR1 --var=VAR2:default-value")
 	cmd.Flags().StringVar(&o.Desc, "desc", "", "template description")
 	cmd.Flags().BoolVar(&o.NoHeader, "no-header", false, "no output headers")
+	cmd.Flags().StringVar(&o.KustomizeDir, "kustomize", "", "build the kustomization directory as input instead of Stdin")
 	cmd.Flags().StringSliceVar(&o.UserRoles, "userroles", []string{}, "user roles only to show this template (e.g. 'teama-*', 'teamb-admin', etc.)")
 	cmd.Flags().StringSliceVar(&o.RequiredUserAddons, "required-useraddons", []string{}, "add dependency to use this useraddon")
 
@@ -84,7 +86,7 @@ func (o *generateUserAddonOption) RunE(cmd *cobra.Command, args []string) error
 		return fmt.Errorf("invalid options: %w", err)
 	}
 
-	input, err := cli.ReadFromPipedStdin()
+	input, err := readInput(o.Ctx, o.KustomizeDir)
 	if err != nil {
 		return err
 	}
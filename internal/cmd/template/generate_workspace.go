@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -15,6 +18,7 @@ import (
 	"github.com/cosmo-workspace/cosmo/pkg/cli"
 	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
 	"github.com/cosmo-workspace/cosmo/pkg/template"
+	"github.com/cosmo-workspace/cosmo/pkg/workspace"
 )
 
 type generateWorkspaceOption struct {
@@ -24,9 +28,13 @@ type generateWorkspaceOption struct {
 	RequiredVars       []string
 	Desc               string
 	NoHeader           bool
+	KustomizeDir       string
+	FromDevcontainer   string
 	UserRoles          []string
 	RequiredUserAddons []string
 	wsConfig           cosmov1alpha1.Config
+
+	additionalWorkloads []string
 }
 
 func generateWorkspaceCmd(cmd *cobra.Command, cliOpt *cli.RootOptions) *cobra.Command {
@@ -38,10 +46,14 @@ func generateWorkspaceCmd(cmd *cobra.Command, cliOpt *cli.RootOptions) *cobra.Co
 	cmd.Flags().StringSliceVar(&o.RequiredVars, "var", []string{}, "indicate template custom vars. format --var=VAR1 --var=VAR2:default-value")
 	cmd.Flags().StringVar(&o.Desc, "desc", "", "template description")
 	cmd.Flags().BoolVar(&o.NoHeader, "no-header", false, "no output headers")
+	cmd.Flags().StringVar(&o.KustomizeDir, "kustomize", "", "build the kustomization directory as input instead of Stdin")
+	cmd.Flags().StringVar(&o.FromDevcontainer, "from-devcontainer", "", "generate from devcontainer.json in the directory or the file instead of Stdin")
 	cmd.Flags().StringSliceVar(&o.UserRoles, "userroles", []string{}, "user roles only to show this template (e.g. 'teama-*', 'teamb-admin', etc.)")
 	cmd.Flags().StringSliceVar(&o.RequiredUserAddons, "required-useraddons", []string{}, "add dependency to use this useraddon")
 
-	cmd.Flags().StringVar(&o.wsConfig.DeploymentName, "deployment", "", "Deployment name for Workspace (auto detected if not specified)")
+	cmd.Flags().StringVar(&o.wsConfig.DeploymentName, "deployment", "", "Deployment or StatefulSet name of the main workload for Workspace (auto detected if not specified)")
+	cmd.Flags().StringVar(&o.wsConfig.WorkloadKind, "workload-kind", "", "kind of the main workload for Workspace. Deployment or StatefulSet (auto detected if not specified)")
+	cmd.Flags().StringSliceVar(&o.additionalWorkloads, "additional-workloads", []string{}, "workloads suspended and resumed together with the main workload. format --additional-workloads=Deployment/NAME,StatefulSet/NAME")
 	cmd.Flags().StringVar(&o.wsConfig.ServiceName, "service", "", "Service name for Workspace (auto detected if not specified)")
 	cmd.Flags().StringVar(&o.wsConfig.ServiceMainPortName, "main-service-port", "", "ServicePort name for Workspace main container port (auto detected if not specified)")
 
@@ -52,6 +64,12 @@ func (o *generateWorkspaceOption) Validate(cmd *cobra.Command, args []string) er
 	if err := o.RootOptions.Validate(cmd, args); err != nil {
 		return err
 	}
+	if o.KustomizeDir != "" && o.FromDevcontainer != "" {
+		return errors.New("--kustomize and --from-devcontainer cannot be specified at the same time")
+	}
+	if o.wsConfig.WorkloadKind != "" && !cosmov1alpha1.IsValidWorkloadKind(o.wsConfig.WorkloadKind) {
+		return fmt.Errorf("--workload-kind must be %s or %s", cosmov1alpha1.WorkloadKindDeployment, cosmov1alpha1.WorkloadKindStatefulSet)
+	}
 	return nil
 }
 
@@ -59,7 +77,7 @@ func (o *generateWorkspaceOption) Complete(cmd *cobra.Command, args []string) er
 	if err := o.RootOptions.CompleteWithoutClient(cmd, args); err != nil {
 		return err
 	}
-	if o.Name == "" {
+	if o.Name == "" && o.FromDevcontainer == "" {
 		dir, err := os.Getwd()
 		if err != nil {
 			return err
@@ -67,6 +85,14 @@ func (o *generateWorkspaceOption) Complete(cmd *cobra.Command, args []string) er
 		o.Name = filepath.Base(dir)
 	}
 
+	if len(o.additionalWorkloads) > 0 {
+		refs, err := workspace.ParseWorkloadRefs(strings.Join(o.additionalWorkloads, ","))
+		if err != nil {
+			return fmt.Errorf("invalid --additional-workloads: %w", err)
+		}
+		o.wsConfig.AdditionalWorkloads = refs
+	}
+
 	if o.OutputFile != "" {
 		outFile, err := filepath.Abs(o.OutputFile)
 		if err != nil {
@@ -85,7 +111,13 @@ func (o *generateWorkspaceOption) RunE(cmd *cobra.Command, args []string) error
 		return fmt.Errorf("invalid options: %w", err)
 	}
 
-	input, err := cli.ReadFromPipedStdin()
+	var input string
+	var err error
+	if o.FromDevcontainer != "" {
+		input, err = o.devcontainerInput(cmd)
+	} else {
+		input, err = readInput(o.Ctx, o.KustomizeDir)
+	}
 	if err != nil {
 		return err
 	}
@@ -128,39 +160,107 @@ func (o *generateWorkspaceOption) RunE(cmd *cobra.Command, args []string) error
 	return nil
 }
 
+// devcontainerInput returns the manifests converted from devcontainer.json and completes the options by it
+func (o *generateWorkspaceOption) devcontainerInput(cmd *cobra.Command) (string, error) {
+	d, err := loadDevcontainer(o.FromDevcontainer)
+	if err != nil {
+		return "", err
+	}
+	input, cfg, vars, warnings, err := d.Manifests()
+	for _, w := range warnings {
+		fmt.Fprintln(cmd.ErrOrStderr(), color.YellowString("WARNING: %s", w))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to convert devcontainer.json: %w", err)
+	}
+
+	if o.Name == "" {
+		name, err := devcontainerTemplateName(d.dir)
+		if err != nil {
+			return "", fmt.Errorf("failed to derive template name from directory %s: %w. specify --name", d.dir, err)
+		}
+		o.Name = name
+	}
+	if o.Desc == "" {
+		o.Desc = d.Name
+	}
+	if o.wsConfig.DeploymentName == "" {
+		o.wsConfig.DeploymentName = cfg.DeploymentName
+	}
+	if o.wsConfig.ServiceName == "" {
+		o.wsConfig.ServiceName = cfg.ServiceName
+	}
+	if o.wsConfig.ServiceMainPortName == "" {
+		o.wsConfig.ServiceMainPortName = cfg.ServiceMainPortName
+	}
+	// vars given by flags take precedence
+	for _, v := range vars {
+		name, _, _ := strings.Cut(v, ":")
+		given := false
+		for _, rv := range o.RequiredVars {
+			if n, _, _ := strings.Cut(rv, ":"); template.FixupTemplateVarKey(n) == template.FixupTemplateVarKey(name) {
+				given = true
+			}
+		}
+		if !given {
+			o.RequiredVars = append(o.RequiredVars, v)
+		}
+	}
+	return input, nil
+}
+
 func completeWorkspaceConfig(wsConfig *cosmov1alpha1.Config, unst []unstructured.Unstructured) error {
 	if wsConfig == nil || len(unst) == 0 {
 		return errors.New("invalid args")
 	}
 
-	dps := make([]unstructured.Unstructured, 0)
+	workloads := map[string][]unstructured.Unstructured{}
 	svcs := make([]unstructured.Unstructured, 0)
 
 	for _, u := range unst {
 		if kubeutil.IsGVKEqual(u.GroupVersionKind(), kubeutil.DeploymentGVK) {
-			dps = append(dps, u)
+			workloads[cosmov1alpha1.WorkloadKindDeployment] = append(workloads[cosmov1alpha1.WorkloadKindDeployment], u)
+		} else if kubeutil.IsGVKEqual(u.GroupVersionKind(), kubeutil.StatefulSetGVK) {
+			workloads[cosmov1alpha1.WorkloadKindStatefulSet] = append(workloads[cosmov1alpha1.WorkloadKindStatefulSet], u)
 		} else if kubeutil.IsGVKEqual(u.GroupVersionKind(), kubeutil.ServiceGVK) {
 			svcs = append(svcs, u)
 		}
 	}
 
-	// complete deployment name
-	if wsConfig.DeploymentName == "" {
-		if len(dps) != 1 {
-			return errors.New("no deployment")
+	// the additional workloads are not candidates of the main workload
+	candidates := func(kind string) []unstructured.Unstructured {
+		objs := make([]unstructured.Unstructured, 0)
+		for _, v := range workloads[kind] {
+			if !slices.Contains(wsConfig.AdditionalWorkloads, cosmov1alpha1.WorkloadRef{Kind: kind, Name: v.GetName()}) {
+				objs = append(objs, v)
+			}
 		}
-		wsConfig.DeploymentName = dps[0].GetName()
+		return objs
 	}
 
-	// validate deployment
-	var validDep, validSvc bool
-	for _, v := range dps {
-		if wsConfig.DeploymentName == v.GetName() {
-			validDep = true
+	// complete main workload kind
+	if wsConfig.WorkloadKind == "" {
+		dps, stss := candidates(cosmov1alpha1.WorkloadKindDeployment), candidates(cosmov1alpha1.WorkloadKindStatefulSet)
+		if (wsConfig.DeploymentName == "" && len(dps) == 0 && len(stss) == 1) ||
+			(wsConfig.DeploymentName != "" && !containsObjectName(dps, wsConfig.DeploymentName) && containsObjectName(stss, wsConfig.DeploymentName)) {
+			wsConfig.WorkloadKind = cosmov1alpha1.WorkloadKindStatefulSet
 		}
 	}
-	if !validDep {
-		return fmt.Errorf("deployment '%s' is not found", wsConfig.DeploymentName)
+
+	// complete main workload name
+	if wsConfig.DeploymentName == "" {
+		objs := candidates(wsConfig.MainWorkload().Kind)
+		if len(objs) != 1 {
+			return fmt.Errorf("no %s", strings.ToLower(wsConfig.MainWorkload().Kind))
+		}
+		wsConfig.DeploymentName = objs[0].GetName()
+	}
+
+	// validate workloads
+	for _, ref := range wsConfig.Workloads() {
+		if !containsObjectName(workloads[ref.Kind], ref.Name) {
+			return fmt.Errorf("%s '%s' is not found", strings.ToLower(ref.Kind), ref.Name)
+		}
 	}
 
 	// complete service name
@@ -172,6 +272,7 @@ func completeWorkspaceConfig(wsConfig *cosmov1alpha1.Config, unst []unstructured
 	}
 
 	// validate service
+	var validSvc bool
 	var svc corev1.Service
 	for _, v := range svcs {
 		if wsConfig.ServiceName == v.GetName() {
@@ -207,3 +308,12 @@ func completeWorkspaceConfig(wsConfig *cosmov1alpha1.Config, unst []unstructured
 
 	return nil
 }
+
+func containsObjectName(objs []unstructured.Unstructured, name string) bool {
+	for _, v := range objs {
+		if v.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -595,6 +595,151 @@ spec:
               number: 3000
         path: /*
         pathType: Exact
+`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "complete statefulset",
+			args: args{
+				wsConfig: &cosmov1alpha1.Config{
+					AdditionalWorkloads: []cosmov1alpha1.WorkloadRef{{Kind: "Deployment", Name: "db"}},
+				},
+				tmpl: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: 'workspace'
+spec:
+  ports:
+  - name: main
+    port: 3000
+    protocol: TCP
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: 'workspace'
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - image: theiaide/theia
+        name: theia
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: 'db'
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - image: postgres
+        name: db
+`,
+			},
+			wantErr: false,
+			want: &cosmov1alpha1.Config{
+				DeploymentName:      "workspace",
+				WorkloadKind:        "StatefulSet",
+				ServiceName:         "workspace",
+				ServiceMainPortName: "main",
+				AdditionalWorkloads: []cosmov1alpha1.WorkloadRef{{Kind: "Deployment", Name: "db"}},
+			},
+		},
+		{
+			name: "statefulset by name",
+			args: args{
+				wsConfig: &cosmov1alpha1.Config{
+					DeploymentName: "workspace",
+				},
+				tmpl: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: 'workspace'
+spec:
+  ports:
+  - name: main
+    port: 3000
+    protocol: TCP
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: 'workspace'
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - image: theiaide/theia
+        name: theia
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: 'db'
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - image: postgres
+        name: db
+`,
+			},
+			wantErr: false,
+			want: &cosmov1alpha1.Config{
+				DeploymentName:      "workspace",
+				WorkloadKind:        "StatefulSet",
+				ServiceName:         "workspace",
+				ServiceMainPortName: "main",
+			},
+		},
+		{
+			name: "NG additional workload",
+			args: args{
+				wsConfig: &cosmov1alpha1.Config{
+					AdditionalWorkloads: []cosmov1alpha1.WorkloadRef{{Kind: "StatefulSet", Name: "db"}},
+				},
+				tmpl: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: 'workspace'
+spec:
+  ports:
+  - name: main
+    port: 3000
+    protocol: TCP
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: 'workspace'
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - image: theiaide/theia
+        name: theia
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: 'db'
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - image: postgres
+        name: db
 `,
 			},
 			wantErr: true,
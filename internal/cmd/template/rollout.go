@@ -0,0 +1,267 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/cli"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
+)
+
+type rolloutOption struct {
+	*cli.RootOptions
+
+	TemplateName string
+	ToRevision   string
+	Undo         bool
+	BatchSize    int
+	Pause        time.Duration
+	DryRun       bool
+}
+
+func rolloutCmd(cmd *cobra.Command, cliOpt *cli.RootOptions) *cobra.Command {
+	o := &rolloutOption{RootOptions: cliOpt}
+	cmd.RunE = cli.ConnectErrorHandler(o)
+	cmd.Flags().StringVar(&o.ToRevision, "to-revision", "", "revision number or TemplateRevision name to roll out (default: latest revision)")
+	cmd.Flags().BoolVar(&o.Undo, "undo", false, "roll back the workspaces on the latest revision to the previous revision")
+	cmd.Flags().IntVar(&o.BatchSize, "batch-size", 10, "number of workspaces to migrate at once")
+	cmd.Flags().DurationVar(&o.Pause, "pause", time.Minute, "pause between batches. the rollout stops if the running workspaces in the batch are not running after the pause")
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "only print the workspaces to migrate")
+	return cmd
+}
+
+func (o *rolloutOption) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Validate(cmd, args); err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+	if !o.UseKubeAPI {
+		return errors.New("rollout is only available with -k")
+	}
+	if o.Undo && o.ToRevision != "" {
+		return errors.New("--undo and --to-revision cannot be used together")
+	}
+	if o.BatchSize <= 0 {
+		return errors.New("--batch-size must be positive")
+	}
+	return nil
+}
+
+func (o *rolloutOption) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+	o.TemplateName = args[0]
+
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return nil
+}
+
+func (o *rolloutOption) RunE(cmd *cobra.Command, args []string) error {
+	if err := o.Validate(cmd, args); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	if err := o.Complete(cmd, args); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
+	ctx := clog.IntoContext(o.Ctx, o.Logr)
+	c := o.KosmoClient
+
+	target, from, err := o.targetRevision(ctx)
+	if err != nil {
+		return err
+	}
+
+	wss, err := c.ListWorkspacesByTemplate(ctx, o.TemplateName)
+	if err != nil {
+		return err
+	}
+	targets := RolloutTargets(wss, target.Name, from)
+	if len(targets) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), color.GreenString("All workspaces are already on revision %s", target.Name))
+		return nil
+	}
+
+	o.Logr.Info("rolling out", "template", o.TemplateName, "revision", target.Name, "workspaces", len(targets), "batchSize", o.BatchSize)
+	if o.DryRun {
+		OutputRolloutTable(cmd.OutOrStdout(), targets)
+		return nil
+	}
+
+	for i, batch := range RolloutBatches(targets, o.BatchSize) {
+		o.Logr.Info("migrating batch", "batch", i+1, "workspaces", len(batch))
+
+		for _, ws := range batch {
+			if _, err := c.UpdateWorkspace(ctx, ws.Name, cosmov1alpha1.UserNameByNamespace(ws.Namespace), kosmo.UpdateWorkspaceOpts{TemplateRevision: &target.Name}); err != nil {
+				return fmt.Errorf("failed to migrate workspace %s/%s: %w", cosmov1alpha1.UserNameByNamespace(ws.Namespace), ws.Name, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "migrated %s/%s\n", cosmov1alpha1.UserNameByNamespace(ws.Namespace), ws.Name)
+		}
+
+		o.Logr.Info("pausing", "duration", o.Pause)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(o.Pause):
+		}
+
+		if unhealthy := o.unhealthyWorkspaces(ctx, batch); len(unhealthy) > 0 {
+			return fmt.Errorf("rollout stopped: workspaces are not running after migration: %s. run with --undo to roll back", strings.Join(unhealthy, ","))
+		}
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), color.GreenString("Successfully rolled out revision %s to %d workspaces", target.Name, len(targets)))
+	return nil
+}
+
+// targetRevision returns the revision to roll out and the revision to roll back from if --undo
+func (o *rolloutOption) targetRevision(ctx context.Context) (target *cosmov1alpha1.TemplateRevision, from string, err error) {
+	if !o.Undo {
+		target, err = o.KosmoClient.GetTemplateRevision(ctx, o.TemplateName, o.ToRevision)
+		return target, "", err
+	}
+
+	revs, err := o.KosmoClient.ListTemplateRevisions(ctx, o.TemplateName)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(revs) < 2 {
+		return nil, "", fmt.Errorf("template %s has no previous revision", o.TemplateName)
+	}
+	return &revs[len(revs)-2], revs[len(revs)-1].Name, nil
+}
+
+// unhealthyWorkspaces returns the workspaces which were running before the migration but are not running now
+func (o *rolloutOption) unhealthyWorkspaces(ctx context.Context, batch []cosmov1alpha1.Workspace) []string {
+	unhealthy := make([]string, 0)
+	for _, before := range batch {
+		if before.Status.Phase != "Running" {
+			continue
+		}
+		ws, err := o.KosmoClient.GetWorkspace(ctx, before.Name, before.Namespace)
+		if err != nil || ws.Status.Phase != "Running" {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s/%s", cosmov1alpha1.UserNameByNamespace(before.Namespace), before.Name))
+		}
+	}
+	return unhealthy
+}
+
+// RolloutTargets returns the workspaces to migrate to the revision.
+// If from is not empty, only the workspaces on the revision are returned.
+func RolloutTargets(wss []cosmov1alpha1.Workspace, revision, from string) []cosmov1alpha1.Workspace {
+	targets := make([]cosmov1alpha1.Workspace, 0, len(wss))
+	for _, ws := range wss {
+		if ws.Spec.Template.Revision == revision {
+			continue
+		}
+		if from != "" && ws.Spec.Template.Revision != from {
+			continue
+		}
+		targets = append(targets, ws)
+	}
+	return targets
+}
+
+// RolloutBatches splits the workspaces into batches
+func RolloutBatches(wss []cosmov1alpha1.Workspace, size int) [][]cosmov1alpha1.Workspace {
+	batches := make([][]cosmov1alpha1.Workspace, 0, (len(wss)+size-1)/size)
+	for size < len(wss) {
+		wss, batches = wss[size:], append(batches, wss[0:size:size])
+	}
+	return append(batches, wss)
+}
+
+func OutputRolloutTable(out io.Writer, wss []cosmov1alpha1.Workspace) {
+	data := [][]string{}
+	for _, v := range wss {
+		data = append(data, []string{cosmov1alpha1.UserNameByNamespace(v.Namespace), v.Name, v.Spec.Template.Revision, v.Status.Phase})
+	}
+	cli.OutputTable(out, []string{"USER", "NAME", "REVISION", "PHASE"}, data)
+}
+
+type getRevisionsOption struct {
+	*cli.RootOptions
+
+	TemplateName string
+}
+
+func getRevisionsCmd(cmd *cobra.Command, cliOpt *cli.RootOptions) *cobra.Command {
+	o := &getRevisionsOption{RootOptions: cliOpt}
+	cmd.RunE = cli.ConnectErrorHandler(o)
+	return cmd
+}
+
+func (o *getRevisionsOption) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Validate(cmd, args); err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+	if !o.UseKubeAPI {
+		return errors.New("revisions is only available with -k")
+	}
+	return nil
+}
+
+func (o *getRevisionsOption) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+	o.TemplateName = args[0]
+
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return nil
+}
+
+func (o *getRevisionsOption) RunE(cmd *cobra.Command, args []string) error {
+	if err := o.Validate(cmd, args); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	if err := o.Complete(cmd, args); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(o.Ctx, time.Second*10)
+	defer cancel()
+	ctx = clog.IntoContext(ctx, o.Logr)
+
+	revs, err := o.KosmoClient.ListTemplateRevisions(ctx, o.TemplateName)
+	if err != nil {
+		return err
+	}
+	wss, err := o.KosmoClient.ListWorkspacesByTemplate(ctx, o.TemplateName)
+	if err != nil {
+		return err
+	}
+
+	count := make(map[string]int)
+	for _, ws := range wss {
+		count[ws.Spec.Template.Revision]++
+	}
+
+	data := [][]string{}
+	for _, v := range revs {
+		data = append(data, []string{v.Name, strconv.FormatInt(v.Spec.Revision, 10), strconv.Itoa(count[v.Name]), v.CreationTimestamp.Local().Format(time.RFC3339)})
+	}
+	cli.OutputTable(cmd.OutOrStdout(), []string{"NAME", "REVISION", "WORKSPACES", "CREATED"}, data)
+	if n := count[""]; n > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "%d workspaces are not pinned to any revision\n", n)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package template
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func TestRolloutTargetsAndBatches(t *testing.T) {
+	ws := func(name, revision string) cosmov1alpha1.Workspace {
+		return cosmov1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cosmov1alpha1.UserNamespace("tom")},
+			Spec:       cosmov1alpha1.WorkspaceSpec{Template: cosmov1alpha1.TemplateRef{Name: "tmpl", Revision: revision}},
+		}
+	}
+	wss := []cosmov1alpha1.Workspace{ws("ws1", ""), ws("ws2", "tmpl-1"), ws("ws3", "tmpl-2"), ws("ws4", "tmpl-1"), ws("ws5", "tmpl-2")}
+
+	if got := RolloutTargets(wss, "tmpl-2", ""); len(got) != 3 {
+		t.Errorf("RolloutTargets() = %d workspaces, want 3", len(got))
+	}
+	undo := RolloutTargets(wss, "tmpl-1", "tmpl-2")
+	if len(undo) != 2 || undo[0].Name != "ws3" || undo[1].Name != "ws5" {
+		t.Errorf("RolloutTargets() with from = %v, want ws3 and ws5", undo)
+	}
+
+	batches := RolloutBatches(wss, 2)
+	if len(batches) != 3 || len(batches[0]) != 2 || len(batches[2]) != 1 {
+		t.Errorf("RolloutBatches() = %v, want batches of 2,2,1", batches)
+	}
+}
@@ -18,7 +18,10 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
 	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
 	"sigs.k8s.io/yaml"
 )
 
@@ -194,11 +197,7 @@ func (b *TempKustomizeBuilder) DisableNamePrefix() *TempKustomizeBuilder {
 
 func (b *TempKustomizeBuilder) Build(ctx context.Context) ([]byte, error) {
 	log := clog.FromContext(ctx).WithCaller()
-
-	cmd, err := kustomizeBuildCmd()
-	if err != nil {
-		return nil, err
-	}
+	defer os.RemoveAll(b.tmpDir)
 
 	// save yaml in tmp and set resources
 	for _, data := range b.resourceData {
@@ -209,7 +208,8 @@ func (b *TempKustomizeBuilder) Build(ctx context.Context) ([]byte, error) {
 		if _, err := f.WriteString(data); err != nil {
 			return nil, fmt.Errorf("failed to write file %s: %w", f.Name(), err)
 		}
-		b.kust.Resources = append(b.kust.Resources, f.Name())
+		f.Close()
+		b.kust.Resources = append(b.kust.Resources, filepath.Base(f.Name()))
 	}
 
 	kustYaml, err := yaml.Marshal(b.kust)
@@ -223,25 +223,100 @@ func (b *TempKustomizeBuilder) Build(ctx context.Context) ([]byte, error) {
 		return nil, err
 	}
 
-	// run kustomize build
-	kustomizeCmd := append(cmd, b.tmpDir)
-	log.Debug().Info("kustomize cmd", "cmd", cmd)
+	return KustomizeBuild(ctx, b.tmpDir)
+}
 
-	out, err := exec.CommandContext(ctx, kustomizeCmd[0], kustomizeCmd[1:]...).CombinedOutput()
+// KustomizeBuild builds the kustomization directory in-process like `kustomize build --enable-helm DIR`.
+// Only the local resources are allowed, and helmCharts in the kustomization are inflated by helm command only if it is used.
+func KustomizeBuild(ctx context.Context, dir string) ([]byte, error) {
+	log := clog.FromContext(ctx).WithCaller()
+	log.Debug().Info("kustomize build", "dir", dir)
+
+	useHelm, err := checkLocalKustomization(dir, make(map[string]bool))
 	if err != nil {
-		return nil, fmt.Errorf("failed to exec kustomize : %w : %s", err, out)
+		return nil, err
+	}
+	if useHelm {
+		if _, err := exec.LookPath("helm"); err != nil {
+			return nil, fmt.Errorf("helm command is required to inflate helmCharts in the kustomization: %w", err)
+		}
+	}
+
+	opts := krusty.MakeDefaultOptions()
+	opts.PluginConfig.HelmConfig = types.HelmConfig{Enabled: true, Command: "helm"}
+
+	resMap, err := krusty.MakeKustomizer(opts).Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to kustomize build %s: %w", dir, err)
+	}
+	out, err := resMap.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal kustomize build result: %w", err)
 	}
 	return out, nil
 }
 
-func kustomizeBuildCmd() ([]string, error) {
-	kust, kustErr := exec.LookPath("kustomize")
-	if kustErr != nil {
-		kctl, kctlErr := exec.LookPath("kubectl")
-		if kctlErr != nil {
-			return nil, fmt.Errorf("kubectl nor kustomize found: kustmizr=%v, kubectl=%v", kustErr, kctlErr)
+// checkLocalKustomization checks the resources, bases and components in the kustomization and its bases are all local,
+// not to fetch remote resources on building. It returns whether helmCharts are used in them.
+func checkLocalKustomization(dir string, visited map[string]bool) (useHelm bool, err error) {
+	if visited[dir] {
+		return false, nil
+	}
+	visited[dir] = true
+
+	var kust types.Kustomization
+	found := false
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		if err := yaml.Unmarshal(data, &kust); err != nil {
+			return false, fmt.Errorf("failed to parse kustomization %s: %w", filepath.Join(dir, name), err)
+		}
+		found = true
+		break
+	}
+	if !found {
+		return false, fmt.Errorf("kustomization file is not found in %s", dir)
+	}
+	useHelm = len(kust.HelmCharts) > 0 || len(kust.HelmChartInflationGenerator) > 0
+
+	paths := make([]string, 0, len(kust.Resources)+len(kust.Bases)+len(kust.Components))
+	paths = append(paths, kust.Resources...)
+	paths = append(paths, kust.Bases...)
+	paths = append(paths, kust.Components...)
+	for _, p := range paths {
+		if strings.Contains(p, "://") || strings.HasPrefix(p, "git@") {
+			return false, fmt.Errorf("remote resource is not allowed in kustomization %s: %s", dir, p)
+		}
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+		fi, err := os.Stat(p)
+		if err != nil {
+			// kustomize regards the path not found in local as the remote git repository
+			return false, fmt.Errorf("resource is not found in local in kustomization %s: %w", dir, err)
+		}
+		if fi.IsDir() {
+			baseUseHelm, err := checkLocalKustomization(p, visited)
+			if err != nil {
+				return false, err
+			}
+			useHelm = useHelm || baseUseHelm
 		}
-		return []string{kctl, "kustomize"}, nil
 	}
-	return []string{kust, "build"}, nil
+	return useHelm, nil
+}
+
+// readInput returns the manifests built from the kustomization directory if given, otherwise read from Stdin
+func readInput(ctx context.Context, kustomizeDir string) (string, error) {
+	if kustomizeDir == "" {
+		return cli.ReadFromPipedStdin()
+	}
+	out, err := KustomizeBuild(ctx, kustomizeDir)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
 }
@@ -0,0 +1,147 @@
+package template
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+const testConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+data:
+  key: val
+`
+
+func TestTemplateObjectBuilder_Build(t *testing.T) {
+	tests := []struct {
+		name              string
+		disableNamePrefix bool
+		wantName          string
+	}{
+		{
+			name:     "name prefix",
+			wantName: "name: '{{INSTANCE}}-cm1'",
+		},
+		{
+			name:              "disable name prefix",
+			disableNamePrefix: true,
+			wantName:          "name: cm1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewTemplateObjectBuilder(false).Name("tmpl1").Resources(testConfigMap)
+			if tt.disableNamePrefix {
+				b.DisableNamePrefix()
+			}
+			out, err := b.Build(context.TODO())
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			tmpl := cosmov1alpha1.Template{}
+			if err := yaml.Unmarshal(out, &tmpl); err != nil {
+				t.Fatalf("failed to unmarshal template: %v", err)
+			}
+			for _, want := range []string{
+				tt.wantName,
+				"namespace: '{{NAMESPACE}}'",
+				cosmov1alpha1.LabelKeyInstanceName + ": '{{INSTANCE}}'",
+				cosmov1alpha1.LabelKeyTemplateName + ": '{{TEMPLATE}}'",
+			} {
+				if !strings.Contains(tmpl.Spec.RawYaml, want) {
+					t.Errorf("rawYaml does not contain %q:\n%s", want, tmpl.Spec.RawYaml)
+				}
+			}
+		})
+	}
+}
+
+func TestKustomizeBuild(t *testing.T) {
+	dir := t.TempDir()
+	write := func(path, data string) {
+		t.Helper()
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("base/cm.yaml", testConfigMap)
+	write("base/kustomization.yaml", "resources:\n- cm.yaml\n")
+	write("overlay/kustomization.yaml", "resources:\n- ../base\nnameSuffix: -dev\n")
+
+	out, err := KustomizeBuild(context.TODO(), filepath.Join(dir, "overlay"))
+	if err != nil {
+		t.Fatalf("KustomizeBuild() error = %v", err)
+	}
+	if !strings.Contains(string(out), "name: cm1-dev") {
+		t.Errorf("KustomizeBuild() = %s, want overlay applied", out)
+	}
+}
+
+func TestKustomizeBuild_remoteResources(t *testing.T) {
+	tests := []struct {
+		name string
+		kust string
+	}{
+		{
+			name: "❌ remote git resource",
+			kust: "resources:\n- github.com/cosmo-workspace/cosmo/config/default?ref=main\n",
+		},
+		{
+			name: "❌ remote https resource",
+			kust: "resources:\n- https://raw.githubusercontent.com/cosmo-workspace/cosmo/main/config/default/kustomization.yaml\n",
+		},
+		{
+			name: "❌ remote base",
+			kust: "bases:\n- git@github.com:cosmo-workspace/cosmo.git/config/default\n",
+		},
+		{
+			name: "❌ remote resource in base",
+			kust: "resources:\n- base\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "kustomization.yaml"), []byte(tt.kust), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.MkdirAll(filepath.Join(dir, "base"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "base", "kustomization.yaml"), []byte("resources:\n- https://example.com/cm.yaml\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := KustomizeBuild(context.TODO(), dir); err == nil {
+				t.Errorf("KustomizeBuild() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestKustomizeBuild_helmNotFound(t *testing.T) {
+	dir := t.TempDir()
+	kust := "helmCharts:\n- name: chart1\n  repo: https://example.com/charts\n  version: 1.0.0\n"
+	if err := os.WriteFile(filepath.Join(dir, "kustomization.yaml"), []byte(kust), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := KustomizeBuild(context.TODO(), dir)
+	if err == nil || !strings.Contains(err.Error(), "helm command is required") {
+		t.Errorf("KustomizeBuild() error = %v, want helm command error", err)
+	}
+}
@@ -140,6 +140,13 @@ func (o *validateOption) RunE(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(o.Ctx, time.Second*10)
 	defer cancel()
 
+	if template.IsGoTemplateRenderMode(&o.tmpl) {
+		o.Logr.Info("smoke test: check rawYaml syntax")
+		if err := template.ValidateGoTemplate(o.tmpl.Spec.RawYaml); err != nil {
+			return o.renderError(err)
+		}
+	}
+
 	o.Logr.Info("smoke test: dryrun apply template")
 	_, tmplUnst, err := template.StringToUnstructured(string(o.input))
 	if err != nil {
@@ -169,8 +176,11 @@ func (o *validateOption) RunE(cmd *cobra.Command, args []string) error {
 	o.Logr.Info("smoke test: create dummy instance to apply each resources", "instance", dummyInst.GetName())
 	o.Logr.Debug().DumpObject(o.KosmoClient.Scheme(), &dummyInst, "test instance")
 
-	builts, err := template.BuildObjects(o.tmpl.Spec, &dummyInst, "dummy.example.com")
+	builts, err := template.BuildObjects(&o.tmpl, &dummyInst, "dummy.example.com", nil)
 	if err != nil {
+		if errors.As(err, new(*template.RenderError)) {
+			return o.renderError(err)
+		}
 		return fmt.Errorf("failed to build test instance: %w", err)
 	}
 	// only apply MetadataTransformer
@@ -202,6 +212,33 @@ func (o *validateOption) RunE(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// renderError returns the error with the line number in the input file if the rawYaml is a literal block scalar
+func (o *validateOption) renderError(err error) error {
+	var renderErr *template.RenderError
+	if !errors.As(err, &renderErr) {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+	if offset := rawYamlLineOffset(o.input); offset > 0 {
+		return fmt.Errorf("failed to render template: %s:%d: %s", o.File, offset+renderErr.Line, renderErr.Message)
+	}
+	return fmt.Errorf("failed to render template: rawYaml:%d: %s", renderErr.Line, renderErr.Message)
+}
+
+// rawYamlLineOffset returns the line number of the rawYaml key in the input. 0 if the rawYaml is not a literal block scalar.
+func rawYamlLineOffset(input []byte) int {
+	for i, line := range strings.Split(string(input), "\n") {
+		key, val, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found || key != "rawYaml" {
+			continue
+		}
+		if val = strings.TrimSpace(val); strings.HasPrefix(val, "|") {
+			return i + 1
+		}
+		return 0
+	}
+	return 0
+}
+
 func (o *validateOption) dryrunApplyOnServer(ctx context.Context, obj client.Object) error {
 	options := &client.PatchOptions{
 		FieldManager: "cosmoctl-validate",
@@ -0,0 +1,46 @@
+package template
+
+import (
+	"testing"
+)
+
+func TestRawYamlLineOffset(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{
+			name: "literal block",
+			input: `apiVersion: cosmo-workspace.github.io/v1alpha1
+kind: Template
+metadata:
+  name: tmpl1
+spec:
+  rawYaml: |
+    apiVersion: v1
+`,
+			want: 6,
+		},
+		{
+			name: "flow scalar",
+			input: `kind: Template
+spec:
+  rawYaml: "apiVersion: v1"
+`,
+			want: 0,
+		},
+		{
+			name:  "no rawYaml",
+			input: `kind: Template`,
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rawYamlLineOffset([]byte(tt.input)); got != tt.want {
+				t.Errorf("rawYamlLineOffset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
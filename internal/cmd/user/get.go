@@ -250,11 +250,11 @@ func OutputWideTable(out io.Writer, users []*dashv1alpha1.User) {
 	data := [][]string{}
 
 	for _, v := range users {
-		data = append(data, []string{v.Name, v.DisplayName, strings.Join(v.Roles, ","), v.AuthType, cosmov1alpha1.UserNamespace(v.Name), v.Status, printDeletePolicy(v.DeletePolicy), printAddonWithVars(v.Addons)})
+		data = append(data, []string{v.Name, v.DisplayName, strings.Join(v.Roles, ","), v.AuthType, cosmov1alpha1.UserNamespace(v.Name), v.Status, cli.ConditionString(v.Conditions, cosmov1alpha1.ConditionTypeReady), printDeletePolicy(v.DeletePolicy), printAddonWithVars(v.Addons)})
 	}
 
 	cli.OutputTable(out,
-		[]string{"NAME", "DISPLAYNAME", "ROLES", "AUTHTYPE", "NAMESPACE", "PHASE", "DELETEPOLOCY", "ADDONS"},
+		[]string{"NAME", "DISPLAYNAME", "ROLES", "AUTHTYPE", "NAMESPACE", "PHASE", "READY", "DELETEPOLOCY", "ADDONS"},
 		data)
 }
 
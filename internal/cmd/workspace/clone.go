@@ -0,0 +1,117 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmo-workspace/cosmo/pkg/cli"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	dashv1alpha1 "github.com/cosmo-workspace/cosmo/proto/gen/dashboard/v1alpha1"
+)
+
+type CloneOption struct {
+	*cli.RootOptions
+
+	SrcWorkspaceName string
+	DstWorkspaceName string
+	UserName         string
+	DstUserName      string
+}
+
+func CloneCmd(cmd *cobra.Command, cliOpt *cli.RootOptions) *cobra.Command {
+	o := &CloneOption{RootOptions: cliOpt}
+	cmd.RunE = cli.ConnectErrorHandler(o)
+	cmd.Flags().StringVarP(&o.UserName, "user", "u", "", "owner of the source workspace (defualt: login user)")
+	cmd.Flags().StringVar(&o.DstUserName, "to-user", "", "owner of the new workspace. admin privilege is required to clone into another user (default: same as --user)")
+	return cmd
+}
+
+func (o *CloneOption) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Validate(cmd, args); err != nil {
+		return err
+	}
+	if len(args) != 2 {
+		return errors.New("invalid args")
+	}
+	if o.UseKubeAPI && o.UserName == "" {
+		return fmt.Errorf("user name is required")
+	}
+	return nil
+}
+
+func (o *CloneOption) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+	o.SrcWorkspaceName = args[0]
+	o.DstWorkspaceName = args[1]
+
+	if !o.UseKubeAPI && o.UserName == "" {
+		o.UserName = o.CliConfig.User
+	}
+	if o.DstUserName == "" {
+		o.DstUserName = o.UserName
+	}
+
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return nil
+}
+
+func (o *CloneOption) RunE(cmd *cobra.Command, args []string) error {
+	if err := o.Validate(cmd, args); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	if err := o.Complete(cmd, args); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(o.Ctx, time.Second*30)
+	defer cancel()
+	ctx = clog.IntoContext(ctx, o.Logr)
+
+	o.Logr.Info("cloning workspace", "user", o.UserName, "workspace", o.SrcWorkspaceName, "toUser", o.DstUserName, "toWorkspace", o.DstWorkspaceName)
+
+	if o.UseKubeAPI {
+		if err := o.CloneWorkspaceWithKubeClient(ctx); err != nil {
+			return err
+		}
+	} else {
+		if err := o.CloneWorkspaceWithDashClient(ctx); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), color.GreenString("Successfully cloned workspace %s into %s", o.SrcWorkspaceName, o.DstWorkspaceName))
+	return nil
+}
+
+func (o *CloneOption) CloneWorkspaceWithDashClient(ctx context.Context) error {
+	req := &dashv1alpha1.CloneWorkspaceRequest{
+		UserName:    o.UserName,
+		WsName:      o.SrcWorkspaceName,
+		DstUserName: &o.DstUserName,
+		DstWsName:   o.DstWorkspaceName,
+	}
+	c := o.CosmoDashClient
+	o.Logr.DebugAll().Info("WorkspaceServiceClient.CloneWorkspace", "req", req)
+	res, err := c.WorkspaceServiceClient.CloneWorkspace(ctx, cli.NewRequestWithToken(req, o.CliConfig))
+	if err != nil {
+		return fmt.Errorf("failed to connect dashboard server: %w", err)
+	}
+	o.Logr.DebugAll().Info("WorkspaceServiceClient.CloneWorkspace", "res", res)
+	return nil
+}
+
+func (o *CloneOption) CloneWorkspaceWithKubeClient(ctx context.Context) error {
+	c := o.KosmoClient
+	if _, err := c.CloneWorkspace(ctx, o.UserName, o.SrcWorkspaceName, o.DstUserName, o.DstWorkspaceName); err != nil {
+		return err
+	}
+	return nil
+}
@@ -66,6 +66,15 @@ Manipulate COSMO Workspace resource.
 		Use:   "update WORKSPACE_NAME",
 		Short: "Update workspace",
 	}, o))
+	workspaceCmd.AddCommand(CloneCmd(&cobra.Command{
+		Use:   "clone SRC_WORKSPACE_NAME DST_WORKSPACE_NAME",
+		Short: "Clone workspace with its volumes",
+	}, o))
+	workspaceCmd.AddCommand(SnapshotCmd(&cobra.Command{
+		Use:     "snapshot",
+		Short:   "Manipulate workspace snapshots",
+		Aliases: []string{"snap"},
+	}, o))
 
 	cmd.AddCommand(workspaceCmd)
 }
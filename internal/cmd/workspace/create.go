@@ -13,6 +13,7 @@ import (
 	"github.com/cosmo-workspace/cosmo/pkg/apiconv"
 	"github.com/cosmo-workspace/cosmo/pkg/cli"
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/template"
 	dashv1alpha1 "github.com/cosmo-workspace/cosmo/proto/gen/dashboard/v1alpha1"
 )
 
@@ -91,6 +92,10 @@ func (o *CreateOption) RunE(cmd *cobra.Command, args []string) error {
 
 	o.Logr.Info("creating workspace", "user", o.UserName, "name", o.WorkspaceName, "template", o.Template, "vars", o.TemplateVars)
 
+	if err := o.ValidateTemplateVars(ctx); err != nil {
+		return err
+	}
+
 	if !o.Force {
 	AskLoop:
 		for {
@@ -153,3 +158,35 @@ func (o *CreateOption) CreateWorkspaceWithKubeClient(ctx context.Context) (*dash
 	}
 	return apiconv.C2D_Workspace(*ws), nil
 }
+
+// ValidateTemplateVars validates the vars satisfy the var specs of the template on client-side
+func (o *CreateOption) ValidateTemplateVars(ctx context.Context) error {
+	if len(o.vars) == 0 {
+		return nil
+	}
+
+	var (
+		tmpls []*dashv1alpha1.Template
+		err   error
+	)
+	getTmplOpt := &GetTemplatesOption{RootOptions: o.RootOptions}
+	if o.UseKubeAPI {
+		tmpls, err = getTmplOpt.ListWorkspaceTemplatesByKubeClient(ctx, false)
+	} else {
+		tmpls, err = getTmplOpt.ListWorkspaceTemplatesWithDashClient(ctx, false)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, tmpl := range tmpls {
+		if tmpl.Name == o.Template {
+			if err := template.ValidateVars(apiconv.D2C_RequiredVars(tmpl.RequiredVars), o.vars); err != nil {
+				return fmt.Errorf("validation error: %w", err)
+			}
+			return nil
+		}
+	}
+	// the template is validated on server-side
+	return nil
+}
@@ -10,10 +10,12 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/utils/ptr"
 
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 	"github.com/cosmo-workspace/cosmo/pkg/apiconv"
 	"github.com/cosmo-workspace/cosmo/pkg/cli"
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
 	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
+	"github.com/cosmo-workspace/cosmo/pkg/workspace"
 	dashv1alpha1 "github.com/cosmo-workspace/cosmo/proto/gen/dashboard/v1alpha1"
 )
 
@@ -237,11 +239,11 @@ func OutputWideTable(out io.Writer, username string, workspaces []*dashv1alpha1.
 	data := [][]string{}
 
 	for _, v := range workspaces {
-		data = append(data, []string{v.OwnerName, v.Name, v.Spec.Template, printVars(v.Spec.Vars), v.Status.Phase, printDeletePolicy(v.DeletePolicy), printMainURL(v, username)})
+		data = append(data, []string{v.OwnerName, v.Name, v.Spec.Template, printVars(v.Spec.Vars), v.Status.Phase, cli.ConditionString(v.Status.Conditions, cosmov1alpha1.ConditionTypeReady), printDeletePolicy(v.DeletePolicy), printSchedule(v.Spec.Schedule), printMainURL(v, username)})
 	}
 
 	cli.OutputTable(out,
-		[]string{"USER", "NAME", "TEMPLATE", "VARS", "PHASE", "DELETEPOLICY", "MAINURL"},
+		[]string{"USER", "NAME", "TEMPLATE", "VARS", "PHASE", "READY", "DELETEPOLICY", "SCHEDULE", "MAINURL"},
 		data)
 }
 
@@ -269,6 +271,10 @@ func printDeletePolicy(deletePolicy *dashv1alpha1.DeletePolicy) string {
 	}
 }
 
+func printSchedule(schedule *dashv1alpha1.WorkspaceSchedule) string {
+	return workspace.ScheduleString(apiconv.D2C_WorkspaceSchedule(schedule))
+}
+
 func (o *GetOption) listWorkspacesByKubeClient(ctx context.Context, userName string, includeShared bool) ([]*dashv1alpha1.Workspace, error) {
 	c := o.KosmoClient
 	workspaces, err := c.ListWorkspacesByUserName(ctx, userName, func(o *kosmo.ListWorkspacesOptions) {
@@ -23,6 +23,7 @@ type RemoveNetworkOption struct {
 	CustomHostPrefix string
 	PortNumber       int32
 	HTTPPath         string
+	Protocol         string
 	Public           bool
 
 	rule cosmov1alpha1.NetworkRule
@@ -37,6 +38,7 @@ func RemoveNetworkCmd(cmd *cobra.Command, cliOpt *cli.RootOptions) *cobra.Comman
 	cmd.MarkFlagRequired("port")
 	cmd.Flags().StringVar(&o.CustomHostPrefix, "custom-host-prefix", "", "custom host prefix")
 	cmd.Flags().StringVar(&o.HTTPPath, "path", "/", "path for Ingress path when using ingress")
+	cmd.Flags().StringVar(&o.Protocol, "protocol", cosmov1alpha1.NetworkRuleProtocolHTTP, "protocol of the network rule. http or tcp. tcp is routed by the hostname in TLS SNI")
 
 	return cmd
 }
@@ -48,6 +50,9 @@ func (o *RemoveNetworkOption) Validate(cmd *cobra.Command, args []string) error
 	if o.UseKubeAPI && o.UserName == "" {
 		return fmt.Errorf("user name is required")
 	}
+	if o.Protocol != cosmov1alpha1.NetworkRuleProtocolHTTP && o.Protocol != cosmov1alpha1.NetworkRuleProtocolTCP {
+		return fmt.Errorf("invalid protocol: %s", o.Protocol)
+	}
 	return nil
 }
 
@@ -69,8 +74,12 @@ func (o *RemoveNetworkOption) Complete(cmd *cobra.Command, args []string) error
 		CustomHostPrefix: o.CustomHostPrefix,
 		PortNumber:       o.PortNumber,
 		HTTPPath:         o.HTTPPath,
+		Protocol:         o.Protocol,
 		Public:           o.Public,
 	}
+	if o.rule.Protocol == cosmov1alpha1.NetworkRuleProtocolTCP && !cmd.Flags().Changed("path") {
+		o.rule.HTTPPath = ""
+	}
 	o.rule.Default()
 
 	cmd.SilenceErrors = true
@@ -0,0 +1,317 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmo-workspace/cosmo/pkg/apiconv"
+	"github.com/cosmo-workspace/cosmo/pkg/cli"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	dashv1alpha1 "github.com/cosmo-workspace/cosmo/proto/gen/dashboard/v1alpha1"
+)
+
+func SnapshotCmd(cmd *cobra.Command, o *cli.RootOptions) *cobra.Command {
+	cmd.AddCommand(SnapshotCreateCmd(&cobra.Command{
+		Use:   "create WORKSPACE_NAME SNAPSHOT_NAME",
+		Short: "Create snapshot of workspace volumes",
+	}, o))
+	cmd.AddCommand(SnapshotListCmd(&cobra.Command{
+		Use:     "list [WORKSPACE_NAME]",
+		Short:   "List workspace snapshots",
+		Aliases: []string{"get", "ls"},
+	}, o))
+	cmd.AddCommand(SnapshotRestoreCmd(&cobra.Command{
+		Use:   "restore SNAPSHOT_NAME",
+		Short: "Restore workspace volumes from snapshot. Workspace must be suspended",
+	}, o))
+	return cmd
+}
+
+type SnapshotCreateOption struct {
+	*cli.RootOptions
+
+	WorkspaceName           string
+	SnapshotName            string
+	UserName                string
+	VolumeSnapshotClassName string
+}
+
+func SnapshotCreateCmd(cmd *cobra.Command, cliOpt *cli.RootOptions) *cobra.Command {
+	o := &SnapshotCreateOption{RootOptions: cliOpt}
+	cmd.RunE = cli.ConnectErrorHandler(o)
+	cmd.Flags().StringVarP(&o.UserName, "user", "u", "", "user name (defualt: login user)")
+	cmd.Flags().StringVar(&o.VolumeSnapshotClassName, "volume-snapshot-class", "", "VolumeSnapshotClass name (default: default class in cluster)")
+	return cmd
+}
+
+func (o *SnapshotCreateOption) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Validate(cmd, args); err != nil {
+		return err
+	}
+	if len(args) != 2 {
+		return errors.New("invalid args")
+	}
+	if o.UseKubeAPI && o.UserName == "" {
+		return fmt.Errorf("user name is required")
+	}
+	return nil
+}
+
+func (o *SnapshotCreateOption) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+	o.WorkspaceName = args[0]
+	o.SnapshotName = args[1]
+
+	if !o.UseKubeAPI && o.UserName == "" {
+		o.UserName = o.CliConfig.User
+	}
+
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return nil
+}
+
+func (o *SnapshotCreateOption) RunE(cmd *cobra.Command, args []string) error {
+	if err := o.Validate(cmd, args); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	if err := o.Complete(cmd, args); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(o.Ctx, time.Second*10)
+	defer cancel()
+	ctx = clog.IntoContext(ctx, o.Logr)
+
+	var className *string
+	if o.VolumeSnapshotClassName != "" {
+		className = &o.VolumeSnapshotClassName
+	}
+
+	if o.UseKubeAPI {
+		if _, err := o.KosmoClient.CreateWorkspaceSnapshot(ctx, o.UserName, o.WorkspaceName, o.SnapshotName, className); err != nil {
+			return err
+		}
+	} else {
+		req := &dashv1alpha1.CreateWorkspaceSnapshotRequest{
+			UserName:                o.UserName,
+			WsName:                  o.WorkspaceName,
+			SnapshotName:            o.SnapshotName,
+			VolumeSnapshotClassName: className,
+		}
+		o.Logr.DebugAll().Info("WorkspaceServiceClient.CreateWorkspaceSnapshot", "req", req)
+		res, err := o.CosmoDashClient.WorkspaceServiceClient.CreateWorkspaceSnapshot(ctx, cli.NewRequestWithToken(req, o.CliConfig))
+		if err != nil {
+			return fmt.Errorf("failed to connect dashboard server: %w", err)
+		}
+		o.Logr.DebugAll().Info("WorkspaceServiceClient.CreateWorkspaceSnapshot", "res", res)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), color.GreenString("Successfully created snapshot %s of workspace %s", o.SnapshotName, o.WorkspaceName))
+	return nil
+}
+
+type SnapshotListOption struct {
+	*cli.RootOptions
+
+	WorkspaceName string
+	UserName      string
+}
+
+func SnapshotListCmd(cmd *cobra.Command, cliOpt *cli.RootOptions) *cobra.Command {
+	o := &SnapshotListOption{RootOptions: cliOpt}
+	cmd.RunE = cli.ConnectErrorHandler(o)
+	cmd.Flags().StringVarP(&o.UserName, "user", "u", "", "user name (defualt: login user)")
+	return cmd
+}
+
+func (o *SnapshotListOption) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Validate(cmd, args); err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		return errors.New("invalid args")
+	}
+	if o.UseKubeAPI && o.UserName == "" {
+		return fmt.Errorf("user name is required")
+	}
+	return nil
+}
+
+func (o *SnapshotListOption) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		o.WorkspaceName = args[0]
+	}
+
+	if !o.UseKubeAPI && o.UserName == "" {
+		o.UserName = o.CliConfig.User
+	}
+
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return nil
+}
+
+func (o *SnapshotListOption) RunE(cmd *cobra.Command, args []string) error {
+	if err := o.Validate(cmd, args); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	if err := o.Complete(cmd, args); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(o.Ctx, time.Second*10)
+	defer cancel()
+	ctx = clog.IntoContext(ctx, o.Logr)
+
+	var snaps []*dashv1alpha1.WorkspaceSnapshot
+	if o.UseKubeAPI {
+		s, err := o.KosmoClient.ListWorkspaceSnapshots(ctx, o.UserName, o.WorkspaceName)
+		if err != nil {
+			return err
+		}
+		snaps = apiconv.C2D_WorkspaceSnapshots(s)
+	} else {
+		req := &dashv1alpha1.GetWorkspaceSnapshotsRequest{
+			UserName: o.UserName,
+			WsName:   o.WorkspaceName,
+		}
+		o.Logr.DebugAll().Info("WorkspaceServiceClient.GetWorkspaceSnapshots", "req", req)
+		res, err := o.CosmoDashClient.WorkspaceServiceClient.GetWorkspaceSnapshots(ctx, cli.NewRequestWithToken(req, o.CliConfig))
+		if err != nil {
+			return fmt.Errorf("failed to connect dashboard server: %w", err)
+		}
+		o.Logr.DebugAll().Info("WorkspaceServiceClient.GetWorkspaceSnapshots", "res", res)
+		snaps = res.Msg.Items
+	}
+
+	OutputSnapshotTable(cmd.OutOrStdout(), snaps)
+	return nil
+}
+
+func OutputSnapshotTable(out io.Writer, snaps []*dashv1alpha1.WorkspaceSnapshot) {
+	data := [][]string{}
+
+	for _, v := range snaps {
+		data = append(data, []string{v.Name, v.WsName, v.Phase, strings.Join(v.Volumes, ","), printTimestamp(v.CreationTimestamp.AsTime(), v.CreationTimestamp != nil), printTimestamp(v.LastRestoredAt.AsTime(), v.LastRestoredAt != nil)})
+	}
+
+	cli.OutputTable(out,
+		[]string{"NAME", "WORKSPACE", "PHASE", "VOLUMES", "CREATED", "LASTRESTORED"},
+		data)
+}
+
+func printTimestamp(t time.Time, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return t.Local().Format(time.RFC3339)
+}
+
+type SnapshotRestoreOption struct {
+	*cli.RootOptions
+
+	SnapshotName string
+	UserName     string
+	Force        bool
+}
+
+func SnapshotRestoreCmd(cmd *cobra.Command, cliOpt *cli.RootOptions) *cobra.Command {
+	o := &SnapshotRestoreOption{RootOptions: cliOpt}
+	cmd.RunE = cli.ConnectErrorHandler(o)
+	cmd.Flags().StringVarP(&o.UserName, "user", "u", "", "user name (defualt: login user)")
+	cmd.Flags().BoolVar(&o.Force, "force", false, "not ask confirmation")
+	return cmd
+}
+
+func (o *SnapshotRestoreOption) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Validate(cmd, args); err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errors.New("invalid args")
+	}
+	if o.UseKubeAPI && o.UserName == "" {
+		return fmt.Errorf("user name is required")
+	}
+	return nil
+}
+
+func (o *SnapshotRestoreOption) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.RootOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+	o.SnapshotName = args[0]
+
+	if !o.UseKubeAPI && o.UserName == "" {
+		o.UserName = o.CliConfig.User
+	}
+
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return nil
+}
+
+func (o *SnapshotRestoreOption) RunE(cmd *cobra.Command, args []string) error {
+	if err := o.Validate(cmd, args); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	if err := o.Complete(cmd, args); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
+	o.Logr.Info("restore workspace from snapshot. current data in the workspace volumes will be lost", "snapshot", o.SnapshotName)
+
+	if !o.Force {
+	AskLoop:
+		for {
+			input, err := cli.AskInput("Confirm? [y/n] ", false)
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(input) {
+			case "y":
+				break AskLoop
+			case "n":
+				fmt.Println("canceled")
+				return nil
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(o.Ctx, time.Second*10)
+	defer cancel()
+	ctx = clog.IntoContext(ctx, o.Logr)
+
+	if o.UseKubeAPI {
+		if _, err := o.KosmoClient.RestoreWorkspaceSnapshot(ctx, o.SnapshotName, o.UserName); err != nil {
+			return err
+		}
+	} else {
+		req := &dashv1alpha1.RestoreWorkspaceSnapshotRequest{
+			UserName:     o.UserName,
+			SnapshotName: o.SnapshotName,
+		}
+		o.Logr.DebugAll().Info("WorkspaceServiceClient.RestoreWorkspaceSnapshot", "req", req)
+		res, err := o.CosmoDashClient.WorkspaceServiceClient.RestoreWorkspaceSnapshot(ctx, cli.NewRequestWithToken(req, o.CliConfig))
+		if err != nil {
+			return fmt.Errorf("failed to connect dashboard server: %w", err)
+		}
+		o.Logr.DebugAll().Info("WorkspaceServiceClient.RestoreWorkspaceSnapshot", "res", res)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), color.GreenString("Successfully requested to restore from snapshot %s", o.SnapshotName))
+	return nil
+}
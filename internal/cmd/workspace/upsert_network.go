@@ -26,6 +26,7 @@ type UpsertNetworkOption struct {
 	CustomHostPrefix string
 	PortNumber       int32
 	HTTPPath         string
+	Protocol         string
 	Public           bool
 	AllowedUsers     []string
 
@@ -41,6 +42,7 @@ func UpsertNetworkCmd(cmd *cobra.Command, cliOpt *cli.RootOptions) *cobra.Comman
 	cmd.MarkFlagRequired("port")
 	cmd.Flags().StringVar(&o.CustomHostPrefix, "host-prefix", "", "custom host prefix")
 	cmd.Flags().StringVar(&o.HTTPPath, "path", "/", "path for Ingress path when using ingress")
+	cmd.Flags().StringVar(&o.Protocol, "protocol", cosmov1alpha1.NetworkRuleProtocolHTTP, "protocol of the network rule. http or tcp. tcp is routed by the hostname in TLS SNI")
 	cmd.Flags().BoolVar(&o.Public, "public", false, "disable authentication for this port")
 	cmd.Flags().StringSliceVarP(&o.AllowedUsers, "share-with", "s", []string{}, "allow user to access this network rule")
 
@@ -54,6 +56,9 @@ func (o *UpsertNetworkOption) Validate(cmd *cobra.Command, args []string) error
 	if o.UseKubeAPI && o.UserName == "" {
 		return fmt.Errorf("user name is required")
 	}
+	if o.Protocol != cosmov1alpha1.NetworkRuleProtocolHTTP && o.Protocol != cosmov1alpha1.NetworkRuleProtocolTCP {
+		return fmt.Errorf("invalid protocol: %s", o.Protocol)
+	}
 	return nil
 }
 
@@ -75,9 +80,13 @@ func (o *UpsertNetworkOption) Complete(cmd *cobra.Command, args []string) error
 		CustomHostPrefix: o.CustomHostPrefix,
 		PortNumber:       o.PortNumber,
 		HTTPPath:         o.HTTPPath,
+		Protocol:         o.Protocol,
 		Public:           o.Public,
 		AllowedUsers:     o.AllowedUsers,
 	}
+	if o.rule.Protocol == cosmov1alpha1.NetworkRuleProtocolTCP && !cmd.Flags().Changed("path") {
+		o.rule.HTTPPath = ""
+	}
 	o.rule.Default()
 
 	cmd.SilenceErrors = true
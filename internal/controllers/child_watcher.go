@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+)
+
+// childResyncDelay is the delay to reconcile the instance after its child resource is changed.
+// The changes in the delay are merged into one reconciliation.
+const childResyncDelay = 2 * time.Second
+
+// childWatcher starts watching the kinds of the child resources dynamically
+// and enqueues the owner instance when a child resource is modified or deleted.
+type childWatcher struct {
+	controller controller.Controller
+	cache      cache.Cache
+	// ownerKind is Instance or ClusterInstance
+	ownerKind string
+
+	mu      sync.Mutex
+	watched map[schema.GroupVersionKind]struct{}
+}
+
+// newChildWatcher returns the childWatcher with the cache dedicated to the child resources.
+// The informers of the cache are cluster-wide, so they are scoped to the resources with the instance label
+// not to cache all the resources of the kinds in the cluster.
+func newChildWatcher(c controller.Controller, mgr ctrl.Manager, ownerKind string) (*childWatcher, error) {
+	selector, err := labels.Parse(cosmov1alpha1.LabelKeyInstanceName)
+	if err != nil {
+		return nil, err
+	}
+	childCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		HTTPClient:           mgr.GetHTTPClient(),
+		Scheme:               mgr.GetScheme(),
+		Mapper:               mgr.GetRESTMapper(),
+		DefaultLabelSelector: selector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache for child resources: %w", err)
+	}
+	if err := mgr.Add(childCache); err != nil {
+		return nil, err
+	}
+	return &childWatcher{
+		controller: c,
+		cache:      childCache,
+		ownerKind:  ownerKind,
+		watched:    make(map[schema.GroupVersionKind]struct{}),
+	}, nil
+}
+
+// watch starts watching the kinds of the resources not watched yet
+func (w *childWatcher) watch(ctx context.Context, refs []cosmov1alpha1.ObjectRef) error {
+	if w == nil {
+		return nil
+	}
+	log := clog.FromContext(ctx).WithCaller()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, ref := range refs {
+		gvk := ref.GroupVersionKind()
+		if gvk.Empty() {
+			continue
+		}
+		if _, ok := w.watched[gvk]; ok {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		if err := w.controller.Watch(source.Kind(w.cache, client.Object(obj), w.eventHandler())); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", gvk, err)
+		}
+		w.watched[gvk] = struct{}{}
+		log.Info("start watching child resources", "apiVersion", gvk.GroupVersion().String(), "kind", gvk.Kind)
+	}
+	return nil
+}
+
+// reader returns the cache if the kind is watched, otherwise the given reader.
+// The cache is not used for the kinds not watched not to start a new informer implicitly.
+// The objects without the instance label are not found in the cache and they are applied again.
+func (w *childWatcher) reader(gvk schema.GroupVersionKind, r client.Reader) client.Reader {
+	if w == nil {
+		return r
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.watched[gvk]; ok {
+		return w.cache
+	}
+	return r
+}
+
+// eventHandler enqueues the owner instance on the update or delete events of the child resources.
+// Create events are ignored as the child resources are created by the instance itself,
+// and status-only updates are ignored as they are not drifts.
+func (w *childWatcher) eventHandler() handler.EventHandler {
+	return handler.Funcs{
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			if !specChanged(e.ObjectOld, e.ObjectNew) {
+				return
+			}
+			w.enqueue(e.ObjectNew, q)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			w.enqueue(e.Object, q)
+		},
+	}
+}
+
+func (w *childWatcher) enqueue(obj client.Object, q workqueue.RateLimitingInterface) {
+	for _, req := range ownerInstanceRequests(obj, w.ownerKind) {
+		// the same request waiting in the queue is not added twice
+		q.AddAfter(req, childResyncDelay)
+	}
+}
+
+// ownerInstanceRequests returns the request of the instance owning the object by the instance label.
+// The owner references are checked to distinguish Instance and ClusterInstance of the same name.
+func ownerInstanceRequests(obj client.Object, ownerKind string) []reconcile.Request {
+	name := obj.GetLabels()[cosmov1alpha1.LabelKeyInstanceName]
+	if name == "" {
+		return nil
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil || gv.Group != cosmov1alpha1.GroupVersion.Group {
+			continue
+		}
+		if (ref.Kind == "Instance" || ref.Kind == "ClusterInstance") && ref.Kind != ownerKind {
+			return nil
+		}
+	}
+	key := types.NamespacedName{Name: name}
+	if ownerKind == "Instance" {
+		if obj.GetNamespace() == "" {
+			return nil
+		}
+		key.Namespace = obj.GetNamespace()
+	}
+	return []reconcile.Request{{NamespacedName: key}}
+}
+
+// specChanged returns true if the object is changed other than status and the metadata managed by the server
+func specChanged(oldObj, newObj client.Object) bool {
+	o, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+	n, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+	o, n = o.DeepCopy(), n.DeepCopy()
+	for _, u := range []*unstructured.Unstructured{o, n} {
+		unstructured.RemoveNestedField(u.Object, "status")
+		unstructured.RemoveNestedField(u.Object, "metadata", "resourceVersion")
+		unstructured.RemoveNestedField(u.Object, "metadata", "managedFields")
+	}
+	return !equality.Semantic.DeepEqual(o.Object, n.Object)
+}
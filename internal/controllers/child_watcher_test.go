@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func Test_ownerInstanceRequests(t *testing.T) {
+	child := func(namespace string, labels map[string]string, ownerKind string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("apps/v1")
+		obj.SetKind("Deployment")
+		obj.SetName("ws1-workspace")
+		obj.SetNamespace(namespace)
+		obj.SetLabels(labels)
+		if ownerKind != "" {
+			obj.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: cosmov1alpha1.GroupVersion.String(), Kind: ownerKind, Name: "ws1"}})
+		}
+		return obj
+	}
+	instLabel := map[string]string{cosmov1alpha1.LabelKeyInstanceName: "ws1"}
+
+	tests := []struct {
+		name      string
+		obj       *unstructured.Unstructured
+		ownerKind string
+		want      []reconcile.Request
+	}{
+		{
+			name:      "✅ Instance",
+			obj:       child("cosmo-user-tom", instLabel, "Instance"),
+			ownerKind: "Instance",
+			want:      []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "cosmo-user-tom", Name: "ws1"}}},
+		},
+		{
+			name:      "✅ Instance without owner reference",
+			obj:       child("cosmo-user-tom", instLabel, ""),
+			ownerKind: "Instance",
+			want:      []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "cosmo-user-tom", Name: "ws1"}}},
+		},
+		{
+			name:      "✅ ClusterInstance",
+			obj:       child("cosmo-user-tom", instLabel, "ClusterInstance"),
+			ownerKind: "ClusterInstance",
+			want:      []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "ws1"}}},
+		},
+		{
+			name:      "❌ Owned by ClusterInstance",
+			obj:       child("cosmo-user-tom", instLabel, "ClusterInstance"),
+			ownerKind: "Instance",
+			want:      nil,
+		},
+		{
+			name:      "❌ Cluster scoped resource for Instance",
+			obj:       child("", instLabel, ""),
+			ownerKind: "Instance",
+			want:      nil,
+		},
+		{
+			name:      "❌ No instance label",
+			obj:       child("cosmo-user-tom", nil, "Instance"),
+			ownerKind: "Instance",
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ownerInstanceRequests(tt.obj, tt.ownerKind)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ownerInstanceRequests() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_specChanged(t *testing.T) {
+	base := func() *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "ws1-workspace", "resourceVersion": "1"},
+			"spec":       map[string]interface{}{"replicas": int64(1)},
+			"status":     map[string]interface{}{"readyReplicas": int64(0)},
+		}}
+		return obj
+	}
+	tests := []struct {
+		name   string
+		mutate func(obj *unstructured.Unstructured)
+		want   bool
+	}{
+		{
+			name: "✅ Spec changed",
+			mutate: func(obj *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(obj.Object, int64(0), "spec", "replicas")
+			},
+			want: true,
+		},
+		{
+			name: "✅ Labels changed",
+			mutate: func(obj *unstructured.Unstructured) {
+				obj.SetLabels(map[string]string{"foo": "bar"})
+			},
+			want: true,
+		},
+		{
+			name: "❌ Status only",
+			mutate: func(obj *unstructured.Unstructured) {
+				obj.SetResourceVersion("2")
+				_ = unstructured.SetNestedField(obj.Object, int64(1), "status", "readyReplicas")
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldObj := base()
+			newObj := base()
+			tt.mutate(newObj)
+			if got := specChanged(oldObj, newObj); got != tt.want {
+				t.Errorf("specChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
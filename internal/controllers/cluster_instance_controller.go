@@ -3,9 +3,10 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -25,6 +26,9 @@ type ClusterInstanceReconciler struct {
 	Recorder record.EventRecorder
 	Scheme   *runtime.Scheme
 	Domain   string
+	// FullResyncInterval is the interval to compare all the child resources with the desired state by dry-run.
+	// The child resources not changed since the last apply are skipped between the full resyncs. 0 disables skipping.
+	FullResyncInterval time.Duration
 
 	impl instanceReconciler
 }
@@ -43,56 +47,72 @@ func (r *ClusterInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	before := inst.DeepCopy()
 	log.DebugAll().DumpObject(r.Scheme, before, "request object")
+	inst.Status.ObservedGeneration = inst.Generation
 
 	tmpl := &cosmov1alpha1.ClusterTemplate{}
 	err := r.Client.Get(ctx, types.NamespacedName{Name: inst.Spec.Template.Name}, tmpl)
 	if err != nil {
 		log.Error(err, "failed to get cluster template", "tmplName", inst.Spec.Template.Name)
-		return ctrl.Result{}, err
+		setNotReadyCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeTemplateResolved, cosmov1alpha1.ConditionReasonTemplateNotFound, err.Error())
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, before, &inst, err)
 	}
 	inst.Status.TemplateName = tmpl.Name
 	inst.Status.TemplateResourceVersion = tmpl.ResourceVersion
 
 	// 1. Build Unstructured objects
-	objects, err := template.BuildObjects(tmpl.Spec, &inst, r.Domain)
+	objects, err := template.BuildObjects(tmpl, &inst, r.Domain, nil)
 	if err != nil {
 		kosmo.InstanceEventf(r.Recorder, &inst, corev1.EventTypeWarning, "BuildFailed", "Failed to build manifests from Template: %v", err)
-		return ctrl.Result{}, err
+		setNotReadyCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeTemplateResolved, cosmov1alpha1.ConditionReasonBuildFailed, err.Error())
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, before, &inst, err)
 	}
 
 	// 2. Transform the objects
 	objects, err = transformer.ApplyTransformers(ctx, transformer.AllTransformers(&inst, r.Scheme, tmpl), objects)
 	if err != nil {
 		kosmo.InstanceEventf(r.Recorder, &inst, corev1.EventTypeWarning, "BuildFailed", "Failed to build resources: %v", err)
-		return ctrl.Result{}, err
+		setNotReadyCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeTemplateResolved, cosmov1alpha1.ConditionReasonBuildFailed, err.Error())
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, before, &inst, err)
 	}
+	setCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeTemplateResolved, metav1.ConditionTrue, cosmov1alpha1.ConditionReasonTemplateFound, "")
 
 	// 3. Reconcile objects
-	if errs := r.impl.reconcileObjects(ctx, &inst, objects); len(errs) != 0 {
+	opts := r.impl.syncOptions(before, tmpl.ResourceVersion, tmpl.Spec.SyncPolicy, inst.Spec.SyncPolicy)
+	waiting, errs := r.impl.reconcileObjects(ctx, &inst, objects, opts)
+	if len(errs) != 0 {
 		for _, err := range errs {
 			kosmo.InstanceEventf(r.Recorder, &inst, corev1.EventTypeWarning, "SyncFailed", "Failed to sync objects: %v", err)
 		}
+		setNotReadyCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeSynced, cosmov1alpha1.ConditionReasonSyncFailed, errs[0].Error())
 		// requeue
-		return ctrl.Result{}, fmt.Errorf("apply child objects failed: %w", errs[0])
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, before, &inst, fmt.Errorf("apply child objects failed: %w", errs[0]))
 	}
-
-	// 4. Update status
-	if !equality.Semantic.DeepEqual(before, &inst) {
-		log.Debug().PrintObjectDiff(before, &inst)
-		if err := r.Status().Update(ctx, &inst); err != nil {
-			log.Error(err, "failed to update InstanceStatus")
+	if waiting != "" {
+		setNotReadyCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeSynced, cosmov1alpha1.ConditionReasonProgressing, waiting)
+		if err := r.impl.updateStatus(ctx, before, &inst); err != nil {
 			return ctrl.Result{}, err
 		}
-		log.Info("status updated")
+		return ctrl.Result{RequeueAfter: syncWaveRequeueInterval}, nil
+	}
+	setInstanceReadyCondition(&inst)
+
+	// 4. Update status
+	if err := r.impl.updateStatus(ctx, before, &inst); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	log.Debug().Info("finish reconcile")
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: r.impl.requeueAfter(&inst)}, nil
 }
 
 func (r *ClusterInstanceReconciler) SetupWithManager(mgr ctrl.Manager, fieldManager string) error {
-	r.impl = instanceReconciler{Client: r.Client, Recorder: r.Recorder, Scheme: r.Scheme, FieldManager: fieldManager}
-	return ctrl.NewControllerManagedBy(mgr).
+	r.impl = instanceReconciler{Client: r.Client, Recorder: r.Recorder, Scheme: r.Scheme, FieldManager: fieldManager, FullResyncInterval: r.FullResyncInterval}
+	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&cosmov1alpha1.ClusterInstance{}).
-		Complete(r)
+		Build(r)
+	if err != nil {
+		return err
+	}
+	r.impl.children, err = newChildWatcher(c, mgr, "ClusterInstance")
+	return err
 }
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+)
+
+// setCondition sets the condition with the observed generation.
+// LastTransitionTime is updated only when the status is changed.
+func setCondition(conditions *[]metav1.Condition, generation int64, condType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// setNotReadyCondition sets the condition to false and Ready to false with the same reason
+func setNotReadyCondition(conditions *[]metav1.Condition, generation int64, condType, reason, message string) {
+	setCondition(conditions, generation, condType, metav1.ConditionFalse, reason, message)
+	setCondition(conditions, generation, cosmov1alpha1.ConditionTypeReady, metav1.ConditionFalse, reason, message)
+}
+
+// updateStatusOnError updates the status to report the failed conditions and returns the reconcile error as it is
+func updateStatusOnError(ctx context.Context, c client.StatusClient, before, obj client.Object, reconcileErr error) error {
+	if equality.Semantic.DeepEqual(before, obj) {
+		return reconcileErr
+	}
+	if err := c.Status().Update(ctx, obj); err != nil && !apierrs.IsConflict(err) {
+		clog.FromContext(ctx).Error(err, "failed to update status conditions", "reconcileError", reconcileErr.Error())
+	}
+	return reconcileErr
+}
@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+var ignoreTransitionTime = cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")
+
+func trueCondition(condType string) metav1.Condition {
+	return metav1.Condition{Type: condType, Status: metav1.ConditionTrue, ObservedGeneration: 1, Reason: "Test"}
+}
+
+func Test_setWorkspaceReadyCondition(t *testing.T) {
+	synced := []metav1.Condition{
+		trueCondition(cosmov1alpha1.ConditionTypeTemplateResolved),
+		trueCondition(cosmov1alpha1.ConditionTypeSynced),
+		trueCondition(cosmov1alpha1.ConditionTypeRoutingReady),
+	}
+	tests := []struct {
+		name       string
+		phase      string
+		reason     string
+		message    string
+		conditions []metav1.Condition
+		want       metav1.Condition
+	}{
+		{
+			name:       "✅ Running",
+			phase:      "Running",
+			conditions: synced,
+			want:       metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, ObservedGeneration: 1, Reason: "Ready"},
+		},
+		{
+			name:       "❌ Stopped",
+			phase:      "Stopped",
+			conditions: synced,
+			want:       metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "Stopped", Message: "workspace is Stopped"},
+		},
+		{
+			name:       "❌ Starting",
+			phase:      "Starting",
+			conditions: synced,
+			want:       metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "Progressing", Message: "workspace is starting"},
+		},
+		{
+			name:       "❌ Pod error",
+			phase:      "CrashLoopBackOff",
+			conditions: synced,
+			want:       metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "NotReady", Message: "workspace pod is CrashLoopBackOff"},
+		},
+		{
+			name:       "❌ Pod error with message",
+			phase:      "ImagePullBackOff",
+			message:    "pod ws1-0: container main: Back-off pulling image",
+			conditions: synced,
+			want:       metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "NotReady", Message: "workspace pod is ImagePullBackOff: pod ws1-0: container main: Back-off pulling image"},
+		},
+		{
+			name:       "❌ Start timeout",
+			phase:      "Failed",
+			reason:     "StartTimeout",
+			message:    "workspace is not running in 10m0s",
+			conditions: synced,
+			want:       metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "StartTimeout", Message: "workspace is not running in 10m0s"},
+		},
+		{
+			name:  "❌ Routing failed",
+			phase: "Running",
+			conditions: []metav1.Condition{
+				trueCondition(cosmov1alpha1.ConditionTypeTemplateResolved),
+				trueCondition(cosmov1alpha1.ConditionTypeSynced),
+				{Type: cosmov1alpha1.ConditionTypeRoutingReady, Status: metav1.ConditionFalse, Reason: "SyncFailed", Message: "forbidden"},
+			},
+			want: metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "SyncFailed", Message: "RoutingReady is False: forbidden"},
+		},
+		{
+			name:       "❌ Not reported yet",
+			phase:      "Running",
+			conditions: nil,
+			want:       metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "Progressing", Message: "TemplateResolved is not reported yet"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &cosmov1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec:       cosmov1alpha1.WorkspaceSpec{Replicas: ptr.To(int64(1))},
+				Status: cosmov1alpha1.WorkspaceStatus{
+					Phase:      tt.phase,
+					Reason:     tt.reason,
+					Message:    tt.message,
+					Conditions: append([]metav1.Condition{}, tt.conditions...),
+				},
+			}
+			setWorkspaceReadyCondition(ws)
+
+			var got metav1.Condition
+			for _, c := range ws.Status.Conditions {
+				if c.Type == cosmov1alpha1.ConditionTypeReady {
+					got = c
+				}
+			}
+			if diff := cmp.Diff(tt.want, got, ignoreTransitionTime); diff != "" {
+				t.Errorf("setWorkspaceReadyCondition() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_setWorkspaceSyncedCondition(t *testing.T) {
+	tests := []struct {
+		name string
+		inst cosmov1alpha1.Instance
+		want metav1.Condition
+	}{
+		{
+			name: "✅ Instance is ready",
+			inst: cosmov1alpha1.Instance{
+				ObjectMeta: metav1.ObjectMeta{Name: "ws1", Generation: 2},
+				Status: cosmov1alpha1.InstanceStatus{
+					Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, ObservedGeneration: 2, Reason: "Ready"}},
+				},
+			},
+			want: metav1.Condition{Type: "Synced", Status: metav1.ConditionTrue, ObservedGeneration: 1, Reason: "Synced"},
+		},
+		{
+			name: "❌ Instance is not reconciled for the latest generation",
+			inst: cosmov1alpha1.Instance{
+				ObjectMeta: metav1.ObjectMeta{Name: "ws1", Generation: 3},
+				Status: cosmov1alpha1.InstanceStatus{
+					Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, ObservedGeneration: 2, Reason: "Ready"}},
+				},
+			},
+			want: metav1.Condition{Type: "Synced", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "Progressing", Message: "instance ws1 is not reconciled yet"},
+		},
+		{
+			name: "❌ Instance failed",
+			inst: cosmov1alpha1.Instance{
+				ObjectMeta: metav1.ObjectMeta{Name: "ws1", Generation: 2},
+				Status: cosmov1alpha1.InstanceStatus{
+					Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 2, Reason: "BuildFailed", Message: "failed to render template"}},
+				},
+			},
+			want: metav1.Condition{Type: "Synced", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "BuildFailed", Message: "failed to render template"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &cosmov1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+			setWorkspaceSyncedCondition(ws, &tt.inst)
+			if diff := cmp.Diff([]metav1.Condition{tt.want}, ws.Status.Conditions, ignoreTransitionTime); diff != "" {
+				t.Errorf("setWorkspaceSyncedCondition() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_setUserReadyCondition(t *testing.T) {
+	tests := []struct {
+		name           string
+		phase          corev1.NamespacePhase
+		addonErrs      []error
+		notReadyAddons []string
+		want           []metav1.Condition
+	}{
+		{
+			name:  "✅ Ready",
+			phase: corev1.NamespaceActive,
+			want: []metav1.Condition{
+				{Type: "AddonsReady", Status: metav1.ConditionTrue, ObservedGeneration: 1, Reason: "Ready"},
+				{Type: "Ready", Status: metav1.ConditionTrue, ObservedGeneration: 1, Reason: "Ready"},
+			},
+		},
+		{
+			name:      "❌ Addon failed",
+			phase:     "AddonFailed",
+			addonErrs: []error{errors.New("template not found")},
+			want: []metav1.Condition{
+				{Type: "AddonsReady", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "AddonsFailed", Message: "template not found"},
+				{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "AddonsFailed", Message: "template not found"},
+			},
+		},
+		{
+			name:           "❌ Addon not ready",
+			phase:          corev1.NamespaceActive,
+			notReadyAddons: []string{"addon1", "addon2"},
+			want: []metav1.Condition{
+				{Type: "AddonsReady", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "Progressing", Message: "addons are not ready: addon1,addon2"},
+				{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "Progressing", Message: "addons are not ready: addon1,addon2"},
+			},
+		},
+		{
+			name:  "❌ Namespace terminating",
+			phase: corev1.NamespaceTerminating,
+			want: []metav1.Condition{
+				{Type: "AddonsReady", Status: metav1.ConditionTrue, ObservedGeneration: 1, Reason: "Ready"},
+				{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 1, Reason: "NotReady", Message: "namespace is Terminating"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &cosmov1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Status:     cosmov1alpha1.UserStatus{Phase: tt.phase},
+			}
+			setUserReadyCondition(user, tt.addonErrs, tt.notReadyAddons)
+			if diff := cmp.Diff(tt.want, user.Status.Conditions, ignoreTransitionTime); diff != "" {
+				t.Errorf("setUserReadyCondition() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,22 +2,35 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"slices"
 	"sort"
+	"strconv"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/instance"
 	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
 	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
 	"github.com/cosmo-workspace/cosmo/pkg/template"
@@ -30,6 +43,9 @@ type InstanceReconciler struct {
 	Recorder record.EventRecorder
 	Scheme   *runtime.Scheme
 	Domain   string
+	// FullResyncInterval is the interval to compare all the child resources with the desired state by dry-run.
+	// The child resources not changed since the last apply are skipped between the full resyncs. 0 disables skipping.
+	FullResyncInterval time.Duration
 
 	impl instanceReconciler
 }
@@ -51,155 +67,310 @@ func (r *InstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	log = log.WithValues("UID", inst.UID, "Template", inst.Spec.Template.Name)
 	ctx = clog.IntoContext(ctx, log)
 
+	// reconciled again when the annotation is removed
+	if pausedBy := kubeutil.GetAnnotation(&inst, cosmov1alpha1.InstanceAnnKeyReconcilePaused); pausedBy != "" {
+		log.Info("reconcile is paused", "pausedBy", pausedBy)
+		return ctrl.Result{}, nil
+	}
+
 	before := inst.DeepCopy()
 	log.DebugAll().DumpObject(r.Scheme, before, "request object")
+	inst.Status.ObservedGeneration = inst.Generation
 
-	tmpl := &cosmov1alpha1.Template{}
-	err := r.Client.Get(ctx, types.NamespacedName{Name: inst.GetSpec().Template.Name}, tmpl)
+	tmpl, err := template.GetTemplate(ctx, r.Client, inst.Spec.Template)
 	if err != nil {
-		log.Error(err, "failed to get template", "tmplName", inst.Spec.Template.Name)
-		return ctrl.Result{}, err
+		log.Error(err, "failed to get template", "tmplName", inst.Spec.Template.Name, "revision", inst.Spec.Template.Revision)
+		setNotReadyCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeTemplateResolved, cosmov1alpha1.ConditionReasonTemplateNotFound, err.Error())
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, before, &inst, err)
 	}
 	inst.Status.TemplateName = tmpl.Name
 	inst.Status.TemplateResourceVersion = tmpl.ResourceVersion
 
 	// 1. Build Unstructured objects
-	objects, err := template.BuildObjects(tmpl.Spec, &inst, r.Domain)
+	secretVars, err := template.GetSecretVars(ctx, r.Client, &inst, tmpl.Spec)
+	if err != nil {
+		kosmo.InstanceEventf(r.Recorder, &inst, corev1.EventTypeWarning, "BuildFailed", "Failed to get secret vars: %v", err)
+		setNotReadyCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeTemplateResolved, cosmov1alpha1.ConditionReasonBuildFailed, err.Error())
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, before, &inst, err)
+	}
+	objects, err := template.BuildObjects(tmpl, &inst, r.Domain, secretVars)
 	if err != nil {
 		kosmo.InstanceEventf(r.Recorder, &inst, corev1.EventTypeWarning, "BuildFailed", "Failed to build manifests from Template: %v", err)
-		return ctrl.Result{}, err
+		setNotReadyCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeTemplateResolved, cosmov1alpha1.ConditionReasonBuildFailed, err.Error())
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, before, &inst, err)
 	}
 
 	// 2. Transform the objects
 	objects, err = transformer.ApplyTransformers(ctx, transformer.AllTransformers(&inst, r.Scheme, tmpl), objects)
 	if err != nil {
 		kosmo.InstanceEventf(r.Recorder, &inst, corev1.EventTypeWarning, "BuildFailed", "Failed to build resources: %v", err)
-		return ctrl.Result{}, err
+		setNotReadyCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeTemplateResolved, cosmov1alpha1.ConditionReasonBuildFailed, err.Error())
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, before, &inst, err)
 	}
+	setCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeTemplateResolved, metav1.ConditionTrue, cosmov1alpha1.ConditionReasonTemplateFound, "")
 
 	// 3. Reconcile objects
-	if errs := r.impl.reconcileObjects(ctx, &inst, objects); len(errs) != 0 {
+	opts := r.impl.syncOptions(before, tmpl.ResourceVersion, tmpl.Spec.SyncPolicy, inst.Spec.SyncPolicy)
+	waiting, errs := r.impl.reconcileObjects(ctx, &inst, objects, opts)
+	if len(errs) != 0 {
 		for _, err := range errs {
 			kosmo.InstanceEventf(r.Recorder, &inst, corev1.EventTypeWarning, "SyncFailed", "Failed to sync objects: %v", err)
 		}
+		setNotReadyCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeSynced, cosmov1alpha1.ConditionReasonSyncFailed, errs[0].Error())
 		// requeue
-		return ctrl.Result{}, fmt.Errorf("apply child objects failed: %w", errs[0])
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, before, &inst, fmt.Errorf("apply child objects failed: %w", errs[0]))
 	}
-
-	// 4. Update status
-	if !equality.Semantic.DeepEqual(before, &inst) {
-		log.Debug().PrintObjectDiff(before, &inst)
-		if err := r.Status().Update(ctx, &inst); err != nil {
-			log.Error(err, "failed to update InstanceStatus")
+	if waiting != "" {
+		setNotReadyCondition(&inst.Status.Conditions, inst.Generation, cosmov1alpha1.ConditionTypeSynced, cosmov1alpha1.ConditionReasonProgressing, waiting)
+		if err := r.impl.updateStatus(ctx, before, &inst); err != nil {
 			return ctrl.Result{}, err
 		}
-		log.Info("status updated")
+		return ctrl.Result{RequeueAfter: syncWaveRequeueInterval}, nil
+	}
+	setInstanceReadyCondition(&inst)
+
+	// 4. Update status
+	if err := r.impl.updateStatus(ctx, before, &inst); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	log.Debug().Info("finish reconcile")
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: r.impl.requeueAfter(&inst)}, nil
 }
 
 func (r *InstanceReconciler) SetupWithManager(mgr ctrl.Manager, fieldManager string) error {
-	r.impl = instanceReconciler{Client: r.Client, Recorder: r.Recorder, Scheme: r.Scheme, FieldManager: fieldManager}
-	return ctrl.NewControllerManagedBy(mgr).
+	r.impl = instanceReconciler{Client: r.Client, Recorder: r.Recorder, Scheme: r.Scheme, FieldManager: fieldManager, FullResyncInterval: r.FullResyncInterval}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &cosmov1alpha1.Instance{}, instanceSecretVarsIndexKey, indexInstanceBySecretVars); err != nil {
+		return err
+	}
+	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&cosmov1alpha1.Instance{}).
-		Complete(r)
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findInstancesBySecretVars), builder.WithPredicates(secretVarsPredicate)).
+		Build(r)
+	if err != nil {
+		return err
+	}
+	r.impl.children, err = newChildWatcher(c, mgr, "Instance")
+	return err
+}
+
+// instanceSecretVarsIndexKey is the field index of Instance by the name of the secret vars Secret
+const instanceSecretVarsIndexKey = "spec.secretVarsRef.name"
+
+// secretVarsPredicate filters the Secrets to the secret vars of workspaces, which are labeled with the workspace name
+var secretVarsPredicate = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	_, ok := obj.GetLabels()[cosmov1alpha1.LabelKeyWorkspaceName]
+	return ok
+})
+
+func indexInstanceBySecretVars(obj client.Object) []string {
+	inst, ok := obj.(*cosmov1alpha1.Instance)
+	if !ok || inst.Spec.SecretVarsRef == nil {
+		return nil
+	}
+	return []string{inst.Spec.SecretVarsRef.Name}
 }
 
+// findInstancesBySecretVars returns the instances referencing the Secret as secret vars
+func (r *InstanceReconciler) findInstancesBySecretVars(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := clog.FromContext(ctx).WithName("findInstancesBySecretVars")
+
+	var insts cosmov1alpha1.InstanceList
+	if err := r.List(ctx, &insts, client.InNamespace(obj.GetNamespace()), client.MatchingFields{instanceSecretVarsIndexKey: obj.GetName()}); err != nil {
+		log.Error(err, "failed to list instances", "namespace", obj.GetNamespace())
+		return nil
+	}
+	reqs := make([]reconcile.Request, 0, len(insts.Items))
+	for _, inst := range insts.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: inst.Name, Namespace: inst.Namespace}})
+	}
+	return reqs
+}
+
+// syncWaveRequeueInterval is the interval to check the health of the resources in the waiting sync wave
+const syncWaveRequeueInterval = 5 * time.Second
+
 type instanceReconciler struct {
 	client.Client
-	Recorder     record.EventRecorder
-	Scheme       *runtime.Scheme
-	FieldManager string
+	Recorder           record.EventRecorder
+	Scheme             *runtime.Scheme
+	FieldManager       string
+	FullResyncInterval time.Duration
+
+	children *childWatcher
+}
+
+// syncOptions are the options to reconcile the child objects
+type syncOptions struct {
+	policy cosmov1alpha1.SyncPolicy
+	// skipUnchanged is true to skip the objects not changed since the last apply
+	skipUnchanged bool
+}
+
+func (r *instanceReconciler) syncOptions(before cosmov1alpha1.InstanceObject, tmplResourceVersion string, policies ...*cosmov1alpha1.SyncPolicy) syncOptions {
+	return syncOptions{
+		policy:        mergeSyncPolicy(policies...),
+		skipUnchanged: alreadySynced(before, tmplResourceVersion) && r.untilFullResync(before) > 0,
+	}
+}
+
+// fullResyncTolerance is the margin to run the full resync on the requeue slightly before the interval passes,
+// as the last full sync time is stored in seconds.
+const fullResyncTolerance = time.Second
+
+// untilFullResync returns the duration until the next full resync, or zero if the full resync is due
+func (r *instanceReconciler) untilFullResync(inst cosmov1alpha1.InstanceObject) time.Duration {
+	lastFullSync := inst.GetStatus().LastFullSyncTime
+	if r.FullResyncInterval <= 0 || lastFullSync == nil {
+		return 0
+	}
+	d := time.Until(lastFullSync.Add(r.FullResyncInterval))
+	if d <= fullResyncTolerance {
+		return 0
+	}
+	return d
+}
+
+// requeueAfter returns the duration to requeue the instance for the next full resync.
+// The requeue is not delayed by the reconciliations between the full resyncs.
+func (r *instanceReconciler) requeueAfter(inst cosmov1alpha1.InstanceObject) time.Duration {
+	if d := r.untilFullResync(inst); d > 0 {
+		return d
+	}
+	return r.FullResyncInterval
+}
+
+// updateStatus updates the status of the instance if changed
+func (r *instanceReconciler) updateStatus(ctx context.Context, before, inst cosmov1alpha1.InstanceObject) error {
+	log := clog.FromContext(ctx).WithCaller()
+	if equality.Semantic.DeepEqual(before, inst) {
+		return nil
+	}
+	log.Debug().PrintObjectDiff(before, inst)
+	if err := r.Status().Update(ctx, inst); err != nil {
+		log.Error(err, "failed to update InstanceStatus")
+		return err
+	}
+	log.Info("status updated")
+	return nil
+}
+
+// alreadySynced returns true if the instance was synced for the current generation and template
+func alreadySynced(before cosmov1alpha1.InstanceObject, tmplResourceVersion string) bool {
+	status := before.GetStatus()
+	cond := meta.FindStatusCondition(status.Conditions, cosmov1alpha1.ConditionTypeSynced)
+	return cond != nil && cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == before.GetGeneration() &&
+		status.TemplateResourceVersion == tmplResourceVersion
+}
+
+// setInstanceReadyCondition sets Synced and Ready conditions after all the objects are applied
+func setInstanceReadyCondition(inst cosmov1alpha1.InstanceObject) {
+	status := inst.GetStatus()
+	msg := fmt.Sprintf("%d/%d objects are applied", status.LastAppliedObjectsCount, status.TemplateObjectsCount)
+	setCondition(&status.Conditions, inst.GetGeneration(), cosmov1alpha1.ConditionTypeSynced, metav1.ConditionTrue, cosmov1alpha1.ConditionReasonSynced, msg)
+	setCondition(&status.Conditions, inst.GetGeneration(), cosmov1alpha1.ConditionTypeReady, metav1.ConditionTrue, cosmov1alpha1.ConditionReasonReady, "")
 }
 
-func (r *instanceReconciler) reconcileObjects(ctx context.Context, inst cosmov1alpha1.InstanceObject, objects []unstructured.Unstructured) []error {
+func (r *instanceReconciler) reconcileObjects(ctx context.Context, inst cosmov1alpha1.InstanceObject, objects []unstructured.Unstructured, opts syncOptions) (waiting string, errs []error) {
 	log := clog.FromContext(ctx).WithCaller()
-	errs := make([]error, 0)
+	errs = make([]error, 0)
 
 	lastApplied := make([]cosmov1alpha1.ObjectRef, len(inst.GetStatus().LastApplied))
 	copy(lastApplied, inst.GetStatus().LastApplied)
+	lastWaitingWave := inst.GetStatus().SyncWave
 
 	currAppliedMap := make(map[types.UID]cosmov1alpha1.ObjectRef)
 
-	// check dry-run apply on first reconciliation
-	if len(inst.GetStatus().LastApplied) == 0 {
-		for _, built := range objects {
-			if _, err := r.dryrunApply(ctx, &built, r.FieldManager); err != nil {
-				// ignore NotFound in case the template contains a dependency resource that was not found.
-				if !apierrs.IsNotFound(err) {
-					errs = append(errs, fmt.Errorf("dryrun failed: kind=%s name=%s: %w", built.GetKind(), built.GetName(), err))
-				}
-			}
-		}
-		if len(errs) != 0 {
-			return errs
-		}
-	}
-
 	inst.GetStatus().TemplateObjectsCount = len(objects)
+	inst.GetStatus().SyncWave = nil
 
-	for _, built := range objects {
-		mapping, err := r.RESTMapper().RESTMapping(built.GroupVersionKind().GroupKind(), built.GroupVersionKind().Version)
-		if err != nil {
-			errs = append(errs, fmt.Errorf("failed to get rest mapping: kind=%s name=%s: %w", built.GetKind(), built.GetName(), err))
-			continue
-		}
+	waves, err := groupBySyncWave(objects)
+	if err != nil {
+		return "", []error{err}
+	}
 
-		// namespaced scope instance cannot create cluster scope resources
-		if inst.GetScope() == meta.RESTScopeNamespace && mapping.Scope != inst.GetScope() {
-			errs = append(errs, fmt.Errorf("kind %s is not scope %s: scope=%s name=%s", built.GetKind(), inst.GetScope(), mapping.Scope.Name(), built.GetName()))
-			continue
+	allWavesApplied := false
+	for i, w := range waves {
+		// check dry-run apply on first reconciliation of the wave
+		if len(lastApplied) == 0 || (lastWaitingWave != nil && w.wave > *lastWaitingWave) {
+			for _, built := range w.objects {
+				if _, err := r.dryrunApply(ctx, &built, r.FieldManager, !opts.policy.DisableForceApply); err != nil {
+					// ignore NotFound in case the template contains a dependency resource that was not found.
+					// conflicts without force apply are reported on reconciling each object.
+					if !apierrs.IsNotFound(err) && !(opts.policy.DisableForceApply && apierrs.IsConflict(err)) {
+						errs = append(errs, fmt.Errorf("dryrun failed: kind=%s name=%s: %w", built.GetKind(), built.GetName(), err))
+					}
+				}
+			}
+			if len(errs) != 0 {
+				break
+			}
 		}
 
-		current, err := kubeutil.GetUnstructured(ctx, r.Client, built.GroupVersionKind(), built.GetName(), built.GetNamespace())
-		if err != nil {
-			// if not found, create resource
-			if apierrs.IsNotFound(err) {
-				log.Info("creating new built resource", "kind", built.GetKind(), "name", built.GetName())
-				log.Debug().DumpObject(r.Scheme, &built, "built object")
-
-				created, err := r.apply(ctx, &built, r.FieldManager)
-				if err != nil {
-					errs = append(errs, fmt.Errorf("failed to create resource: kind = %s name = %s: %w", built.GetKind(), built.GetName(), err))
+		lives := make([]*unstructured.Unstructured, 0, len(w.objects))
+		for _, built := range w.objects {
+			hash, err := builtHash(&built)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			// the built object is not changed since the last apply
+			lastRef, builtUnchanged := lastAppliedRef(inst, &built, hash)
+			if opts.skipUnchanged && builtUnchanged {
+				if live, ok := r.getIfUnchanged(ctx, lastRef, &built); ok {
+					ref := unstToObjectRef(live)
+					ref.BuiltHash = hash
+					currAppliedMap[live.GetUID()] = ref
+					lives = append(lives, live)
 					continue
 				}
-				kosmo.InstanceEventf(r.Recorder, inst, corev1.EventTypeNormal, "Synced", "%s %s is created", built.GetKind(), built.GetName())
-				currAppliedMap[created.GetUID()] = unstToObjectRef(created)
-
-			} else {
-				errs = append(errs, fmt.Errorf("failed to get resource: kind = %s name = %s: %w", built.GetKind(), built.GetName(), err))
-				continue
 			}
-
-		} else {
-			// get desired state
-			desired, err := r.dryrunApply(ctx, &built, r.FieldManager)
+			live, desired, err := r.reconcileObject(ctx, inst, built, opts, builtUnchanged)
 			if err != nil {
-				errs = append(errs, fmt.Errorf("dryrun failed: kind=%s name=%s: %w", built.GetKind(), built.GetName(), err))
+				errs = append(errs, err)
 				continue
 			}
-			currAppliedMap[desired.GetUID()] = unstToObjectRef(desired)
-
-			// compare current with the desired state
-			if !kubeutil.LooseDeepEqual(current, desired) {
-				log.Info("current is not desired state, synced", "kind", desired.GetKind(), "name", desired.GetName())
-				log.Debug().PrintObjectDiff(current, desired)
-
-				// apply
-				log.DumpObject(r.Scheme, &built, "applying object")
-				if _, err := r.apply(ctx, &built, r.FieldManager); err != nil {
-					errs = append(errs, fmt.Errorf("failed to apply resource %s %s: %w", built.GetKind(), built.GetName(), err))
-				} else {
-					kosmo.InstanceEventf(r.Recorder, inst, corev1.EventTypeNormal, "Synced", "%s %s is not desired state, synced", built.GetKind(), built.GetName())
-				}
+			ref := unstToObjectRef(live)
+			if desired {
+				ref.BuiltHash = hash
+			}
+			currAppliedMap[live.GetUID()] = ref
+			lives = append(lives, live)
+		}
+		if len(errs) != 0 {
+			allWavesApplied = i == len(waves)-1
+			break
+		}
+		if i == len(waves)-1 {
+			allWavesApplied = true
+			break
+		}
+
+		// wait for the resources in the wave to become healthy before applying the next wave
+		for _, live := range lives {
+			if healthy, msg := r.checkHealth(ctx, live); !healthy {
+				waiting = fmt.Sprintf("waiting for sync wave %d: %s", w.wave, msg)
+				break
+			}
+		}
+		if waiting != "" {
+			inst.GetStatus().SyncWave = ptr.To(w.wave)
+			log.Debug().Info(waiting)
+			break
+		}
+	}
+
+	if !allWavesApplied {
+		// keep the references of the resources in the waves not applied yet
+		for _, ref := range lastApplied {
+			if _, ok := currAppliedMap[ref.UID]; !ok {
+				currAppliedMap[ref.UID] = ref
 			}
 		}
 	}
 
 	// garbage collection
-	if len(errs) == 0 && !cosmov1alpha1.KeepResourceDeletePolicy(inst) {
+	if allWavesApplied && len(errs) == 0 && !cosmov1alpha1.KeepResourceDeletePolicy(inst) {
 		log.Debug().Info("checking garbage collection")
 		shouldDeletes := objectRefNotExistsInMap(lastApplied, currAppliedMap)
 		for _, d := range shouldDeletes {
@@ -215,16 +386,249 @@ func (r *instanceReconciler) reconcileObjects(ctx context.Context, inst cosmov1a
 
 	inst.GetStatus().LastApplied = objectRefMapToSlice(currAppliedMap)
 	inst.GetStatus().LastAppliedObjectsCount = len(inst.GetStatus().LastApplied)
+	if allWavesApplied && len(errs) == 0 && !opts.skipUnchanged {
+		inst.GetStatus().LastFullSyncTime = ptr.To(metav1.Now())
+	}
+
+	// watch the child resources to correct the drift
+	if err := r.children.watch(ctx, inst.GetStatus().LastApplied); err != nil {
+		log.Error(err, "failed to watch child resources")
+	}
+
+	return waiting, errs
+}
+
+// reconcileObject creates or applies the built object if it is not desired state and returns the live object.
+// The fields ignored by the sync policy are kept as they are in the live object.
+// If the built object is not changed since the last apply, the difference is counted as a drift.
+// desired is false if the live object is left as it is because of the conflicts.
+func (r *instanceReconciler) reconcileObject(ctx context.Context, inst cosmov1alpha1.InstanceObject, built unstructured.Unstructured, opts syncOptions, builtUnchanged bool) (live *unstructured.Unstructured, desired bool, err error) {
+	log := clog.FromContext(ctx).WithCaller()
+	force := !opts.policy.DisableForceApply
+
+	mapping, err := r.RESTMapper().RESTMapping(built.GroupVersionKind().GroupKind(), built.GroupVersionKind().Version)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get rest mapping: kind=%s name=%s: %w", built.GetKind(), built.GetName(), err)
+	}
+
+	// namespaced scope instance cannot create cluster scope resources
+	if inst.GetScope() == meta.RESTScopeNamespace && mapping.Scope != inst.GetScope() {
+		return nil, false, fmt.Errorf("kind %s is not scope %s: scope=%s name=%s", built.GetKind(), inst.GetScope(), mapping.Scope.Name(), built.GetName())
+	}
+
+	ignored, err := ignoredFields(opts.policy, inst.GetName(), &built)
+	if err != nil {
+		return nil, false, err
+	}
+
+	current, err := kubeutil.GetUnstructured(ctx, r.Client, built.GroupVersionKind(), built.GetName(), built.GetNamespace())
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			return nil, false, fmt.Errorf("failed to get resource: kind = %s name = %s: %w", built.GetKind(), built.GetName(), err)
+		}
+		// if not found, create resource
+		log.Info("creating new built resource", "kind", built.GetKind(), "name", built.GetName())
+		log.Debug().DumpObject(r.Scheme, &built, "built object")
+
+		created, err := r.apply(ctx, &built, r.FieldManager, force)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to create resource: kind = %s name = %s: %w", built.GetKind(), built.GetName(), err)
+		}
+		kosmo.InstanceEventf(r.Recorder, inst, corev1.EventTypeNormal, "Synced", "%s %s is created", built.GetKind(), built.GetName())
+		if builtUnchanged {
+			driftCorrections.WithLabelValues(built.GetKind(), driftReasonDeleted).Inc()
+		}
+		return created, true, nil
+	}
+
+	// keep the ignored fields of the live object not to overwrite them
+	if len(ignored) > 0 {
+		built = *built.DeepCopy()
+		for _, p := range ignored {
+			p.RestoreFields(built.Object, current.Object)
+		}
+	}
+
+	// get desired state
+	dryrun, err := r.dryrunApply(ctx, &built, r.FieldManager, force)
+	if err != nil {
+		if !force && apierrs.IsConflict(err) {
+			r.reportConflict(ctx, inst, &built, err)
+			return current, false, nil
+		}
+		return nil, false, fmt.Errorf("dryrun failed: kind=%s name=%s: %w", built.GetKind(), built.GetName(), err)
+	}
+
+	// compare current with the desired state
+	if kubeutil.LooseDeepEqual(withoutFields(current, ignored), withoutFields(dryrun, ignored)) {
+		return dryrun, true, nil
+	}
+	log.Info("current is not desired state, synced", "kind", dryrun.GetKind(), "name", dryrun.GetName())
+	log.Debug().PrintObjectDiff(current, dryrun)
+
+	// apply
+	log.DumpObject(r.Scheme, &built, "applying object")
+	applied, err := r.apply(ctx, &built, r.FieldManager, force)
+	if err != nil {
+		if !force && apierrs.IsConflict(err) {
+			r.reportConflict(ctx, inst, &built, err)
+			return current, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to apply resource %s %s: %w", built.GetKind(), built.GetName(), err)
+	}
+	kosmo.InstanceEventf(r.Recorder, inst, corev1.EventTypeNormal, "Synced", "%s %s is not desired state, synced", built.GetKind(), built.GetName())
+	if builtUnchanged {
+		driftCorrections.WithLabelValues(built.GetKind(), driftReasonModified).Inc()
+	}
+	return applied, true, nil
+}
+
+// lastAppliedRef returns the reference of the object last applied and whether the built object is the same as the one last applied
+func lastAppliedRef(inst cosmov1alpha1.InstanceObject, built *unstructured.Unstructured, hash string) (cosmov1alpha1.ObjectRef, bool) {
+	lastApplied := inst.GetStatus().LastApplied
+	i := slices.IndexFunc(lastApplied, func(ref cosmov1alpha1.ObjectRef) bool {
+		return ref.Namespace == built.GetNamespace() && instance.IsTarget(ref, inst.GetName(), built)
+	})
+	if i < 0 {
+		return cosmov1alpha1.ObjectRef{}, false
+	}
+	return lastApplied[i], lastApplied[i].BuiltHash != "" && lastApplied[i].BuiltHash == hash
+}
+
+// getIfUnchanged returns the live object if it is not changed since the last apply.
+// The live object is read from the cache if the kind is watched.
+func (r *instanceReconciler) getIfUnchanged(ctx context.Context, ref cosmov1alpha1.ObjectRef, built *unstructured.Unstructured) (*unstructured.Unstructured, bool) {
+	live, err := kubeutil.GetUnstructured(ctx, r.children.reader(built.GroupVersionKind(), r.Client), built.GroupVersionKind(), built.GetName(), built.GetNamespace())
+	if err != nil || live.GetUID() != ref.UID {
+		return nil, false
+	}
+	// generation is not bumped by the metadata-only changes
+	if !metadataApplied(built, live) {
+		return nil, false
+	}
+	// resourceVersion is used for the resources without generation such as ConfigMap
+	if live.GetGeneration() > 0 {
+		return live, live.GetGeneration() == ref.Generation
+	}
+	return live, live.GetResourceVersion() == ref.ResourceVersion
+}
+
+// metadataApplied returns true if the live object has all the labels and annotations of the built object.
+// The labels and annotations added by others are ignored as they are not changed by apply.
+func metadataApplied(built, live *unstructured.Unstructured) bool {
+	contains := func(want, got map[string]string) bool {
+		for k, v := range want {
+			if gv, ok := got[k]; !ok || gv != v {
+				return false
+			}
+		}
+		return true
+	}
+	return contains(built.GetLabels(), live.GetLabels()) && contains(built.GetAnnotations(), live.GetAnnotations())
+}
+
+// builtHash returns the hash of the built object
+func builtHash(obj *unstructured.Unstructured) (string, error) {
+	b, err := obj.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal built object: kind=%s name=%s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// reportConflict records the field manager conflicts as an event instead of taking over the fields by force
+func (r *instanceReconciler) reportConflict(ctx context.Context, inst cosmov1alpha1.InstanceObject, obj *unstructured.Unstructured, err error) {
+	log := clog.FromContext(ctx).WithCaller()
+	log.Info("apply conflicts with other field managers", "kind", obj.GetKind(), "name", obj.GetName(), "error", err.Error())
+	kosmo.InstanceEventf(r.Recorder, inst, corev1.EventTypeWarning, "ApplyConflict", "%s %s is not synced: %v", obj.GetKind(), obj.GetName(), err)
+}
+
+// withoutFields returns a copy of the object without the fields
+func withoutFields(obj *unstructured.Unstructured, paths []kubeutil.FieldPath) *unstructured.Unstructured {
+	if len(paths) == 0 {
+		return obj
+	}
+	obj = obj.DeepCopy()
+	for _, p := range paths {
+		p.RemoveFields(obj.Object)
+	}
+	return obj
+}
+
+// checkHealth checks the resource is healthy to apply the next sync wave.
+// PersistentVolumeClaim of StorageClass with WaitForFirstConsumer is healthy while it is pending,
+// otherwise it cannot be bound until the pods in the later waves are scheduled.
+func (r *instanceReconciler) checkHealth(ctx context.Context, obj *unstructured.Unstructured) (bool, string) {
+	healthy, msg := kubeutil.CheckHealth(obj)
+	if !healthy && obj.GroupVersionKind().GroupKind().String() == "PersistentVolumeClaim" && r.waitForFirstConsumer(ctx, obj) {
+		return true, ""
+	}
+	return healthy, msg
+}
 
-	return errs
+func (r *instanceReconciler) waitForFirstConsumer(ctx context.Context, pvc *unstructured.Unstructured) bool {
+	log := clog.FromContext(ctx).WithCaller()
+
+	scName, _, _ := unstructured.NestedString(pvc.Object, "spec", "storageClassName")
+	var sc storagev1.StorageClass
+	if scName != "" {
+		if err := r.Get(ctx, types.NamespacedName{Name: scName}, &sc); err != nil {
+			log.Debug().Info("failed to get storage class", "storageClass", scName, "error", err)
+			return false
+		}
+	} else {
+		var scList storagev1.StorageClassList
+		if err := r.List(ctx, &scList); err != nil {
+			log.Debug().Info("failed to list storage classes", "error", err)
+			return false
+		}
+		i := slices.IndexFunc(scList.Items, func(v storagev1.StorageClass) bool {
+			return v.Annotations["storageclass.kubernetes.io/is-default-class"] == "true"
+		})
+		if i < 0 {
+			return false
+		}
+		sc = scList.Items[i]
+	}
+	return sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer
+}
+
+// syncWave is a group of the objects applied together
+type syncWave struct {
+	wave    int
+	objects []unstructured.Unstructured
+}
+
+// groupBySyncWave groups the objects by the sync wave annotation in ascending order of the wave.
+// The order of the objects in the same wave is kept.
+func groupBySyncWave(objects []unstructured.Unstructured) ([]syncWave, error) {
+	waveMap := make(map[int][]unstructured.Unstructured)
+	for _, obj := range objects {
+		wave := 0
+		if v := kubeutil.GetAnnotation(&obj, cosmov1alpha1.ResourceAnnKeySyncWave); v != "" {
+			w, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sync wave: kind=%s name=%s: %w", obj.GetKind(), obj.GetName(), err)
+			}
+			wave = w
+		}
+		waveMap[wave] = append(waveMap[wave], obj)
+	}
+	waves := make([]syncWave, 0, len(waveMap))
+	for w, objs := range waveMap {
+		waves = append(waves, syncWave{wave: w, objects: objs})
+	}
+	sort.Slice(waves, func(i, j int) bool { return waves[i].wave < waves[j].wave })
+	return waves, nil
 }
 
-func (r *instanceReconciler) dryrunApply(ctx context.Context, obj *unstructured.Unstructured, fieldManager string) (patched *unstructured.Unstructured, err error) {
-	return kubeutil.Apply(ctx, r.Client, obj, fieldManager, true, true)
+func (r *instanceReconciler) dryrunApply(ctx context.Context, obj *unstructured.Unstructured, fieldManager string, force bool) (patched *unstructured.Unstructured, err error) {
+	return kubeutil.Apply(ctx, r.Client, obj, fieldManager, true, force)
 }
 
-func (r *instanceReconciler) apply(ctx context.Context, obj *unstructured.Unstructured, fieldManager string) (patched *unstructured.Unstructured, err error) {
-	return kubeutil.Apply(ctx, r.Client, obj, fieldManager, false, true)
+func (r *instanceReconciler) apply(ctx context.Context, obj *unstructured.Unstructured, fieldManager string, force bool) (patched *unstructured.Unstructured, err error) {
+	return kubeutil.Apply(ctx, r.Client, obj, fieldManager, false, force)
 }
 
 // unstToObjectRef generate ObjectRef by Unstructured object
@@ -235,6 +639,7 @@ func unstToObjectRef(obj *unstructured.Unstructured) cosmov1alpha1.ObjectRef {
 	ref.Namespace = obj.GetNamespace()
 	ref.UID = obj.GetUID()
 	ref.ResourceVersion = obj.GetResourceVersion()
+	ref.Generation = obj.GetGeneration()
 
 	create := obj.GetCreationTimestamp()
 	ref.CreationTimestamp = &create
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 	"github.com/cosmo-workspace/cosmo/pkg/instance"
@@ -403,3 +404,240 @@ func Test_unstToObjectRef(t *testing.T) {
 		})
 	}
 }
+
+func Test_groupBySyncWave(t *testing.T) {
+	obj := func(kind, name, wave string) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind(kind)
+		u.SetName(name)
+		if wave != "" {
+			u.SetAnnotations(map[string]string{cosmov1alpha1.ResourceAnnKeySyncWave: wave})
+		}
+		return u
+	}
+	names := func(waves []syncWave) map[int][]string {
+		m := make(map[int][]string)
+		for _, w := range waves {
+			for _, o := range w.objects {
+				m[w.wave] = append(m[w.wave], o.GetName())
+			}
+		}
+		return m
+	}
+
+	tests := []struct {
+		name      string
+		objects   []unstructured.Unstructured
+		wantWaves []int
+		wantNames map[int][]string
+		wantErr   bool
+	}{
+		{
+			name:      "✅ No annotation",
+			objects:   []unstructured.Unstructured{obj("Service", "svc", ""), obj("Deployment", "deploy", "")},
+			wantWaves: []int{0},
+			wantNames: map[int][]string{0: {"svc", "deploy"}},
+		},
+		{
+			name: "✅ Ordered by wave",
+			objects: []unstructured.Unstructured{
+				obj("Deployment", "deploy", "1"),
+				obj("Service", "svc", ""),
+				obj("PersistentVolumeClaim", "pvc", "-1"),
+				obj("ConfigMap", "cm", "-1"),
+			},
+			wantWaves: []int{-1, 0, 1},
+			wantNames: map[int][]string{-1: {"pvc", "cm"}, 0: {"svc"}, 1: {"deploy"}},
+		},
+		{
+			name:    "❌ Invalid wave",
+			objects: []unstructured.Unstructured{obj("Deployment", "deploy", "first")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := groupBySyncWave(tt.objects)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("groupBySyncWave() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			gotWaves := make([]int, len(got))
+			for i, w := range got {
+				gotWaves[i] = w.wave
+			}
+			if !reflect.DeepEqual(gotWaves, tt.wantWaves) {
+				t.Errorf("groupBySyncWave() waves = %v, want %v", gotWaves, tt.wantWaves)
+			}
+			if !reflect.DeepEqual(names(got), tt.wantNames) {
+				t.Errorf("groupBySyncWave() objects = %v, want %v", names(got), tt.wantNames)
+			}
+		})
+	}
+}
+
+func Test_instanceReconciler_syncOptions(t *testing.T) {
+	syncedInstance := func(lastFullSync *metav1.Time) *cosmov1alpha1.Instance {
+		return &cosmov1alpha1.Instance{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Status: cosmov1alpha1.InstanceStatus{
+				TemplateResourceVersion: "100",
+				LastFullSyncTime:        lastFullSync,
+				Conditions:              []metav1.Condition{{Type: "Synced", Status: metav1.ConditionTrue, ObservedGeneration: 2, Reason: "Synced"}},
+			},
+		}
+	}
+	recent := metav1.NewTime(time.Now().Add(-time.Minute))
+	old := metav1.NewTime(time.Now().Add(-time.Hour))
+	almostDue := metav1.NewTime(time.Now().Add(-10*time.Minute + fullResyncTolerance/2))
+
+	tests := []struct {
+		name              string
+		interval          time.Duration
+		before            *cosmov1alpha1.Instance
+		tmplRV            string
+		wantSkipUnchanged bool
+	}{
+		{
+			name:              "✅ Skip unchanged after recent full sync",
+			interval:          10 * time.Minute,
+			before:            syncedInstance(&recent),
+			tmplRV:            "100",
+			wantSkipUnchanged: true,
+		},
+		{
+			name:              "❌ Full sync interval passed",
+			interval:          10 * time.Minute,
+			before:            syncedInstance(&old),
+			tmplRV:            "100",
+			wantSkipUnchanged: false,
+		},
+		{
+			name:              "❌ Full sync interval passes within tolerance",
+			interval:          10 * time.Minute,
+			before:            syncedInstance(&almostDue),
+			tmplRV:            "100",
+			wantSkipUnchanged: false,
+		},
+		{
+			name:              "❌ Never fully synced",
+			interval:          10 * time.Minute,
+			before:            syncedInstance(nil),
+			tmplRV:            "100",
+			wantSkipUnchanged: false,
+		},
+		{
+			name:              "❌ Template changed",
+			interval:          10 * time.Minute,
+			before:            syncedInstance(&recent),
+			tmplRV:            "101",
+			wantSkipUnchanged: false,
+		},
+		{
+			name:              "❌ Disabled",
+			interval:          0,
+			before:            syncedInstance(&recent),
+			tmplRV:            "100",
+			wantSkipUnchanged: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &instanceReconciler{FullResyncInterval: tt.interval}
+			got := r.syncOptions(tt.before, tt.tmplRV, nil, nil)
+			if got.skipUnchanged != tt.wantSkipUnchanged {
+				t.Errorf("syncOptions() skipUnchanged = %v, want %v", got.skipUnchanged, tt.wantSkipUnchanged)
+			}
+		})
+	}
+}
+
+func Test_instanceReconciler_requeueAfter(t *testing.T) {
+	interval := 10 * time.Minute
+	r := &instanceReconciler{FullResyncInterval: interval}
+	inst := func(lastFullSync time.Time) *cosmov1alpha1.Instance {
+		return &cosmov1alpha1.Instance{Status: cosmov1alpha1.InstanceStatus{LastFullSyncTime: ptr.To(metav1.NewTime(lastFullSync))}}
+	}
+
+	// requeued at the next full sync, not the interval after the last reconcile
+	if got := r.requeueAfter(inst(time.Now().Add(-4 * time.Minute))); got > 6*time.Minute || got < 5*time.Minute {
+		t.Errorf("requeueAfter() = %v, want about 6m", got)
+	}
+	if got := r.requeueAfter(inst(time.Now().Add(-time.Hour))); got != interval {
+		t.Errorf("requeueAfter() = %v, want %v", got, interval)
+	}
+	if got := r.requeueAfter(&cosmov1alpha1.Instance{}); got != interval {
+		t.Errorf("requeueAfter() = %v, want %v", got, interval)
+	}
+}
+
+func Test_metadataApplied(t *testing.T) {
+	obj := func(labels, annotations map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetLabels(labels)
+		u.SetAnnotations(annotations)
+		return u
+	}
+	built := obj(map[string]string{"a": "1"}, map[string]string{"b": "2"})
+
+	tests := []struct {
+		name string
+		live *unstructured.Unstructured
+		want bool
+	}{
+		{
+			name: "✅ Same metadata",
+			live: obj(map[string]string{"a": "1"}, map[string]string{"b": "2"}),
+			want: true,
+		},
+		{
+			name: "✅ Metadata added by others",
+			live: obj(map[string]string{"a": "1", "x": "y"}, map[string]string{"b": "2", "x": "y"}),
+			want: true,
+		},
+		{
+			name: "❌ Label removed",
+			live: obj(nil, map[string]string{"b": "2"}),
+			want: false,
+		},
+		{
+			name: "❌ Annotation changed",
+			live: obj(map[string]string{"a": "1"}, map[string]string{"b": "3"}),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := metadataApplied(built, tt.live); got != tt.want {
+				t.Errorf("metadataApplied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_builtHash(t *testing.T) {
+	obj := func(replicas int64) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("apps/v1")
+		u.SetKind("Deployment")
+		u.SetName("ws1-workspace")
+		u.SetLabels(map[string]string{"a": "1", "b": "2", "c": "3"})
+		_ = unstructured.SetNestedField(u.Object, replicas, "spec", "replicas")
+		return u
+	}
+	h1, err := builtHash(obj(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h2, _ := builtHash(obj(1))
+	h3, _ := builtHash(obj(0))
+	if h1 != h2 {
+		t.Errorf("builtHash() is not stable: %s != %s", h1, h2)
+	}
+	if h1 == h3 {
+		t.Errorf("builtHash() is not changed by the object: %s", h1)
+	}
+}
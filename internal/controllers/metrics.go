@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	driftReasonModified = "modified"
+	driftReasonDeleted  = "deleted"
+)
+
+// driftCorrections counts the child resources of instances synced back to the desired state
+var driftCorrections = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cosmo_instance_drift_corrections_total",
+		Help: "Number of the child resources of instances corrected from drift",
+	},
+	[]string{"kind", "reason"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(driftCorrections)
+}
@@ -36,13 +36,15 @@ const (
 )
 
 const (
-	instController        string = "cosmo-instance-controller"
-	clusterInstController string = "cosmo-cluster-instance-controller"
-	tmplController        string = "cosmo-template-controller"
-	clusterTmplController string = "cosmo-cluster-template-controller"
-	userController        string = "cosmo-user-controller"
-	wsController          string = "cosmo-workspace-controller"
-	wsStatController      string = "cosmo-workspace-status-controller"
+	instController          string = "cosmo-instance-controller"
+	clusterInstController   string = "cosmo-cluster-instance-controller"
+	tmplController          string = "cosmo-template-controller"
+	clusterTmplController   string = "cosmo-cluster-template-controller"
+	userController          string = "cosmo-user-controller"
+	wsController            string = "cosmo-workspace-controller"
+	wsStatController        string = "cosmo-workspace-status-controller"
+	wsAutoSuspendController string = "cosmo-workspace-autosuspend-controller"
+	wsScheduleController    string = "cosmo-workspace-schedule-controller"
 )
 
 // These tests use Ginkgo (BDD-style Go testing framework). Refer to
@@ -131,7 +133,12 @@ var _ = BeforeSuite(func() {
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor(wsController),
 
-		TraefikIngressRouteCfg: &workspace.TraefikIngressRouteConfig{
+		Routing: &workspace.TraefikIngressRouteConfig{
+			RoutingConfig: workspace.RoutingConfig{
+				URLProtocol: "https",
+				HostBase:    "{{NETRULE}}-{{WORKSPACE}}-{{USER}}",
+				Domain:      "domain",
+			},
 			Entrypoints: []string{"web", "websecure"},
 			TLS:         nil,
 			AuthenMiddleware: traefikv1.MiddlewareRef{
@@ -141,10 +148,7 @@ var _ = BeforeSuite(func() {
 			UserNameHeaderMiddleware: traefikv1.MiddlewareRef{
 				Name: "userNameHeader",
 			},
-			HostBase: "{{NETRULE}}-{{WORKSPACE}}-{{USER}}",
-			Domain:   "domain",
 		},
-		URLBaseProtocol: "https",
 	}).SetupWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -155,6 +159,20 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&WorkspaceAutoSuspendReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor(wsAutoSuspendController),
+	}).SetupWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&WorkspaceScheduleReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor(wsScheduleController),
+	}).SetupWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = (&UserReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
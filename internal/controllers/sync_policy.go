@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/instance"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+)
+
+// mergeSyncPolicy merges the sync policies of the template and the instance.
+// IgnoreDifferences are concatenated and force apply is disabled if any of them disables it.
+func mergeSyncPolicy(policies ...*cosmov1alpha1.SyncPolicy) cosmov1alpha1.SyncPolicy {
+	merged := cosmov1alpha1.SyncPolicy{}
+	for _, p := range policies {
+		if p == nil {
+			continue
+		}
+		merged.IgnoreDifferences = append(merged.IgnoreDifferences, p.IgnoreDifferences...)
+		merged.DisableForceApply = merged.DisableForceApply || p.DisableForceApply
+	}
+	return merged
+}
+
+// ignoredFields returns the field paths of the object to ignore by the sync policy
+func ignoredFields(policy cosmov1alpha1.SyncPolicy, instanceName string, obj *unstructured.Unstructured) ([]kubeutil.FieldPath, error) {
+	paths := make([]kubeutil.FieldPath, 0)
+	for _, d := range policy.IgnoreDifferences {
+		if !isIgnoreTarget(d, instanceName, obj) {
+			continue
+		}
+		for _, ptr := range d.JSONPointers {
+			p, err := kubeutil.ParseJSONPointer(ptr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ignoreDifferences for kind=%s: %w", d.Kind, err)
+			}
+			paths = append(paths, p)
+		}
+		for _, expr := range d.JSONPaths {
+			p, err := kubeutil.ParseJSONPath(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ignoreDifferences for kind=%s: %w", d.Kind, err)
+			}
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+func isIgnoreTarget(d cosmov1alpha1.IgnoreDifference, instanceName string, obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	if d.Group != gvk.Group || d.Kind != gvk.Kind {
+		return false
+	}
+	return d.Name == "" || instance.EqualInstanceResourceName(instanceName, d.Name, obj.GetName())
+}
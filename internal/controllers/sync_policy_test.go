@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func Test_mergeSyncPolicy(t *testing.T) {
+	tmplPolicy := &cosmov1alpha1.SyncPolicy{
+		IgnoreDifferences: []cosmov1alpha1.IgnoreDifference{{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}}},
+	}
+	instPolicy := &cosmov1alpha1.SyncPolicy{
+		IgnoreDifferences: []cosmov1alpha1.IgnoreDifference{{Kind: "Service", JSONPaths: []string{".spec.ports"}}},
+		DisableForceApply: true,
+	}
+	tests := []struct {
+		name     string
+		policies []*cosmov1alpha1.SyncPolicy
+		want     cosmov1alpha1.SyncPolicy
+	}{
+		{
+			name:     "✅ No policy",
+			policies: []*cosmov1alpha1.SyncPolicy{nil, nil},
+			want:     cosmov1alpha1.SyncPolicy{},
+		},
+		{
+			name:     "✅ Template only",
+			policies: []*cosmov1alpha1.SyncPolicy{tmplPolicy, nil},
+			want:     *tmplPolicy,
+		},
+		{
+			name:     "✅ Merged",
+			policies: []*cosmov1alpha1.SyncPolicy{tmplPolicy, instPolicy},
+			want: cosmov1alpha1.SyncPolicy{
+				IgnoreDifferences: []cosmov1alpha1.IgnoreDifference{
+					{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}},
+					{Kind: "Service", JSONPaths: []string{".spec.ports"}},
+				},
+				DisableForceApply: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeSyncPolicy(tt.policies...)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mergeSyncPolicy() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ignoredFields(t *testing.T) {
+	deploy := &unstructured.Unstructured{}
+	deploy.SetAPIVersion("apps/v1")
+	deploy.SetKind("Deployment")
+	deploy.SetName("ws1-workspace")
+
+	tests := []struct {
+		name      string
+		diff      cosmov1alpha1.IgnoreDifference
+		wantLen   int
+		wantError bool
+	}{
+		{
+			name:    "✅ All resources of the kind",
+			diff:    cosmov1alpha1.IgnoreDifference{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}, JSONPaths: []string{".spec.template.metadata.annotations"}},
+			wantLen: 2,
+		},
+		{
+			name:    "✅ Name without instance prefix",
+			diff:    cosmov1alpha1.IgnoreDifference{Group: "apps", Kind: "Deployment", Name: "workspace", JSONPointers: []string{"/spec/replicas"}},
+			wantLen: 1,
+		},
+		{
+			name:    "✅ Name with instance prefix",
+			diff:    cosmov1alpha1.IgnoreDifference{Group: "apps", Kind: "Deployment", Name: "ws1-workspace", JSONPointers: []string{"/spec/replicas"}},
+			wantLen: 1,
+		},
+		{
+			name:    "✅ Other name",
+			diff:    cosmov1alpha1.IgnoreDifference{Group: "apps", Kind: "Deployment", Name: "other", JSONPointers: []string{"/spec/replicas"}},
+			wantLen: 0,
+		},
+		{
+			name:    "✅ Other group",
+			diff:    cosmov1alpha1.IgnoreDifference{Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}},
+			wantLen: 0,
+		},
+		{
+			name:      "❌ Invalid pointer",
+			diff:      cosmov1alpha1.IgnoreDifference{Group: "apps", Kind: "Deployment", JSONPointers: []string{"spec/replicas"}},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := cosmov1alpha1.SyncPolicy{IgnoreDifferences: []cosmov1alpha1.IgnoreDifference{tt.diff}}
+			got, err := ignoredFields(policy, "ws1", deploy)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("ignoredFields() error = %v, wantError %v", err, tt.wantError)
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("ignoredFields() len = %d, want %d", len(got), tt.wantLen)
+			}
+		})
+	}
+}
@@ -10,12 +10,15 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
 	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+	"github.com/cosmo-workspace/cosmo/pkg/template"
 )
 
 // TemplateReconciler reconciles a Template object
@@ -55,6 +58,13 @@ func (r *TemplateReconciler) reconcile(ctx context.Context, tmpl *cosmov1alpha1.
 		return fmt.Errorf("failed to list instances for template %s: %w", tmpl.Name, err)
 	}
 
+	if template.IsPinRevision(tmpl) {
+		if err := r.reconcileRevisions(ctx, tmpl, insts.Items); err != nil {
+			r.Recorder.Eventf(tmpl, corev1.EventTypeWarning, "RevisionFailed", "Failed to sync revisions: %v", err)
+			return fmt.Errorf("failed to sync revisions for template %s: %w", tmpl.Name, err)
+		}
+	}
+
 	if errs := notifyUpdateToInstances(ctx, r.Client, r.Recorder, tmpl, insts.InstanceObjects()); len(errs) > 0 {
 		for _, e := range errs {
 			log.Error(e, "failed to notify the update of template")
@@ -69,11 +79,63 @@ func (r *TemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&cosmov1alpha1.Template{}).
 		WithEventFilter(predicate.Funcs{
-			CreateFunc: func(ce event.CreateEvent) bool { return false },
+			// record the first revision on creation
+			CreateFunc: func(ce event.CreateEvent) bool {
+				tmpl, ok := ce.Object.(*cosmov1alpha1.Template)
+				return ok && template.IsPinRevision(tmpl)
+			},
 		}).
 		Complete(r)
 }
 
+// reconcileRevisions creates a new TemplateRevision if the Template is changed from the latest revision,
+// and deletes the old revisions over the history limit which are not used by any Instance.
+func (r *TemplateReconciler) reconcileRevisions(ctx context.Context, tmpl *cosmov1alpha1.Template, insts []cosmov1alpha1.Instance) error {
+	log := clog.FromContext(ctx)
+
+	revs, err := template.ListTemplateRevisions(ctx, r.Client, tmpl.Name)
+	if err != nil {
+		return fmt.Errorf("failed to list revisions: %w", err)
+	}
+
+	var latest int64
+	if len(revs) > 0 {
+		latest = revs[len(revs)-1].Spec.Revision
+	}
+	if len(revs) == 0 || kubeutil.GetAnnotation(&revs[len(revs)-1], cosmov1alpha1.TemplateRevisionAnnKeyHash) != template.RevisionHash(tmpl) {
+		rev := template.NewTemplateRevision(tmpl, latest+1)
+		if err := controllerutil.SetControllerReference(tmpl, rev, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		if err := r.Create(ctx, rev); err != nil {
+			return fmt.Errorf("failed to create revision: %w", err)
+		}
+		log.Info("template revision created", "revision", rev.Name)
+		r.Recorder.Eventf(tmpl, corev1.EventTypeNormal, "RevisionCreated", "Revision %s is created", rev.Name)
+		revs = append(revs, *rev)
+	}
+
+	inUse := make(map[string]bool)
+	for _, inst := range insts {
+		if inst.Spec.Template.Name == tmpl.Name && inst.Spec.Template.Revision != "" {
+			inUse[inst.Spec.Template.Revision] = true
+		}
+	}
+
+	over := len(revs) - template.RevisionHistoryLimit(tmpl)
+	for i := 0; i < len(revs)-1 && over > 0; i++ {
+		if inUse[revs[i].Name] {
+			continue
+		}
+		if err := r.Delete(ctx, &revs[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete old revision %s: %w", revs[i].Name, err)
+		}
+		log.Info("old template revision deleted", "revision", revs[i].Name)
+		over--
+	}
+	return nil
+}
+
 func notifyUpdateToInstances(ctx context.Context, c client.Client, rec record.EventRecorder, tmpl cosmov1alpha1.TemplateObject, insts []cosmov1alpha1.InstanceObject) []error {
 	log := clog.FromContext(ctx)
 	errs := make([]error, 0)
@@ -81,6 +143,10 @@ func notifyUpdateToInstances(ctx context.Context, c client.Client, rec record.Ev
 		if tmpl.GetName() != inst.GetSpec().Template.Name {
 			continue
 		}
+		// instances pinned to a revision are not affected by the update of the template
+		if inst.GetSpec().Template.Revision != "" {
+			continue
+		}
 
 		before := inst.DeepCopyObject()
 		inst.GetStatus().TemplateResourceVersion = tmpl.GetResourceVersion()
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+)
+
+const (
+	// workspaceTemplateIndexKey is the field index of Workspace by the template name
+	workspaceTemplateIndexKey = "spec.template.name"
+	// userAddonTemplateIndexKey is the field index of User by the addon template kind and name
+	userAddonTemplateIndexKey = "spec.addons.template"
+)
+
+const (
+	// dependentsBatchSize is the number of the dependents enqueued at once when a template is changed
+	dependentsBatchSize = 20
+	// dependentsBatchInterval is the interval to enqueue the next batch of the dependents
+	dependentsBatchInterval = time.Second
+)
+
+// templateChangedPredicate filters the updates of templates not affecting the dependents such as status
+var templateChangedPredicate = predicate.Or(
+	predicate.GenerationChangedPredicate{},
+	predicate.AnnotationChangedPredicate{},
+	predicate.LabelChangedPredicate{},
+)
+
+func indexWorkspaceByTemplate(obj client.Object) []string {
+	ws, ok := obj.(*cosmov1alpha1.Workspace)
+	if !ok || ws.Spec.Template.Name == "" {
+		return nil
+	}
+	return []string{ws.Spec.Template.Name}
+}
+
+func indexUserByAddonTemplate(obj client.Object) []string {
+	user, ok := obj.(*cosmov1alpha1.User)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(user.Spec.Addons))
+	for _, addon := range user.Spec.Addons {
+		if addon.Template.Name == "" {
+			continue
+		}
+		keys = append(keys, addonTemplateIndexValue(addon.Template.ClusterScoped, addon.Template.Name))
+	}
+	return keys
+}
+
+// addonTemplateIndexValue returns the index value of the addon template, which is "Template/NAME" or "ClusterTemplate/NAME"
+func addonTemplateIndexValue(clusterScoped bool, name string) string {
+	if clusterScoped {
+		return "ClusterTemplate/" + name
+	}
+	return "Template/" + name
+}
+
+// findWorkspacesByTemplate returns the requests for the workspaces using the template
+func (r *WorkspaceReconciler) findWorkspacesByTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := clog.FromContext(ctx).WithName("findWorkspacesByTemplate")
+
+	var wss cosmov1alpha1.WorkspaceList
+	if err := r.List(ctx, &wss, client.MatchingFields{workspaceTemplateIndexKey: obj.GetName()}); err != nil {
+		log.Error(err, "failed to list workspaces", "template", obj.GetName())
+		return nil
+	}
+	reqs := make([]reconcile.Request, 0, len(wss.Items))
+	for _, ws := range wss.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: ws.Name, Namespace: ws.Namespace}})
+	}
+	return reqs
+}
+
+// findUsersByAddonTemplate returns the requests for the users using the template or cluster template as an addon
+func (r *UserReconciler) findUsersByAddonTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := clog.FromContext(ctx).WithName("findUsersByAddonTemplate")
+
+	_, clusterScoped := obj.(*cosmov1alpha1.ClusterTemplate)
+	var users cosmov1alpha1.UserList
+	if err := r.List(ctx, &users, client.MatchingFields{userAddonTemplateIndexKey: addonTemplateIndexValue(clusterScoped, obj.GetName())}); err != nil {
+		log.Error(err, "failed to list users", "template", obj.GetName())
+		return nil
+	}
+	reqs := make([]reconcile.Request, 0, len(users.Items))
+	for _, user := range users.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: user.Name}})
+	}
+	return reqs
+}
+
+// enqueueDependents returns the event handler to enqueue the objects depending on the changed template.
+// The requests are enqueued in batches over time not to burst the reconciliations
+// when the template used by many objects is changed.
+func enqueueDependents(mapFn handler.MapFunc) handler.EventHandler {
+	enqueue := func(ctx context.Context, obj client.Object, q workqueue.RateLimitingInterface) {
+		for i, req := range mapFn(ctx, obj) {
+			q.AddAfter(req, time.Duration(i/dependentsBatchSize)*dependentsBatchInterval)
+		}
+	}
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
+			enqueue(ctx, e.Object, q)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			enqueue(ctx, e.ObjectNew, q)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			enqueue(ctx, e.Object, q)
+		},
+	}
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func Test_indexUserByAddonTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  client.Object
+		want []string
+	}{
+		{
+			name: "✅ Template and ClusterTemplate",
+			obj: &cosmov1alpha1.User{
+				Spec: cosmov1alpha1.UserSpec{
+					Addons: []cosmov1alpha1.UserAddon{
+						{Template: cosmov1alpha1.UserAddonTemplateRef{Name: "addon1"}},
+						{Template: cosmov1alpha1.UserAddonTemplateRef{Name: "addon2", ClusterScoped: true}},
+					},
+				},
+			},
+			want: []string{"Template/addon1", "ClusterTemplate/addon2"},
+		},
+		{
+			name: "✅ No addons",
+			obj:  &cosmov1alpha1.User{},
+			want: []string{},
+		},
+		{
+			name: "❌ Not a User",
+			obj:  &cosmov1alpha1.Workspace{},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := indexUserByAddonTemplate(tt.obj)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("indexUserByAddonTemplate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_indexWorkspaceByTemplate(t *testing.T) {
+	ws := &cosmov1alpha1.Workspace{Spec: cosmov1alpha1.WorkspaceSpec{Template: cosmov1alpha1.TemplateRef{Name: "code-server"}}}
+	if diff := cmp.Diff([]string{"code-server"}, indexWorkspaceByTemplate(ws)); diff != "" {
+		t.Errorf("indexWorkspaceByTemplate() mismatch (-want +got):\n%s", diff)
+	}
+	if got := indexWorkspaceByTemplate(&cosmov1alpha1.Workspace{}); got != nil {
+		t.Errorf("indexWorkspaceByTemplate() = %v, want nil", got)
+	}
+}
+
+func Test_enqueueDependents(t *testing.T) {
+	total := dependentsBatchSize*2 + 1
+	mapFn := func(ctx context.Context, obj client.Object) []reconcile.Request {
+		reqs := make([]reconcile.Request, total)
+		for i := range reqs {
+			reqs[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("ws%d", i), Namespace: "cosmo-user-tom"}}
+		}
+		return reqs
+	}
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	tmpl := &cosmov1alpha1.Template{ObjectMeta: metav1.ObjectMeta{Name: "code-server"}}
+	enqueueDependents(mapFn).Update(context.TODO(), event.UpdateEvent{ObjectOld: tmpl, ObjectNew: tmpl}, q)
+
+	// only the first batch is enqueued immediately
+	if q.Len() != dependentsBatchSize {
+		t.Errorf("queue length = %d, want %d", q.Len(), dependentsBatchSize)
+	}
+}
@@ -5,23 +5,30 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 	"github.com/cosmo-workspace/cosmo/pkg/auth/password"
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
 	"github.com/cosmo-workspace/cosmo/pkg/instance"
 	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
+	"github.com/cosmo-workspace/cosmo/pkg/quota"
 	"github.com/cosmo-workspace/cosmo/pkg/useraddon"
 )
 
@@ -44,6 +51,7 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	log = log.WithValues("UID", user.UID)
 	ctx = clog.IntoContext(ctx, log)
 	currentUser := user.DeepCopy()
+	user.Status.ObservedGeneration = user.Generation
 
 	// reconcile namespace
 	ns := corev1.Namespace{}
@@ -57,7 +65,8 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 			return ctrl.Result{Requeue: true}, nil
 		}
 		kosmo.UserEventf(r.Recorder, &user, corev1.EventTypeWarning, "SyncFailed", "Failed to sync namespace %s: %v", ns.Name, err)
-		return ctrl.Result{}, fmt.Errorf("failed to sync namespace: %w", err)
+		setNotReadyCondition(&user.Status.Conditions, user.Generation, cosmov1alpha1.ConditionTypeSynced, cosmov1alpha1.ConditionReasonSyncFailed, err.Error())
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, currentUser, &user, fmt.Errorf("failed to sync namespace: %w", err))
 	}
 	if op != controllerutil.OperationResultNone {
 		log.Info("namespace synced", "namespace", ns.Name)
@@ -81,6 +90,16 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		CreationTimestamp: &ns.CreationTimestamp,
 	}
 
+	// reconcile resource quota
+	if err := r.reconcileQuota(ctx, &user); err != nil {
+		if apierrs.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		kosmo.UserEventf(r.Recorder, &user, corev1.EventTypeWarning, "QuotaSyncFailed", "Failed to sync quota: %v", err)
+		setNotReadyCondition(&user.Status.Conditions, user.Generation, cosmov1alpha1.ConditionTypeSynced, cosmov1alpha1.ConditionReasonSyncFailed, err.Error())
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, currentUser, &user, fmt.Errorf("failed to sync quota: %w", err))
+	}
+
 	if user.Spec.AuthType == cosmov1alpha1.UserAuthTypePasswordSecert {
 		// generate default password if password secret is not found
 		if _, err := password.GetDefaultPassword(ctx, r.Client, user.Name); err != nil && apierrs.IsNotFound(err) {
@@ -90,15 +109,19 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 				}
 				kosmo.UserEventf(r.Recorder, &user, corev1.EventTypeWarning, "PasswordInitFailed", "Failed to reset password: %v", err)
 				log.Error(err, "failed to reset password")
-				return ctrl.Result{}, err
+				setNotReadyCondition(&user.Status.Conditions, user.Generation, cosmov1alpha1.ConditionTypeSynced, cosmov1alpha1.ConditionReasonSyncFailed, err.Error())
+				return ctrl.Result{}, updateStatusOnError(ctx, r.Client, currentUser, &user, err)
 			}
 			log.Info("password secret initialized")
 			kosmo.UserEventf(r.Recorder, &user, corev1.EventTypeNormal, "PasswordInitialized", "Successfully reset password secret")
 		}
 	}
 
+	setCondition(&user.Status.Conditions, user.Generation, cosmov1alpha1.ConditionTypeSynced, metav1.ConditionTrue, cosmov1alpha1.ConditionReasonSynced, "")
+
 	// reconcile user addon
 	addonErrs := make([]error, 0)
+	notReadyAddons := make([]string, 0)
 
 	lastAddons := make([]cosmov1alpha1.ObjectRef, len(user.Status.Addons))
 	copy(lastAddons, user.Status.Addons)
@@ -140,6 +163,10 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		} else {
 			log.Debug().Info("the result of update addon instance operation is None", "addon", addon)
 		}
+		if instReady := meta.FindStatusCondition(inst.GetStatus().Conditions, cosmov1alpha1.ConditionTypeReady); instReady == nil ||
+			instReady.Status != metav1.ConditionTrue || instReady.ObservedGeneration != inst.GetGeneration() {
+			notReadyAddons = append(notReadyAddons, addon.Template.Name)
+		}
 
 		ct := inst.GetCreationTimestamp()
 		gvk, err := apiutil.GVKForObject(inst, r.Scheme)
@@ -168,6 +195,7 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		user.Status.Phase = "AddonFailed"
 		err = addonErrs[0]
 	}
+	setUserReadyCondition(&user, addonErrs, notReadyAddons)
 
 	log.Debug().Info("checking shared workspace garbage collection", "sharedWorkspaces", user.Status.SharedWorkspaces)
 	shouldRemoveSharedRef := make([]cosmov1alpha1.ObjectRef, 0)
@@ -221,13 +249,130 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	return ctrl.Result{}, err
 }
 
+// setUserReadyCondition sets AddonsReady and Ready conditions after the namespace is synced
+func setUserReadyCondition(user *cosmov1alpha1.User, addonErrs []error, notReadyAddons []string) {
+	switch {
+	case len(addonErrs) > 0:
+		setNotReadyCondition(&user.Status.Conditions, user.Generation, cosmov1alpha1.ConditionTypeAddonsReady, cosmov1alpha1.ConditionReasonAddonsFailed, addonErrs[0].Error())
+		return
+	case len(notReadyAddons) > 0:
+		setNotReadyCondition(&user.Status.Conditions, user.Generation, cosmov1alpha1.ConditionTypeAddonsReady, cosmov1alpha1.ConditionReasonProgressing, fmt.Sprintf("addons are not ready: %s", strings.Join(notReadyAddons, ",")))
+		return
+	}
+	setCondition(&user.Status.Conditions, user.Generation, cosmov1alpha1.ConditionTypeAddonsReady, metav1.ConditionTrue, cosmov1alpha1.ConditionReasonReady, "")
+
+	if user.Status.Phase != corev1.NamespaceActive {
+		setCondition(&user.Status.Conditions, user.Generation, cosmov1alpha1.ConditionTypeReady, metav1.ConditionFalse, cosmov1alpha1.ConditionReasonNotReady, fmt.Sprintf("namespace is %s", user.Status.Phase))
+		return
+	}
+	setCondition(&user.Status.Conditions, user.Generation, cosmov1alpha1.ConditionTypeReady, metav1.ConditionTrue, cosmov1alpha1.ConditionReasonReady, "")
+}
+
 func (r *UserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &cosmov1alpha1.User{}, userAddonTemplateIndexKey, indexUserByAddonTemplate); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&cosmov1alpha1.User{}).
 		Owns(&corev1.Namespace{}).
+		Owns(&corev1.ResourceQuota{}).
+		Owns(&corev1.LimitRange{}).
+		Owns(&cosmov1alpha1.Instance{}).
+		Owns(&cosmov1alpha1.ClusterInstance{}).
+		Watches(&cosmov1alpha1.QuotaProfile{}, handler.EnqueueRequestsFromMapFunc(r.findAllUsers)).
+		Watches(&cosmov1alpha1.Template{}, enqueueDependents(r.findUsersByAddonTemplate), builder.WithPredicates(templateChangedPredicate)).
+		Watches(&cosmov1alpha1.ClusterTemplate{}, enqueueDependents(r.findUsersByAddonTemplate), builder.WithPredicates(templateChangedPredicate)).
 		Complete(r)
 }
 
+// findAllUsers returns requests for all users as a QuotaProfile change can affect any user
+func (r *UserReconciler) findAllUsers(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := clog.FromContext(ctx).WithName("findAllUsers")
+
+	var users cosmov1alpha1.UserList
+	if err := r.List(ctx, &users); err != nil {
+		log.Error(err, "failed to list users")
+		return nil
+	}
+	reqs := make([]reconcile.Request, 0, len(users.Items))
+	for _, u := range users.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: u.Name}})
+	}
+	return reqs
+}
+
+// reconcileQuota applies the ResourceQuota and the LimitRange of the matched QuotaProfile into the user namespace.
+// They are deleted if no profile matches or the profile does not have them.
+func (r *UserReconciler) reconcileQuota(ctx context.Context, user *cosmov1alpha1.User) error {
+	log := clog.FromContext(ctx)
+
+	var profiles cosmov1alpha1.QuotaProfileList
+	if err := r.List(ctx, &profiles); err != nil {
+		return fmt.Errorf("failed to list quota profiles: %w", err)
+	}
+	profile := quota.MatchProfile(profiles.Items, *user)
+
+	rq := corev1.ResourceQuota{}
+	rq.SetName(cosmov1alpha1.UserResourceQuotaName)
+	rq.SetNamespace(cosmov1alpha1.UserNamespace(user.Name))
+
+	if profile != nil && profile.Spec.ResourceQuota != nil {
+		op, err := ctrl.CreateOrUpdate(ctx, r.Client, &rq, func() error {
+			return quota.PatchResourceQuotaAsDesired(&rq, *profile, *user, r.Scheme)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to sync resource quota: %w", err)
+		}
+		if op != controllerutil.OperationResultNone {
+			log.Info("resource quota synced", "profile", profile.Name)
+			kosmo.UserEventf(r.Recorder, user, corev1.EventTypeNormal, "QuotaSynced", "ResourceQuota %s is %s by QuotaProfile %s", rq.Name, op, profile.Name)
+		}
+	} else if err := r.deleteIfManaged(ctx, &rq); err != nil {
+		return fmt.Errorf("failed to delete resource quota: %w", err)
+	}
+
+	lr := corev1.LimitRange{}
+	lr.SetName(cosmov1alpha1.UserLimitRangeName)
+	lr.SetNamespace(cosmov1alpha1.UserNamespace(user.Name))
+
+	if profile != nil && profile.Spec.LimitRange != nil {
+		op, err := ctrl.CreateOrUpdate(ctx, r.Client, &lr, func() error {
+			return quota.PatchLimitRangeAsDesired(&lr, *profile, *user, r.Scheme)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to sync limit range: %w", err)
+		}
+		if op != controllerutil.OperationResultNone {
+			log.Info("limit range synced", "profile", profile.Name)
+			kosmo.UserEventf(r.Recorder, user, corev1.EventTypeNormal, "QuotaSynced", "LimitRange %s is %s by QuotaProfile %s", lr.Name, op, profile.Name)
+		}
+	} else if err := r.deleteIfManaged(ctx, &lr); err != nil {
+		return fmt.Errorf("failed to delete limit range: %w", err)
+	}
+
+	if profile != nil {
+		user.Status.QuotaProfile = profile.Name
+	} else {
+		user.Status.QuotaProfile = ""
+	}
+	return nil
+}
+
+// deleteIfManaged deletes the object if it exists and is managed by the controller
+func (r *UserReconciler) deleteIfManaged(ctx context.Context, obj client.Object) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if _, ok := obj.GetLabels()[cosmov1alpha1.LabelControllerManaged]; !ok {
+		return nil
+	}
+	if err := r.Delete(ctx, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	clog.FromContext(ctx).Info("deleted object as no quota profile is matched", "name", obj.GetName(), "namespace", obj.GetNamespace())
+	return nil
+}
+
 func (r *UserReconciler) patchNamespaceToUserDesired(ns *corev1.Namespace, user cosmov1alpha1.User) error {
 	label := ns.GetLabels()
 	if label == nil {
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+	"github.com/cosmo-workspace/cosmo/pkg/workspace"
+)
+
+// WorkspaceAutoSuspendReconciler suspends idle Workspaces
+type WorkspaceAutoSuspendReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+
+	// IdleTimeout is the default idle duration to suspend workspaces.
+	// It can be overridden by annotations on Template or Workspace. Zero disables auto-suspend.
+	IdleTimeout time.Duration
+}
+
+// +kubebuilder:rbac:groups=cosmo-workspace.github.io,resources=workspaces,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=cosmo-workspace.github.io,resources=templates,verbs=get;list;watch
+func (r *WorkspaceAutoSuspendReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := clog.FromContext(ctx).WithName("WorkspaceAutoSuspendReconciler").WithValues("req", req)
+
+	log.Debug().Info("start reconcile")
+
+	var ws cosmov1alpha1.Workspace
+	if err := r.Get(ctx, req.NamespacedName, &ws); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if ws.Spec.Replicas == nil || *ws.Spec.Replicas == 0 {
+		log.Debug().Info("workspace is not running")
+		return ctrl.Result{}, nil
+	}
+
+	var tmpl *cosmov1alpha1.Template
+	t := &cosmov1alpha1.Template{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ws.Spec.Template.Name}, t); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	} else {
+		tmpl = t
+	}
+
+	timeout, err := workspace.IdleTimeout(&ws, tmpl, r.IdleTimeout)
+	if err != nil {
+		kosmo.WorkspaceEventf(r.Recorder, &ws, corev1.EventTypeWarning, "AutoSuspendFailed", "%s", err)
+		return ctrl.Result{}, nil
+	}
+	if timeout <= 0 {
+		log.Debug().Info("auto-suspend is disabled")
+		return ctrl.Result{}, nil
+	}
+
+	idle := time.Since(workspace.LastActivity(&ws))
+	if idle < timeout {
+		log.Debug().Info("workspace is active", "idle", idle, "timeout", timeout)
+		return ctrl.Result{RequeueAfter: timeout - idle}, nil
+	}
+
+	ws.Spec.Replicas = ptr.To(int64(0))
+	kubeutil.SetAnnotation(&ws, cosmov1alpha1.WorkspaceAnnKeyLastStoppedAt, time.Now().Format(time.RFC3339))
+
+	if err := r.Update(ctx, &ws); err != nil {
+		return ctrl.Result{}, err
+	}
+	log.Info("workspace suspended", "idle", idle, "timeout", timeout)
+	kosmo.WorkspaceEventf(r.Recorder, &ws, corev1.EventTypeNormal, "Suspended", "Suspended after idle for %s", idle.Round(time.Second))
+
+	log.Debug().Info("finish reconcile")
+	return ctrl.Result{}, nil
+}
+
+func (r *WorkspaceAutoSuspendReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&cosmov1alpha1.Workspace{}).
+		Complete(r)
+}
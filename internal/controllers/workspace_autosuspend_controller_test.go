@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+)
+
+var _ = Describe("Workspace auto-suspend controller", func() {
+	const tmplName string = "autosuspend-test"
+	const userName string = "wsautosuspendtest"
+	var nsName string = cosmov1alpha1.UserNamespace(userName)
+
+	tmpl := cosmov1alpha1.Template{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: tmplName,
+			Labels: map[string]string{
+				cosmov1alpha1.TemplateLabelKeyType: cosmov1alpha1.TemplateLabelEnumTypeWorkspace,
+			},
+			Annotations: map[string]string{
+				cosmov1alpha1.WorkspaceAnnKeyIdleTimeout: "1s",
+			},
+		},
+		Spec: cosmov1alpha1.TemplateSpec{
+			RawYaml: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: '{{NAMESPACE}}'
+`,
+		},
+	}
+
+	Context("when creating Template and namespace", func() {
+		It("should be created", func() {
+			ctx := context.Background()
+
+			ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nsName}}
+			err := k8sClient.Create(ctx, &ns)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			err = k8sClient.Create(ctx, &tmpl)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("when running workspace is idle over the timeout in template", func() {
+		It("should suspend the workspace", func() {
+			ctx := context.Background()
+
+			ws := cosmov1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ws-idle",
+					Namespace: nsName,
+				},
+				Spec: cosmov1alpha1.WorkspaceSpec{
+					Template: cosmov1alpha1.TemplateRef{Name: tmplName},
+					Replicas: ptr.To(int64(1)),
+				},
+			}
+			err := k8sClient.Create(ctx, &ws)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Eventually(func() int64 {
+				var w cosmov1alpha1.Workspace
+				if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(&ws), &w); err != nil {
+					return -1
+				}
+				return *w.Spec.Replicas
+			}, time.Second*10).Should(BeEquivalentTo(0))
+
+			var suspended cosmov1alpha1.Workspace
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(&ws), &suspended)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(kubeutil.GetAnnotation(&suspended, cosmov1alpha1.WorkspaceAnnKeyLastStoppedAt)).ShouldNot(BeEmpty())
+		})
+	})
+
+	Context("when auto-suspend is disabled on workspace annotation", func() {
+		It("should not suspend the workspace", func() {
+			ctx := context.Background()
+
+			ws := cosmov1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ws-disabled",
+					Namespace: nsName,
+					Annotations: map[string]string{
+						cosmov1alpha1.WorkspaceAnnKeyIdleTimeout: "0",
+					},
+				},
+				Spec: cosmov1alpha1.WorkspaceSpec{
+					Template: cosmov1alpha1.TemplateRef{Name: tmplName},
+					Replicas: ptr.To(int64(1)),
+				},
+			}
+			err := k8sClient.Create(ctx, &ws)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Consistently(func() int64 {
+				var w cosmov1alpha1.Workspace
+				if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(&ws), &w); err != nil {
+					return -1
+				}
+				return *w.Spec.Replicas
+			}, time.Second*3).Should(BeEquivalentTo(1))
+		})
+	})
+})
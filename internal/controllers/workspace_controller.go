@@ -8,20 +8,21 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	traefikv1 "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
-
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
 	"github.com/cosmo-workspace/cosmo/pkg/instance"
 	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
+	"github.com/cosmo-workspace/cosmo/pkg/template"
 	"github.com/cosmo-workspace/cosmo/pkg/workspace"
 )
 
@@ -31,8 +32,7 @@ type WorkspaceReconciler struct {
 	Recorder record.EventRecorder
 	Scheme   *runtime.Scheme
 
-	TraefikIngressRouteCfg *workspace.TraefikIngressRouteConfig
-	URLBaseProtocol        string
+	Routing workspace.RoutingProvider
 }
 
 // +kubebuilder:rbac:groups=cosmo-workspace.github.io,resources=workspaces,verbs=get;list;watch;create;update;patch;delete
@@ -55,23 +55,27 @@ func (r *WorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	currentWs := ws.DeepCopy()
 
 	log.DumpObject(r.Scheme, currentWs, "request object")
+	ws.Status.ObservedGeneration = ws.Generation
+
+	tmpl, err := template.GetTemplate(ctx, r.Client, ws.Spec.Template)
+	if err != nil {
+		setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeTemplateResolved, metav1.ConditionFalse, cosmov1alpha1.ConditionReasonTemplateNotFound, err.Error())
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, currentWs, &ws, fmt.Errorf("failed to fetch template %s: %w", ws.Spec.Template.Name, err))
+	}
 
 	// sync workspace config with template
-	cfg, err := getWorkspaceConfig(ctx, r.Client, ws.Spec.Template.Name)
+	cfg, err := workspace.ConfigFromTemplateAnnotations(tmpl)
 	if err != nil {
-		return ctrl.Result{}, err
+		setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeTemplateResolved, metav1.ConditionFalse, cosmov1alpha1.ConditionReasonBuildFailed, err.Error())
+		return ctrl.Result{}, updateStatusOnError(ctx, r.Client, currentWs, &ws, err)
 	}
 	ws.Status.Config = cfg
+	setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeTemplateResolved, metav1.ConditionTrue, cosmov1alpha1.ConditionReasonTemplateFound, "")
 
 	inst := &cosmov1alpha1.Instance{}
 	inst.SetName(ws.Name)
 	inst.SetNamespace(ws.Namespace)
 
-	tmpl := &cosmov1alpha1.Template{}
-	if err := r.Get(ctx, types.NamespacedName{Name: ws.Spec.Template.Name}, tmpl); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to fetch template %s: %w", ws.Spec.Template.Name, err)
-	}
-
 	// sync
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, inst, func() error {
 		if err := workspace.PatchWorkspaceInstanceAsDesired(inst, &ws, r.Scheme); err != nil {
@@ -86,7 +90,8 @@ func (r *WorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			return ctrl.Result{Requeue: true}, nil
 		} else {
 			kosmo.WorkspaceEventf(r.Recorder, &ws, corev1.EventTypeWarning, "SyncFailed", "Failed to sync instance %s: %v", inst.Name, err)
-			return ctrl.Result{}, fmt.Errorf("failed to sync instance: %w", err)
+			setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeSynced, metav1.ConditionFalse, cosmov1alpha1.ConditionReasonSyncFailed, err.Error())
+			return ctrl.Result{}, updateStatusOnError(ctx, r.Client, currentWs, &ws, fmt.Errorf("failed to sync instance: %w", err))
 		}
 	}
 	if op != controllerutil.OperationResultNone {
@@ -107,27 +112,20 @@ func (r *WorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		},
 		CreationTimestamp: &inst.CreationTimestamp,
 	}
+	setWorkspaceSyncedCondition(&ws, inst)
 
-	// sync ingress route
-	ir := traefikv1.IngressRoute{}
-	ir.SetName(ws.Name)
-	ir.SetNamespace(ws.Namespace)
-	op, err = controllerutil.CreateOrUpdate(ctx, r.Client, &ir, func() error {
-		return r.TraefikIngressRouteCfg.PatchTraefikIngressRouteAsDesired(&ir, ws, r.Scheme)
-	})
-	if err != nil {
+	// sync routing resources
+	if err := r.syncRoutes(ctx, ws); err != nil {
 		if apierrs.IsConflict(err) {
 			// if conflict, retry
 			return ctrl.Result{Requeue: true}, nil
 		} else {
-			kosmo.WorkspaceEventf(r.Recorder, &ws, corev1.EventTypeWarning, "SyncFailed", "Failed to sync traefik ingress route %s: %v", ir.Name, err)
-			return ctrl.Result{}, fmt.Errorf("failed to sync traefik ingress route: %w", err)
+			kosmo.WorkspaceEventf(r.Recorder, &ws, corev1.EventTypeWarning, "SyncFailed", "Failed to sync routing: %v", err)
+			setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeRoutingReady, metav1.ConditionFalse, cosmov1alpha1.ConditionReasonSyncFailed, err.Error())
+			return ctrl.Result{}, updateStatusOnError(ctx, r.Client, currentWs, &ws, fmt.Errorf("failed to sync routing: %w", err))
 		}
 	}
-	if op != controllerutil.OperationResultNone {
-		log.Info("traefik ingress route synced", "ingressroute", ir.Name)
-		kosmo.WorkspaceEventf(r.Recorder, &ws, corev1.EventTypeNormal, "Synced", "Successfully reconciled. Traefik ingress route %s is %s", ir.Name, op)
-	}
+	setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeRoutingReady, metav1.ConditionTrue, cosmov1alpha1.ConditionReasonSynced, "")
 
 	// generate URL and set to status
 	urlMap := r.GenWorkspaceURLMap(ctx, ws)
@@ -182,26 +180,119 @@ func (r *WorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 }
 
 func (r *WorkspaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &cosmov1alpha1.Workspace{}, workspaceTemplateIndexKey, indexWorkspaceByTemplate); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&cosmov1alpha1.Workspace{}).
 		Owns(&cosmov1alpha1.Instance{}).
+		Watches(&cosmov1alpha1.Template{}, enqueueDependents(r.findWorkspacesByTemplate), builder.WithPredicates(templateChangedPredicate)).
 		Complete(r)
 }
 
-func getWorkspaceConfig(ctx context.Context, c client.Client, tmplName string) (cfg cosmov1alpha1.Config, err error) {
-	tmpl := &cosmov1alpha1.Template{}
-	if err := c.Get(ctx, types.NamespacedName{Name: tmplName}, tmpl); err != nil {
-		return cfg, err
+// setWorkspaceSyncedCondition sets Synced condition of the workspace by the Ready condition of the instance
+func setWorkspaceSyncedCondition(ws *cosmov1alpha1.Workspace, inst *cosmov1alpha1.Instance) {
+	instReady := meta.FindStatusCondition(inst.Status.Conditions, cosmov1alpha1.ConditionTypeReady)
+	switch {
+	case instReady == nil || instReady.ObservedGeneration != inst.Generation:
+		setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeSynced, metav1.ConditionFalse, cosmov1alpha1.ConditionReasonProgressing, fmt.Sprintf("instance %s is not reconciled yet", inst.Name))
+	case instReady.Status == metav1.ConditionTrue:
+		setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeSynced, metav1.ConditionTrue, cosmov1alpha1.ConditionReasonSynced, "")
+	default:
+		setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeSynced, metav1.ConditionFalse, instReady.Reason, instReady.Message)
 	}
-	return workspace.ConfigFromTemplateAnnotations(tmpl)
+}
+
+// syncRoutes creates or updates the routing resources of the workspace and deletes the unnecessary ones
+func (r *WorkspaceReconciler) syncRoutes(ctx context.Context, ws cosmov1alpha1.Workspace) error {
+	log := clog.FromContext(ctx).WithCaller()
+
+	routes := r.Routing.Routes(ws, r.Scheme)
+	desired := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		obj := route.Object
+		kind := kindOf(obj, r.Scheme)
+
+		if route.Patch == nil {
+			if err := r.deleteRoute(ctx, ws, obj); err != nil {
+				return fmt.Errorf("failed to delete %s %s: %w", kind, obj.GetName(), err)
+			}
+			continue
+		}
+		desired[kind+"/"+obj.GetName()] = true
+
+		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, route.Patch)
+		if err != nil {
+			return fmt.Errorf("failed to sync %s %s: %w", kind, obj.GetName(), err)
+		}
+		if op != controllerutil.OperationResultNone {
+			log.Info("routing synced", "kind", kind, "name", obj.GetName())
+			kosmo.WorkspaceEventf(r.Recorder, &ws, corev1.EventTypeNormal, "Synced", "Successfully reconciled. %s %s is %s", kind, obj.GetName(), op)
+		}
+	}
+
+	// delete the routing resources of the removed network rules
+	lister, ok := r.Routing.(workspace.RouteLister)
+	if !ok {
+		return nil
+	}
+	list := lister.NewRouteList()
+	if err := r.List(ctx, list, client.InNamespace(ws.Namespace), client.MatchingLabels{cosmov1alpha1.LabelKeyWorkspaceName: ws.Name, cosmov1alpha1.LabelControllerManaged: "1"}); err != nil {
+		return fmt.Errorf("failed to list routing resources: %w", err)
+	}
+	return meta.EachListItem(list, func(o runtime.Object) error {
+		obj, ok := o.(client.Object)
+		if !ok {
+			return nil
+		}
+		kind := kindOf(obj, r.Scheme)
+		if desired[kind+"/"+obj.GetName()] {
+			return nil
+		}
+		if err := r.deleteRoute(ctx, ws, obj); err != nil {
+			return fmt.Errorf("failed to delete %s %s: %w", kind, obj.GetName(), err)
+		}
+		return nil
+	})
+}
+
+// deleteRoute deletes the routing resource if exists
+func (r *WorkspaceReconciler) deleteRoute(ctx context.Context, ws cosmov1alpha1.Workspace, obj client.Object) error {
+	log := clog.FromContext(ctx).WithCaller()
+
+	if err := r.Delete(ctx, obj); err != nil {
+		// ignore if the CRD is not installed as well
+		if apierrs.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	kind := kindOf(obj, r.Scheme)
+	log.Info("routing deleted", "kind", kind, "name", obj.GetName())
+	kosmo.WorkspaceEventf(r.Recorder, &ws, corev1.EventTypeNormal, "Synced", "Successfully reconciled. %s %s is deleted", kind, obj.GetName())
+	return nil
+}
+
+func kindOf(obj client.Object, scheme *runtime.Scheme) string {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return fmt.Sprintf("%T", obj)
+	}
+	return gvk.Kind
 }
 
 func (r *WorkspaceReconciler) GenWorkspaceURLMap(ctx context.Context, ws cosmov1alpha1.Workspace) map[string]string {
+	log := clog.FromContext(ctx).WithCaller()
+
 	urlMap := make(map[string]string)
 	for _, netRule := range ws.Spec.Network {
-		host := cosmov1alpha1.GenHost(r.TraefikIngressRouteCfg.HostBase, r.TraefikIngressRouteCfg.Domain, netRule.HostPrefix(), ws)
-		url := cosmov1alpha1.GenURL(r.URLBaseProtocol, host, netRule.HTTPPath)
-		urlMap[netRule.UniqueKey()] = url
+		// private network rules are not routed without the authentication of the routing provider configured.
+		// unsupported protocols are rejected by the webhook, but remain in the workspaces created before the routing provider is changed
+		if !r.Routing.Supports(netRule) {
+			log.Debug().Info("network rule is not supported by the routing provider", "netRule", netRule.UniqueKey())
+			continue
+		}
+		urlMap[netRule.UniqueKey()] = r.Routing.URL(netRule, ws)
 	}
 	return urlMap
 }
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+	"github.com/cosmo-workspace/cosmo/pkg/workspace"
+)
+
+// WorkspaceScheduleReconciler starts and stops Workspaces by their schedules
+type WorkspaceScheduleReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=cosmo-workspace.github.io,resources=workspaces,verbs=get;list;watch;update;patch
+func (r *WorkspaceScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := clog.FromContext(ctx).WithName("WorkspaceScheduleReconciler").WithValues("req", req)
+
+	log.Debug().Info("start reconcile")
+
+	var ws cosmov1alpha1.Workspace
+	if err := r.Get(ctx, req.NamespacedName, &ws); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if ws.Spec.Schedule == nil {
+		log.Debug().Info("no schedule")
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	prev, next, err := workspace.ScheduleTransitions(*ws.Spec.Schedule, now)
+	if err != nil {
+		kosmo.WorkspaceEventf(r.Recorder, &ws, corev1.EventTypeWarning, "InvalidSchedule", "%s", err)
+		return ctrl.Result{}, nil
+	}
+
+	if prev != nil && !scheduleApplied(&ws, prev.Time) {
+		current := ws.DeepCopy()
+
+		if prev.Start && ptr.Deref(ws.Spec.Replicas, 1) == 0 {
+			ws.Spec.Replicas = ptr.To(int64(1))
+			kubeutil.SetAnnotation(&ws, cosmov1alpha1.WorkspaceAnnKeyLastStartedAt, now.Format(time.RFC3339))
+		} else if !prev.Start && ptr.Deref(ws.Spec.Replicas, 1) > 0 {
+			ws.Spec.Replicas = ptr.To(int64(0))
+			kubeutil.SetAnnotation(&ws, cosmov1alpha1.WorkspaceAnnKeyLastStoppedAt, now.Format(time.RFC3339))
+		}
+		kubeutil.SetAnnotation(&ws, cosmov1alpha1.WorkspaceAnnKeyScheduleLastAppliedAt, prev.Time.Format(time.RFC3339))
+
+		log.Debug().PrintObjectDiff(current, &ws)
+		if err := r.Update(ctx, &ws); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if ptr.Deref(current.Spec.Replicas, 1) != ptr.Deref(ws.Spec.Replicas, 1) {
+			if prev.Start {
+				log.Info("workspace started by schedule", "transition", prev.Time)
+				kosmo.WorkspaceEventf(r.Recorder, &ws, corev1.EventTypeNormal, "ScheduledStart", "Started by schedule at %s", prev.Time.Format(time.RFC3339))
+			} else {
+				log.Info("workspace stopped by schedule", "transition", prev.Time)
+				kosmo.WorkspaceEventf(r.Recorder, &ws, corev1.EventTypeNormal, "ScheduledStop", "Stopped by schedule at %s", prev.Time.Format(time.RFC3339))
+			}
+		}
+	}
+
+	if next == nil {
+		log.Debug().Info("finish reconcile", "next", nil)
+		return ctrl.Result{}, nil
+	}
+	log.Debug().Info("finish reconcile", "next", next.Time)
+	return ctrl.Result{RequeueAfter: next.Time.Sub(now)}, nil
+}
+
+// scheduleApplied returns true if the schedule transition at t is already applied to the workspace
+func scheduleApplied(ws *cosmov1alpha1.Workspace, t time.Time) bool {
+	last, err := time.Parse(time.RFC3339, kubeutil.GetAnnotation(ws, cosmov1alpha1.WorkspaceAnnKeyScheduleLastAppliedAt))
+	if err != nil {
+		return false
+	}
+	return !t.After(last)
+}
+
+func (r *WorkspaceScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&cosmov1alpha1.Workspace{}).
+		Complete(r)
+}
@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+)
+
+var _ = Describe("Workspace schedule controller", func() {
+	const tmplName string = "schedule-test"
+	const userName string = "wsscheduletest"
+	var nsName string = cosmov1alpha1.UserNamespace(userName)
+
+	tmpl := cosmov1alpha1.Template{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: tmplName,
+			Labels: map[string]string{
+				cosmov1alpha1.TemplateLabelKeyType: cosmov1alpha1.TemplateLabelEnumTypeWorkspace,
+			},
+		},
+		Spec: cosmov1alpha1.TemplateSpec{
+			RawYaml: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: '{{NAMESPACE}}'
+`,
+		},
+	}
+
+	Context("when creating Template and namespace", func() {
+		It("should be created", func() {
+			ctx := context.Background()
+
+			ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nsName}}
+			err := k8sClient.Create(ctx, &ns)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			err = k8sClient.Create(ctx, &tmpl)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("when running workspace is out of the scheduled window", func() {
+		It("should stop the workspace", func() {
+			ctx := context.Background()
+
+			now := time.Now().UTC()
+			ws := cosmov1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ws-schedule-stop",
+					Namespace: nsName,
+				},
+				Spec: cosmov1alpha1.WorkspaceSpec{
+					Template: cosmov1alpha1.TemplateRef{Name: tmplName},
+					Replicas: ptr.To(int64(1)),
+					Schedule: &cosmov1alpha1.WorkspaceSchedule{
+						StartTime: now.Add(time.Hour).Format("15:04"),
+						StopTime:  now.Add(-time.Hour).Format("15:04"),
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, &ws)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Eventually(func() int64 {
+				var w cosmov1alpha1.Workspace
+				if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(&ws), &w); err != nil {
+					return -1
+				}
+				return *w.Spec.Replicas
+			}, time.Second*10).Should(BeEquivalentTo(0))
+		})
+	})
+
+	Context("when transition is already applied", func() {
+		It("should not change replicas", func() {
+			ctx := context.Background()
+
+			now := time.Now().UTC()
+			ws := cosmov1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ws-schedule-applied",
+					Namespace: nsName,
+					Annotations: map[string]string{
+						cosmov1alpha1.WorkspaceAnnKeyScheduleLastAppliedAt: now.Format(time.RFC3339),
+					},
+				},
+				Spec: cosmov1alpha1.WorkspaceSpec{
+					Template: cosmov1alpha1.TemplateRef{Name: tmplName},
+					Replicas: ptr.To(int64(1)),
+					Schedule: &cosmov1alpha1.WorkspaceSchedule{
+						StartTime: now.Add(time.Hour).Format("15:04"),
+						StopTime:  now.Add(-time.Hour).Format("15:04"),
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, &ws)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Consistently(func() int64 {
+				var w cosmov1alpha1.Workspace
+				if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(&ws), &w); err != nil {
+					return -1
+				}
+				return *w.Spec.Replicas
+			}, time.Second*3).Should(BeEquivalentTo(1))
+
+			var w cosmov1alpha1.Workspace
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(&ws), &w)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(kubeutil.GetAnnotation(&w, cosmov1alpha1.WorkspaceAnnKeyScheduleLastAppliedAt)).Should(Equal(now.Format(time.RFC3339)))
+		})
+	})
+})
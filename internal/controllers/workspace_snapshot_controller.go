@@ -0,0 +1,263 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+	"github.com/cosmo-workspace/cosmo/pkg/workspace"
+)
+
+const (
+	snapshotPollingInterval     = 5 * time.Second
+	snapshotRestoreWaitInterval = 10 * time.Second
+)
+
+// WorkspaceSnapshotReconciler takes VolumeSnapshots of the workspace PVCs and restores them
+type WorkspaceSnapshotReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=cosmo-workspace.github.io,resources=workspacesnapshots,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=cosmo-workspace.github.io,resources=workspacesnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=cosmo-workspace.github.io,resources=instances,verbs=get;list;watch;patch
+func (r *WorkspaceSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := clog.FromContext(ctx).WithName("WorkspaceSnapshotReconciler").WithValues("req", req)
+	ctx = clog.IntoContext(ctx, log)
+
+	log.Debug().Info("start reconcile")
+
+	var snap cosmov1alpha1.WorkspaceSnapshot
+	if err := r.Get(ctx, req.NamespacedName, &snap); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !snap.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	current := snap.DeepCopy()
+
+	var result ctrl.Result
+	var err error
+	switch snap.Status.Phase {
+	case "":
+		result, err = r.takeSnapshot(ctx, &snap)
+	case cosmov1alpha1.WorkspaceSnapshotPhasePending:
+		result, err = r.checkSnapshot(ctx, &snap)
+	case cosmov1alpha1.WorkspaceSnapshotPhaseReady:
+		result, err = r.restore(ctx, &snap)
+	}
+
+	if !equality.Semantic.DeepEqual(current.Status, snap.Status) {
+		log.Debug().PrintObjectDiff(current, &snap)
+		if uerr := r.Status().Update(ctx, &snap); uerr != nil {
+			return ctrl.Result{}, uerr
+		}
+	}
+	log.Debug().Info("finish reconcile", "phase", snap.Status.Phase)
+	return result, err
+}
+
+func (r *WorkspaceSnapshotReconciler) fail(snap *cosmov1alpha1.WorkspaceSnapshot, reason, message string) {
+	snap.Status.Phase = cosmov1alpha1.WorkspaceSnapshotPhaseFailed
+	snap.Status.Message = message
+	r.Recorder.Event(snap, corev1.EventTypeWarning, reason, message)
+}
+
+// takeSnapshot creates VolumeSnapshots for each PVC in the workspace instance
+func (r *WorkspaceSnapshotReconciler) takeSnapshot(ctx context.Context, snap *cosmov1alpha1.WorkspaceSnapshot) (ctrl.Result, error) {
+	log := clog.FromContext(ctx)
+
+	var inst cosmov1alpha1.Instance
+	if err := r.Get(ctx, types.NamespacedName{Name: snap.Spec.Workspace, Namespace: snap.Namespace}, &inst); err != nil {
+		if apierrs.IsNotFound(err) {
+			r.fail(snap, "WorkspaceNotFound", fmt.Sprintf("instance of workspace %s is not found", snap.Spec.Workspace))
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	pvcNames := workspace.PersistentVolumeClaimNames(inst.Status.LastApplied)
+	if len(pvcNames) == 0 {
+		r.fail(snap, "NoVolumes", fmt.Sprintf("workspace %s has no PersistentVolumeClaim", snap.Spec.Workspace))
+		return ctrl.Result{}, nil
+	}
+
+	volumes := make([]cosmov1alpha1.VolumeSnapshot, 0, len(pvcNames))
+	for _, name := range pvcNames {
+		var pvc corev1.PersistentVolumeClaim
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: snap.Namespace}, &pvc); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get PersistentVolumeClaim %s: %w", name, err)
+		}
+
+		vs := workspace.NewVolumeSnapshot(snap, name)
+		if err := controllerutil.SetControllerReference(snap, vs, r.Scheme); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		if err := r.Create(ctx, vs); err != nil && !apierrs.IsAlreadyExists(err) {
+			r.fail(snap, "SnapshotFailed", fmt.Sprintf("failed to create VolumeSnapshot %s: %v", vs.GetName(), err))
+			return ctrl.Result{}, nil
+		}
+		log.Info("VolumeSnapshot created", "pvc", name, "volumesnapshot", vs.GetName())
+
+		volumes = append(volumes, workspace.VolumeSnapshotSource(snap.Name, &pvc))
+	}
+
+	snap.Status.Phase = cosmov1alpha1.WorkspaceSnapshotPhasePending
+	snap.Status.Message = ""
+	snap.Status.Volumes = volumes
+	r.Recorder.Eventf(snap, corev1.EventTypeNormal, "SnapshotCreated", "Created %d VolumeSnapshots of workspace %s", len(volumes), snap.Spec.Workspace)
+
+	return ctrl.Result{RequeueAfter: snapshotPollingInterval}, nil
+}
+
+// checkSnapshot waits for all VolumeSnapshots to be ready to use
+func (r *WorkspaceSnapshotReconciler) checkSnapshot(ctx context.Context, snap *cosmov1alpha1.WorkspaceSnapshot) (ctrl.Result, error) {
+	allReady := true
+	for i, v := range snap.Status.Volumes {
+		vs := &unstructured.Unstructured{}
+		vs.SetGroupVersionKind(workspace.VolumeSnapshotGVK)
+		if err := r.Get(ctx, types.NamespacedName{Name: v.VolumeSnapshotName, Namespace: snap.Namespace}, vs); err != nil {
+			if apierrs.IsNotFound(err) {
+				r.fail(snap, "SnapshotFailed", fmt.Sprintf("VolumeSnapshot %s is not found", v.VolumeSnapshotName))
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, err
+		}
+
+		ready, errMessage := workspace.VolumeSnapshotReadiness(vs)
+		if errMessage != "" {
+			r.fail(snap, "SnapshotFailed", fmt.Sprintf("VolumeSnapshot %s failed: %s", v.VolumeSnapshotName, errMessage))
+			return ctrl.Result{}, nil
+		}
+		snap.Status.Volumes[i].ReadyToUse = ready
+		allReady = allReady && ready
+	}
+
+	if !allReady {
+		return ctrl.Result{RequeueAfter: snapshotPollingInterval}, nil
+	}
+	snap.Status.Phase = cosmov1alpha1.WorkspaceSnapshotPhaseReady
+	r.Recorder.Eventf(snap, corev1.EventTypeNormal, "SnapshotReady", "All VolumeSnapshots of workspace %s are ready to use", snap.Spec.Workspace)
+
+	// restore may be requested before the snapshot is ready
+	return r.restore(ctx, snap)
+}
+
+// restore re-creates the PVCs from the VolumeSnapshots while the workspace is suspended
+func (r *WorkspaceSnapshotReconciler) restore(ctx context.Context, snap *cosmov1alpha1.WorkspaceSnapshot) (ctrl.Result, error) {
+	log := clog.FromContext(ctx)
+
+	requestedAt, ok := workspace.RestoreRequestedAt(snap)
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+
+	var ws cosmov1alpha1.Workspace
+	if err := r.Get(ctx, types.NamespacedName{Name: snap.Spec.Workspace, Namespace: snap.Namespace}, &ws); err != nil {
+		return ctrl.Result{}, err
+	}
+	if !workspace.IsSuspended(&ws) {
+		msg := fmt.Sprintf("waiting for workspace %s to be suspended to restore", ws.Name)
+		if snap.Status.Message != msg {
+			r.Recorder.Event(snap, corev1.EventTypeWarning, "RestoreWaiting", msg)
+		}
+		snap.Status.Message = msg
+		return ctrl.Result{RequeueAfter: snapshotRestoreWaitInterval}, nil
+	}
+
+	var inst cosmov1alpha1.Instance
+	if err := r.Get(ctx, types.NamespacedName{Name: ws.Name, Namespace: ws.Namespace}, &inst); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	restoredFrom := workspace.RestoredFrom(snap, requestedAt)
+
+	// pause the instance reconcile not to re-create the deleted PVCs from the template before they are restored
+	if kubeutil.GetAnnotation(&inst, cosmov1alpha1.InstanceAnnKeyReconcilePaused) != restoredFrom {
+		patch := client.MergeFrom(inst.DeepCopy())
+		kubeutil.SetAnnotation(&inst, cosmov1alpha1.InstanceAnnKeyReconcilePaused, restoredFrom)
+		if err := r.Patch(ctx, &inst, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to pause instance reconcile: %w", err)
+		}
+		log.Info("instance reconcile paused to restore", "instance", inst.Name)
+		// delete the PVCs after the paused instance is observed in the cache
+		return ctrl.Result{RequeueAfter: time.Second}, nil
+	}
+
+	done := true
+	for _, v := range snap.Status.Volumes {
+		var pvc corev1.PersistentVolumeClaim
+		err := r.Get(ctx, types.NamespacedName{Name: v.PersistentVolumeClaimName, Namespace: snap.Namespace}, &pvc)
+		if err != nil && !apierrs.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+		if err == nil {
+			if kubeutil.GetAnnotation(&pvc, cosmov1alpha1.WorkspaceSnapshotAnnKeyRestoredFrom) == restoredFrom {
+				continue
+			}
+			done = false
+			if pvc.DeletionTimestamp.IsZero() {
+				log.Info("deleting PersistentVolumeClaim to restore", "pvc", pvc.Name)
+				if err := r.Delete(ctx, &pvc); err != nil && !apierrs.IsNotFound(err) {
+					return ctrl.Result{}, fmt.Errorf("failed to delete PersistentVolumeClaim %s: %w", pvc.Name, err)
+				}
+			}
+			continue
+		}
+
+		restored := workspace.RestoredPersistentVolumeClaim(snap.Namespace, v, restoredFrom)
+		if kubeutil.GetAnnotation(restored, cosmov1alpha1.ResourceAnnKeyDeletePolicy) != cosmov1alpha1.ResourceAnnEnumDeletePolicyKeep {
+			if err := controllerutil.SetControllerReference(&inst, restored, r.Scheme); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to set owner reference: %w", err)
+			}
+		}
+		if err := r.Create(ctx, restored); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create PersistentVolumeClaim %s: %w", restored.Name, err)
+		}
+		log.Info("PersistentVolumeClaim restored", "pvc", restored.Name, "volumesnapshot", v.VolumeSnapshotName)
+	}
+
+	if !done {
+		return ctrl.Result{RequeueAfter: time.Second}, nil
+	}
+
+	patch := client.MergeFrom(inst.DeepCopy())
+	delete(inst.Annotations, cosmov1alpha1.InstanceAnnKeyReconcilePaused)
+	if err := r.Patch(ctx, &inst, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to resume instance reconcile: %w", err)
+	}
+	log.Info("instance reconcile resumed", "instance", inst.Name)
+
+	snap.Status.LastRestoredAt = &metav1.Time{Time: requestedAt}
+	snap.Status.Message = ""
+	r.Recorder.Eventf(snap, corev1.EventTypeNormal, "Restored", "Restored volumes of workspace %s", ws.Name)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager does not watch VolumeSnapshots but polls them
+// not to require the snapshot CRDs installed on the cluster when the feature is not used.
+func (r *WorkspaceSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&cosmov1alpha1.WorkspaceSnapshot{}).
+		Complete(r)
+}
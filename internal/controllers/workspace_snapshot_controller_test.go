@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+)
+
+// Test_WorkspaceSnapshotReconciler_restore checks the instance reconcile is paused
+// while the PVC is deleted and not yet restored, not to re-create the empty PVC from the template.
+func Test_WorkspaceSnapshotReconciler_restore(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cosmov1alpha1.AddToScheme(scheme))
+
+	ns := cosmov1alpha1.UserNamespace("tom")
+	requestedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	ws := &cosmov1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: ns},
+		Spec:       cosmov1alpha1.WorkspaceSpec{Replicas: ptr.To[int64](0)},
+		Status:     cosmov1alpha1.WorkspaceStatus{Phase: "Stopped"},
+	}
+	inst := &cosmov1alpha1.Instance{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: ns},
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1-home", Namespace: ns},
+	}
+	snap := &cosmov1alpha1.WorkspaceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "snap1",
+			Namespace:   ns,
+			Annotations: map[string]string{cosmov1alpha1.WorkspaceSnapshotAnnKeyRestoreRequestedAt: requestedAt.Format(time.RFC3339)},
+		},
+		Spec: cosmov1alpha1.WorkspaceSnapshotSpec{Workspace: "ws1"},
+		Status: cosmov1alpha1.WorkspaceSnapshotStatus{
+			Phase:   cosmov1alpha1.WorkspaceSnapshotPhaseReady,
+			Volumes: []cosmov1alpha1.VolumeSnapshot{{PersistentVolumeClaimName: "ws1-home", VolumeSnapshotName: "snap1-ws1-home", ReadyToUse: true}},
+		},
+	}
+
+	ctx := context.TODO()
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(ws, inst, pvc, snap).
+		WithStatusSubresource(&cosmov1alpha1.WorkspaceSnapshot{}, &cosmov1alpha1.Instance{}).
+		Build()
+
+	snapReconciler := &WorkspaceSnapshotReconciler{Client: c, Recorder: record.NewFakeRecorder(100), Scheme: scheme}
+	instReconciler := &InstanceReconciler{Client: c, Recorder: record.NewFakeRecorder(100), Scheme: scheme}
+
+	snapReq := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(snap)}
+	instReq := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(inst)}
+	pvcKey := client.ObjectKeyFromObject(pvc)
+
+	getInstance := func() *cosmov1alpha1.Instance {
+		t.Helper()
+		var got cosmov1alpha1.Instance
+		if err := c.Get(ctx, instReq.NamespacedName, &got); err != nil {
+			t.Fatal(err)
+		}
+		return &got
+	}
+
+	// 1. the instance is paused before the PVC is deleted
+	if _, err := snapReconciler.Reconcile(ctx, snapReq); err != nil {
+		t.Fatal(err)
+	}
+	if v := kubeutil.GetAnnotation(getInstance(), cosmov1alpha1.InstanceAnnKeyReconcilePaused); v != "snap1@2024-01-01T00:00:00Z" {
+		t.Fatalf("instance is not paused: %s", v)
+	}
+	if err := c.Get(ctx, pvcKey, &corev1.PersistentVolumeClaim{}); err != nil {
+		t.Fatalf("PVC is deleted before the instance is paused: %v", err)
+	}
+
+	// 2. the PVC is deleted
+	if _, err := snapReconciler.Reconcile(ctx, snapReq); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, pvcKey, &corev1.PersistentVolumeClaim{}); !apierrs.IsNotFound(err) {
+		t.Fatalf("PVC is not deleted: %v", err)
+	}
+
+	// the instance reconcile triggered by the PVC deletion does nothing
+	before := getInstance()
+	if _, err := instReconciler.Reconcile(ctx, instReq); err != nil {
+		t.Fatal(err)
+	}
+	if after := getInstance(); after.ResourceVersion != before.ResourceVersion {
+		t.Errorf("paused instance is reconciled")
+	}
+	if err := c.Get(ctx, pvcKey, &corev1.PersistentVolumeClaim{}); !apierrs.IsNotFound(err) {
+		t.Errorf("PVC is re-created while paused: %v", err)
+	}
+
+	// 3. the PVC is restored from the snapshot and the instance is resumed
+	if _, err := snapReconciler.Reconcile(ctx, snapReq); err != nil {
+		t.Fatal(err)
+	}
+	var restored corev1.PersistentVolumeClaim
+	if err := c.Get(ctx, pvcKey, &restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Spec.DataSource == nil || restored.Spec.DataSource.Name != "snap1-ws1-home" {
+		t.Errorf("PVC is not restored from the snapshot: %v", restored.Spec.DataSource)
+	}
+	if v := kubeutil.GetAnnotation(getInstance(), cosmov1alpha1.InstanceAnnKeyReconcilePaused); v != "" {
+		t.Errorf("instance is not resumed: %s", v)
+	}
+
+	var gotSnap cosmov1alpha1.WorkspaceSnapshot
+	if err := c.Get(ctx, types.NamespacedName{Name: snap.Name, Namespace: ns}, &gotSnap); err != nil {
+		t.Fatal(err)
+	}
+	if gotSnap.Status.LastRestoredAt == nil || !gotSnap.Status.LastRestoredAt.Time.Equal(requestedAt) {
+		t.Errorf("lastRestoredAt = %v", gotSnap.Status.LastRestoredAt)
+	}
+}
@@ -2,14 +2,22 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -18,14 +26,29 @@ import (
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/instance"
+	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
 	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
 )
 
+const (
+	// defaultStatusCheckInterval is the initial interval to recheck the status of the workspace not running
+	defaultStatusCheckInterval = 5 * time.Second
+	// maxStatusCheckInterval is the upper limit of the interval backed off
+	maxStatusCheckInterval = 5 * time.Minute
+)
+
 // WorkspaceStatusReconciler reconciles a Workspace object
 type WorkspaceStatusReconciler struct {
 	client.Client
 	Recorder record.EventRecorder
 	Scheme   *runtime.Scheme
+
+	// StatusCheckInterval is the initial interval to recheck the status while the workspace is starting.
+	// The interval is doubled as the workspace keeps starting.
+	StatusCheckInterval time.Duration
+	// StartTimeout is the duration to mark the workspace Failed when it is not running. 0 disables the timeout.
+	StartTimeout time.Duration
 }
 
 // +kubebuilder:rbac:groups=cosmo-workspace.github.io,resources=workspaces,verbs=get;list;watch
@@ -47,51 +70,224 @@ func (r *WorkspaceStatusReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	log.DebugAll().DumpObject(r.Scheme, &ws, "before workspace")
 
 	// set workspace phase
-	requeue := false
+	now := metav1.Now()
 	pods, err := listWorkspacePods(ctx, r.Client, ws)
 	if err != nil {
 		log.Info("failed to list instance pods", "error", err, "ws", ws.Name, "logLevel", "warn")
-		ws.Status.Phase = "NotRunning"
-
+		ws.Status.Phase, ws.Status.Reason, ws.Status.Message = "NotRunning", "", ""
 	} else {
-		if len(pods) > 0 {
-			if *ws.Spec.Replicas == 0 {
-				ws.Status.Phase = "Stopping"
-				requeue = true
-			} else {
-				requeue = true
-				for _, pod := range pods {
-					ws.Status.Phase = kubeutil.PodStatusReason(pod)
-					if ws.Status.Phase == "Running" {
-						requeue = false
-						break
-					}
-				}
+		ws.Status.Phase, ws.Status.Reason, ws.Status.Message = workspacePhase(ptr.Deref(ws.Spec.Replicas, 1), pods)
+
+		// the pods may not be created yet for some workloads
+		if ws.Status.Phase == "Running" || ws.Status.Phase == "Starting" {
+			if msg := r.checkWorkloads(ctx, &ws); msg != "" {
+				ws.Status.Phase, ws.Status.Reason, ws.Status.Message = "Starting", "", msg
 			}
+		}
+	}
+
+	var result ctrl.Result
+	switch ws.Status.Phase {
+	case "Running", "Stopped":
+		ws.Status.StartingSince = nil
+	case "Stopping":
+		ws.Status.StartingSince = nil
+		result.RequeueAfter = r.statusCheckInterval()
+	default:
+		ws.Status.StartingSince = startingSince(ws.Status.StartingSince, pods, now)
+		elapsed := now.Sub(ws.Status.StartingSince.Time)
 
-		} else {
-			if *ws.Spec.Replicas > 0 {
-				ws.Status.Phase = "Starting"
-				requeue = true
-			} else {
-				ws.Status.Phase = "Stopped"
+		if r.StartTimeout > 0 && elapsed >= r.StartTimeout {
+			msg := fmt.Sprintf("workspace is not running in %s", r.StartTimeout)
+			if ws.Status.Message != "" {
+				msg = fmt.Sprintf("%s: %s", msg, ws.Status.Message)
 			}
+			ws.Status.Phase = "Failed"
+			ws.Status.Reason = cosmov1alpha1.ConditionReasonStartTimeout
+			ws.Status.Message = msg
+
+			if current.Status.Phase != "Failed" {
+				kosmo.WorkspaceEventf(r.Recorder, &ws, corev1.EventTypeWarning, ws.Status.Reason, "Workspace is marked as Failed: %s", ws.Status.Message)
+			}
+			// the pod events trigger the reconciliation after the workspace is failed
+			break
 		}
+		result.RequeueAfter = statusCheckBackoff(r.statusCheckInterval(), elapsed, r.StartTimeout)
 	}
 
+	setWorkspaceReadyCondition(&ws)
+
 	// update workspace status
 	if !equality.Semantic.DeepEqual(current, &ws) {
 		log.Debug().PrintObjectDiff(current, &ws)
 		if err := r.Status().Update(ctx, &ws); err != nil {
 			return ctrl.Result{}, err
 		}
-		log.Info("status phase updated", "before", current.Status.Phase, "now", ws.Status.Phase)
+		log.Info("status phase updated", "before", current.Status.Phase, "now", ws.Status.Phase, "reason", ws.Status.Reason)
+	}
+
+	log.Debug().Info("finish reconcile", "requeueAfter", result.RequeueAfter)
+	return result, nil
+}
+
+// checkWorkloads returns the message of the first workload of the workspace not ready,
+// or empty if all the main and additional workloads are ready.
+func (r *WorkspaceStatusReconciler) checkWorkloads(ctx context.Context, ws *cosmov1alpha1.Workspace) string {
+	log := clog.FromContext(ctx).WithCaller()
+
+	for _, ref := range ws.Status.Config.Workloads() {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(ref.GroupVersionKind())
+		key := types.NamespacedName{Name: instance.InstanceResourceName(ws.Name, ref.Name), Namespace: ws.Namespace}
+
+		if err := r.Get(ctx, key, obj); err != nil {
+			if apierrs.IsNotFound(err) {
+				return fmt.Sprintf("%s %s is not found", ref.Kind, key.Name)
+			}
+			log.Info("failed to get workload", "error", err, "workload", ref.String(), "logLevel", "warn")
+			continue
+		}
+		if healthy, msg := kubeutil.CheckHealth(obj); !healthy {
+			return msg
+		}
+	}
+	return ""
+}
+
+func (r *WorkspaceStatusReconciler) statusCheckInterval() time.Duration {
+	if r.StatusCheckInterval > 0 {
+		return r.StatusCheckInterval
+	}
+	return defaultStatusCheckInterval
+}
+
+// workspacePhase aggregates the status of all the workspace pods into the phase and its reason and message.
+// The workspace is Running only when all the pods are running, and a failing pod takes priority over the pods in progress.
+// The pods of the additional workloads are aggregated together with the pods of the main workload,
+// as they are suspended and resumed together and the workspace does not work without them.
+func workspacePhase(replicas int64, pods []corev1.Pod) (phase, reason, message string) {
+	var active, failed []corev1.Pod
+	terminating := 0
+	for _, pod := range pods {
+		switch {
+		case pod.Status.Phase == corev1.PodSucceeded:
+			continue
+		case pod.DeletionTimestamp != nil:
+			terminating++
+		case pod.Status.Phase == corev1.PodFailed:
+			// failed pods such as evicted ones remain until they are garbage collected
+			failed = append(failed, pod)
+		default:
+			active = append(active, pod)
+		}
+	}
+
+	if replicas == 0 {
+		if len(active)+terminating > 0 {
+			return "Stopping", "", ""
+		}
+		return "Stopped", "", ""
 	}
 
-	// Requeue: true makes exponential backoff by default
-	// https://github.com/kubernetes-sigs/controller-runtime/issues/808
-	log.Debug().Info("finish reconcile", "requeue", requeue)
-	return ctrl.Result{Requeue: requeue}, nil
+	// failed pods are reported only when they are not replaced by new pods yet
+	if len(active) == 0 {
+		active = failed
+	}
+	if len(active) == 0 {
+		return "Starting", "", ""
+	}
+
+	sort.Slice(active, func(i, j int) bool { return active[i].Name < active[j].Name })
+
+	var notRunning *corev1.Pod
+	for i, pod := range active {
+		podReason := kubeutil.PodStatusReason(pod)
+		if kubeutil.IsPodFailureReason(podReason) {
+			return podReason, podReason, podStatusMessage(pod)
+		}
+		if podReason != "Running" && notRunning == nil {
+			notRunning = &active[i]
+		}
+	}
+	if notRunning == nil {
+		return "Running", "", ""
+	}
+	podReason := kubeutil.PodStatusReason(*notRunning)
+	return podReason, podReason, podStatusMessage(*notRunning)
+}
+
+func podStatusMessage(pod corev1.Pod) string {
+	if msg := kubeutil.PodStatusMessage(pod); msg != "" {
+		return fmt.Sprintf("pod %s: %s", pod.Name, msg)
+	}
+	return fmt.Sprintf("pod %s is %s", pod.Name, kubeutil.PodStatusReason(pod))
+}
+
+// startingSince returns the time when the workspace started starting.
+// It is moved forward when a new pod is created, so that the timeout restarts on a rollout or a restart of the pods.
+func startingSince(since *metav1.Time, pods []corev1.Pod, now metav1.Time) *metav1.Time {
+	if since == nil {
+		since = &now
+	}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil && since.Before(&pod.CreationTimestamp) {
+			since = pod.CreationTimestamp.DeepCopy()
+		}
+	}
+	return since
+}
+
+// statusCheckBackoff returns the interval to recheck the status of the workspace which has been starting for the elapsed time.
+// The interval is doubled from the base as the time elapses up to maxStatusCheckInterval,
+// and is not longer than the time remaining until the timeout.
+func statusCheckBackoff(base, elapsed, timeout time.Duration) time.Duration {
+	d := base
+	for d*2 <= elapsed && d < maxStatusCheckInterval {
+		d *= 2
+	}
+	if d > maxStatusCheckInterval {
+		d = maxStatusCheckInterval
+	}
+	if timeout > 0 && timeout-elapsed < d {
+		d = timeout - elapsed
+	}
+	return d
+}
+
+// setWorkspaceReadyCondition sets Ready condition by the conditions set by WorkspaceReconciler and the workspace phase
+func setWorkspaceReadyCondition(ws *cosmov1alpha1.Workspace) {
+	for _, t := range []string{cosmov1alpha1.ConditionTypeTemplateResolved, cosmov1alpha1.ConditionTypeSynced, cosmov1alpha1.ConditionTypeRoutingReady} {
+		c := meta.FindStatusCondition(ws.Status.Conditions, t)
+		if c == nil {
+			setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeReady, metav1.ConditionFalse, cosmov1alpha1.ConditionReasonProgressing, fmt.Sprintf("%s is not reported yet", t))
+			return
+		}
+		if c.Status != metav1.ConditionTrue {
+			setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeReady, metav1.ConditionFalse, c.Reason, fmt.Sprintf("%s is %s: %s", t, c.Status, c.Message))
+			return
+		}
+	}
+
+	switch ws.Status.Phase {
+	case "Running":
+		setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeReady, metav1.ConditionTrue, cosmov1alpha1.ConditionReasonReady, "")
+	case "Stopped", "Stopping":
+		setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeReady, metav1.ConditionFalse, cosmov1alpha1.ConditionReasonStopped, fmt.Sprintf("workspace is %s", ws.Status.Phase))
+	case "Starting":
+		msg := "workspace is starting"
+		if ws.Status.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, ws.Status.Message)
+		}
+		setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeReady, metav1.ConditionFalse, cosmov1alpha1.ConditionReasonProgressing, msg)
+	case "Failed":
+		setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeReady, metav1.ConditionFalse, ws.Status.Reason, ws.Status.Message)
+	default:
+		msg := fmt.Sprintf("workspace pod is %s", ws.Status.Phase)
+		if ws.Status.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, ws.Status.Message)
+		}
+		setCondition(&ws.Status.Conditions, ws.Generation, cosmov1alpha1.ConditionTypeReady, metav1.ConditionFalse, cosmov1alpha1.ConditionReasonNotReady, msg)
+	}
 }
 
 func (r *WorkspaceStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -124,7 +320,7 @@ func (r *WorkspaceStatusReconciler) findWorkspaceByService(ctx context.Context,
 
 func findWorkspace[T client.Object](ctx context.Context, obj T, c client.Client) []reconcile.Request {
 	var ws cosmov1alpha1.Workspace
-	if err := c.Get(ctx, types.NamespacedName{Name: obj.GetLabels()[cosmov1alpha1.LabelKeyInstanceName]}, &ws); err == nil {
+	if err := c.Get(ctx, types.NamespacedName{Name: obj.GetLabels()[cosmov1alpha1.LabelKeyInstanceName], Namespace: obj.GetNamespace()}, &ws); err == nil {
 		// request is Pod with "cosmo-workspace.github.io/instance" label
 		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: ws.Name, Namespace: ws.Namespace}}}
 	}
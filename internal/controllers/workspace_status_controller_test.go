@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_workspacePhase(t *testing.T) {
+	running := func(name string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main"}}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "main", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+				},
+			},
+		}
+	}
+	waiting := func(name, reason, message string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main"}}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "main", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: message}}},
+				},
+			},
+		}
+	}
+	terminating := func(pod corev1.Pod) corev1.Pod {
+		pod.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		return pod
+	}
+	evicted := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1-0"},
+		Status:     corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted", Message: "The node was low on resource: memory."},
+	}
+
+	type want struct {
+		phase, reason, message string
+	}
+	tests := []struct {
+		name     string
+		replicas int64
+		pods     []corev1.Pod
+		want     want
+	}{
+		{
+			name:     "✅ All pods running",
+			replicas: 1,
+			pods:     []corev1.Pod{running("ws1-a"), running("ws1-b")},
+			want:     want{phase: "Running"},
+		},
+		{
+			name:     "✅ Evicted pod replaced",
+			replicas: 1,
+			pods:     []corev1.Pod{evicted, running("ws1-1")},
+			want:     want{phase: "Running"},
+		},
+		{
+			name:     "❌ One of pods crashing",
+			replicas: 1,
+			pods:     []corev1.Pod{running("ws1-a"), waiting("ws1-b", "CrashLoopBackOff", "back-off 5m0s restarting failed container")},
+			want:     want{phase: "CrashLoopBackOff", reason: "CrashLoopBackOff", message: "pod ws1-b: container main: back-off 5m0s restarting failed container"},
+		},
+		{
+			name:     "❌ Failure takes priority over progress",
+			replicas: 1,
+			pods:     []corev1.Pod{waiting("ws1-a", "ContainerCreating", ""), waiting("ws1-b", "ImagePullBackOff", "Back-off pulling image")},
+			want:     want{phase: "ImagePullBackOff", reason: "ImagePullBackOff", message: "pod ws1-b: container main: Back-off pulling image"},
+		},
+		{
+			name:     "❌ Pod in progress",
+			replicas: 1,
+			pods:     []corev1.Pod{running("ws1-a"), waiting("ws1-b", "ContainerCreating", "")},
+			want:     want{phase: "ContainerCreating", reason: "ContainerCreating", message: "pod ws1-b is ContainerCreating"},
+		},
+		{
+			name:     "❌ Evicted pod not replaced yet",
+			replicas: 1,
+			pods:     []corev1.Pod{evicted},
+			want:     want{phase: "Evicted", reason: "Evicted", message: "pod ws1-0: The node was low on resource: memory."},
+		},
+		{
+			name:     "❌ No pods",
+			replicas: 1,
+			want:     want{phase: "Starting"},
+		},
+		{
+			name:     "❌ Old pod terminating",
+			replicas: 1,
+			pods:     []corev1.Pod{terminating(running("ws1-a"))},
+			want:     want{phase: "Starting"},
+		},
+		{
+			name:     "❌ Stopping",
+			replicas: 0,
+			pods:     []corev1.Pod{terminating(running("ws1-a"))},
+			want:     want{phase: "Stopping"},
+		},
+		{
+			name:     "❌ Stopped with evicted pod",
+			replicas: 0,
+			pods:     []corev1.Pod{evicted},
+			want:     want{phase: "Stopped"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got want
+			got.phase, got.reason, got.message = workspacePhase(tt.replicas, tt.pods)
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("workspacePhase() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_statusCheckBackoff(t *testing.T) {
+	base := 5 * time.Second
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{name: "✅ Just started", elapsed: 0, want: 5 * time.Second},
+		{name: "✅ Doubled", elapsed: 12 * time.Second, want: 10 * time.Second},
+		{name: "✅ Doubled twice", elapsed: 20 * time.Second, want: 20 * time.Second},
+		{name: "✅ Capped", elapsed: time.Hour, want: maxStatusCheckInterval},
+		{name: "✅ Until timeout", elapsed: 9*time.Minute + 50*time.Second, timeout: 10 * time.Minute, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusCheckBackoff(base, tt.elapsed, tt.timeout); got != tt.want {
+				t.Errorf("statusCheckBackoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_startingSince(t *testing.T) {
+	now := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	before := metav1.NewTime(now.Add(-time.Hour))
+	newPod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ws1-b", CreationTimestamp: metav1.NewTime(now.Add(-time.Minute))}}
+	oldPod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ws1-a", CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour))}}
+
+	tests := []struct {
+		name  string
+		since *metav1.Time
+		pods  []corev1.Pod
+		want  metav1.Time
+	}{
+		{name: "✅ Not starting yet", since: nil, want: now},
+		{name: "✅ Keep", since: &before, pods: []corev1.Pod{oldPod}, want: before},
+		{name: "✅ New pod created", since: &before, pods: []corev1.Pod{oldPod, newPod}, want: newPod.CreationTimestamp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := startingSince(tt.since, tt.pods, now)
+			if !got.Equal(&tt.want) {
+				t.Errorf("startingSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
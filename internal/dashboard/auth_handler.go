@@ -2,6 +2,7 @@ package dashboard
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -12,6 +13,7 @@ import (
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/auth"
 	"github.com/cosmo-workspace/cosmo/pkg/auth/session"
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
 	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
@@ -70,18 +72,41 @@ func (s *Server) Login(ctx context.Context, req *connect_go.Request[dashv1alpha1
 
 	// Check name
 	user, err := s.Klient.GetUser(ctx, req.Msg.UserName)
+	authType := s.jitPasswordAuthType()
 	if err != nil {
-		log.Info(err.Error(), "username", req.Msg.UserName)
-		return nil, ErrResponse(log, NewForbidden(fmt.Errorf("incorrect user or password")))
+		if !s.JITUserCreation || !apierrs.IsNotFound(err) {
+			log.Info(err.Error(), "username", req.Msg.UserName)
+			return nil, ErrResponse(log, NewForbidden(fmt.Errorf("incorrect user or password")))
+		}
+		// user not found is created after authorized by LDAP or webhook
+		user = nil
+	} else {
+		authType = user.Spec.AuthType
 	}
 	// Check password
-	authrizer, ok := s.Authorizers[user.Spec.AuthType]
+	authrizer, ok := s.Authorizers[authType]
 	if !ok {
-		log.Info("authrizer not found", "username", req.Msg.UserName, "authType", user.Spec.AuthType)
+		log.Info("authrizer not found", "username", req.Msg.UserName, "authType", authType)
+		if user == nil {
+			return nil, ErrResponse(log, NewForbidden(fmt.Errorf("incorrect user or password")))
+		}
 		return nil, ErrResponse(log, apierrs.NewServiceUnavailable(
-			fmt.Sprintf("auth-type '%s' is not supported", user.Spec.AuthType)))
+			fmt.Sprintf("auth-type '%s' is not supported", authType)))
+	}
+	var (
+		verified       bool
+		groups         []string
+		groupsResolved = true
+	)
+	if ga, ok := authrizer.(auth.GroupAuthorizer); ok {
+		verified, groups, err = ga.AuthorizeWithGroups(ctx, req.Msg)
+		if verified && errors.Is(err, auth.ErrGroupsNotResolved) {
+			log.Error(err, "login without syncing roles", "username", req.Msg.UserName)
+			groupsResolved, err = false, nil
+		}
+	} else {
+		verified, err = authrizer.Authorize(ctx, req.Msg)
 	}
-	verified, err := authrizer.Authorize(ctx, req.Msg)
 	if err != nil {
 		log.Error(err, "authorize failed", "username", req.Msg.UserName)
 		return nil, ErrResponse(log, NewForbidden(fmt.Errorf("incorrect user or password")))
@@ -91,8 +116,14 @@ func (s *Server) Login(ctx context.Context, req *connect_go.Request[dashv1alpha1
 		log.Info("login failed: password invalid", "username", req.Msg.UserName)
 		return nil, ErrResponse(log, NewForbidden(fmt.Errorf("incorrect user or password")))
 	}
+	if _, ok := authrizer.(auth.GroupAuthorizer); ok || user == nil {
+		if _, err := s.provisionUser(ctx, user, req.Msg.UserName, authType, groups, groupsResolved); err != nil {
+			log.Error(err, "failed to provision user", "username", req.Msg.UserName)
+			return nil, ErrResponse(log, apierrs.NewInternalError(err))
+		}
+	}
 	var isDefault bool
-	if cosmov1alpha1.UserAuthType(user.Spec.AuthType) == cosmov1alpha1.UserAuthTypePasswordSecert {
+	if authType == cosmov1alpha1.UserAuthTypePasswordSecert {
 		isDefault, err = s.Klient.IsDefaultPassword(ctx, req.Msg.UserName)
 		if err != nil {
 			log.Error(err, "failed to check is default password", "username", req.Msg.UserName)
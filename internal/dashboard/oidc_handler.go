@@ -0,0 +1,305 @@
+package dashboard
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+const (
+	oidcLoginPath    = "/oidc/login"
+	oidcCallbackPath = "/oidc/callback"
+
+	oidcSessionKeyState       = "state"
+	oidcSessionKeyNonce       = "nonce"
+	oidcSessionKeyVerifier    = "verifier"
+	oidcSessionKeyRedirectTo  = "redirect_to"
+	oidcSessionMaxAgeSeconds  = 600
+	oidcSessionNameSuffix     = "-oidc"
+	oidcDefaultUsernameClaim  = "preferred_username"
+	oidcDefaultGroupsClaim    = "groups"
+	oidcQueryParamRedirectTo  = "redirect_to"
+	oidcDefaultRedirectTarget = "/"
+)
+
+// OIDCConfig is the configuration for OpenID Connect authorization code flow with PKCE
+type OIDCConfig struct {
+	IssuerURL     string
+	ClientID      string
+	ClientSecret  string
+	RedirectURL   string
+	Scopes        []string
+	UsernameClaim string
+	GroupsClaim   string
+
+	// HTTPClient is used to communicate with the issuer if not nil
+	HTTPClient *http.Client
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+func (c *OIDCConfig) context(ctx context.Context) context.Context {
+	if c.HTTPClient != nil {
+		return oidc.ClientContext(ctx, c.HTTPClient)
+	}
+	return ctx
+}
+
+// Provider returns the discovered OpenID provider. Discovery is done at the first call.
+func (c *OIDCConfig) Provider(ctx context.Context) (*oidc.Provider, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.provider != nil {
+		return c.provider, nil
+	}
+	p, err := oidc.NewProvider(c.context(ctx), c.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OpenID provider: %w", err)
+	}
+	c.provider = p
+	return p, nil
+}
+
+func (c *OIDCConfig) oauth2Config(p *oidc.Provider) *oauth2.Config {
+	scopes := c.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID}
+	}
+	return &oauth2.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		RedirectURL:  c.RedirectURL,
+		Endpoint:     p.Endpoint(),
+		Scopes:       scopes,
+	}
+}
+
+func (c *OIDCConfig) usernameClaim() string {
+	if c.UsernameClaim == "" {
+		return oidcDefaultUsernameClaim
+	}
+	return c.UsernameClaim
+}
+
+func (c *OIDCConfig) groupsClaim() string {
+	if c.GroupsClaim == "" {
+		return oidcDefaultGroupsClaim
+	}
+	return c.GroupsClaim
+}
+
+func (s *Server) OIDCHandler(mux *http.ServeMux) {
+	if s.OIDC == nil {
+		return
+	}
+	mux.Handle(oidcLoginPath, s.timeoutHandler(http.HandlerFunc(s.OIDCLogin)))
+	mux.Handle(oidcCallbackPath, s.timeoutHandler(http.HandlerFunc(s.OIDCCallback)))
+}
+
+func (s *Server) oidcSessionName() string {
+	return s.CookieSessionName + oidcSessionNameSuffix
+}
+
+// OIDCLogin starts authorization code flow with PKCE by redirecting to the issuer
+func (s *Server) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	log := s.Log.WithName("oidc")
+	ctx := r.Context()
+
+	provider, err := s.OIDC.Provider(ctx)
+	if err != nil {
+		log.Error(err, "failed to get OpenID provider")
+		http.Error(w, "OpenID provider is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	state, err := randomString()
+	if err != nil {
+		log.Error(err, "failed to generate state")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	nonce, err := randomString()
+	if err != nil {
+		log.Error(err, "failed to generate nonce")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	redirectTo := r.URL.Query().Get(oidcQueryParamRedirectTo)
+	if !s.isValidRedirectURL(redirectTo) {
+		redirectTo = oidcDefaultRedirectTarget
+	}
+
+	ses, _ := s.sessionStore.New(r, s.oidcSessionName())
+	ses.Options.MaxAge = oidcSessionMaxAgeSeconds
+	ses.Values[oidcSessionKeyState] = state
+	ses.Values[oidcSessionKeyNonce] = nonce
+	ses.Values[oidcSessionKeyVerifier] = verifier
+	ses.Values[oidcSessionKeyRedirectTo] = redirectTo
+	if err := ses.Save(r, w); err != nil {
+		log.Error(err, "failed to save oidc session")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	authURL := s.OIDC.oauth2Config(provider).AuthCodeURL(state, oauth2.S256ChallengeOption(verifier), oidc.Nonce(nonce))
+	log.Debug().Info("redirect to OpenID provider", "url", authURL)
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// OIDCCallback exchanges the authorization code and creates the login session
+func (s *Server) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	log := s.Log.WithName("oidc")
+	ctx := r.Context()
+
+	ses, err := s.sessionStore.Get(r, s.oidcSessionName())
+	if err != nil || ses.IsNew {
+		log.Info("oidc session not found", "error", err)
+		http.Error(w, "invalid login session", http.StatusBadRequest)
+		return
+	}
+	state, _ := ses.Values[oidcSessionKeyState].(string)
+	nonce, _ := ses.Values[oidcSessionKeyNonce].(string)
+	verifier, _ := ses.Values[oidcSessionKeyVerifier].(string)
+	redirectTo, _ := ses.Values[oidcSessionKeyRedirectTo].(string)
+
+	// oidc session is used only once
+	ses.Options.MaxAge = -1
+	if err := ses.Save(r, w); err != nil {
+		log.Error(err, "failed to clear oidc session")
+	}
+
+	q := r.URL.Query()
+	if e := q.Get("error"); e != "" {
+		log.Info("authorization failed", "error", e, "description", q.Get("error_description"))
+		http.Error(w, "authorization failed", http.StatusForbidden)
+		return
+	}
+	if state == "" || q.Get("state") != state {
+		log.Info("state mismatch")
+		http.Error(w, "invalid state", http.StatusBadRequest)
+		return
+	}
+
+	provider, err := s.OIDC.Provider(ctx)
+	if err != nil {
+		log.Error(err, "failed to get OpenID provider")
+		http.Error(w, "OpenID provider is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	oidcCtx := s.OIDC.context(ctx)
+	token, err := s.OIDC.oauth2Config(provider).Exchange(oidcCtx, q.Get("code"), oauth2.VerifierOption(verifier))
+	if err != nil {
+		log.Error(err, "failed to exchange token")
+		http.Error(w, "failed to exchange token", http.StatusForbidden)
+		return
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		log.Info("id_token not found in token response")
+		http.Error(w, "id_token not found", http.StatusForbidden)
+		return
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: s.OIDC.ClientID}).Verify(oidcCtx, rawIDToken)
+	if err != nil {
+		log.Error(err, "failed to verify id_token")
+		http.Error(w, "invalid id_token", http.StatusForbidden)
+		return
+	}
+	if idToken.Nonce != nonce {
+		log.Info("nonce mismatch")
+		http.Error(w, "invalid nonce", http.StatusForbidden)
+		return
+	}
+
+	claims := make(map[string]interface{})
+	if err := idToken.Claims(&claims); err != nil {
+		log.Error(err, "failed to parse claims")
+		http.Error(w, "invalid claims", http.StatusForbidden)
+		return
+	}
+	userName, _ := claims[s.OIDC.usernameClaim()].(string)
+	if userName == "" {
+		log.Info("username claim not found", "claim", s.OIDC.usernameClaim())
+		http.Error(w, "username claim not found", http.StatusForbidden)
+		return
+	}
+
+	user, err := s.Klient.GetUser(ctx, userName)
+	if err != nil {
+		if !s.JITUserCreation || !apierrs.IsNotFound(err) {
+			log.Info(err.Error(), "username", userName)
+			http.Error(w, "user not found", http.StatusForbidden)
+			return
+		}
+		user = nil
+	} else if user.Spec.AuthType != cosmov1alpha1.UserAuthTypeOIDC {
+		log.Info("auth type is not oidc", "username", userName, "authType", user.Spec.AuthType)
+		http.Error(w, "user is not allowed to login with OpenID Connect", http.StatusForbidden)
+		return
+	}
+
+	groups := claimValues(claims, s.OIDC.groupsClaim())
+	if _, err := s.provisionUser(ctx, user, userName, cosmov1alpha1.UserAuthTypeOIDC, groups, true); err != nil {
+		log.Error(err, "failed to provision user", "username", userName)
+		http.Error(w, "failed to provision user", http.StatusInternalServerError)
+		return
+	}
+
+	sesInfo, _ := s.SessionInfo(userName)
+	if err := s.CreateSession(w, r, sesInfo); err != nil {
+		log.Error(err, "failed to save session")
+		http.Error(w, "failed to save session", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info("login with OpenID Connect", "username", userName)
+	if redirectTo == "" {
+		redirectTo = oidcDefaultRedirectTarget
+	}
+	http.Redirect(w, r, redirectTo, http.StatusFound)
+}
+
+// isValidRedirectURL returns true if the url is a path in dashboard or the url in the cookie domain
+func (s *Server) isValidRedirectURL(v string) bool {
+	if v == "" {
+		return false
+	}
+	u, err := url.Parse(v)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" && u.Host == "" {
+		return strings.HasPrefix(u.Path, "/") && !strings.HasPrefix(v, "//")
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return false
+	}
+	if s.CookieDomain == "" {
+		return false
+	}
+	host := u.Hostname()
+	return host == s.CookieDomain || strings.HasSuffix(host, "."+s.CookieDomain)
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
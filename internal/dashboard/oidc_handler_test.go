@@ -0,0 +1,406 @@
+package dashboard
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/auth"
+	"github.com/cosmo-workspace/cosmo/pkg/auth/session"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
+)
+
+const testOIDCClientID = "cosmo-dashboard"
+
+// testIssuer is a stand-in OpenID provider which supports authorization code flow with PKCE
+type testIssuer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	// subject is the preferred_username claim of the issued id_token
+	subject string
+	// groups is the groups claim of the issued id_token
+	groups []string
+	// overrideNonce is set to id_token instead of the requested nonce if not empty
+	overrideNonce string
+
+	mu    sync.Mutex
+	codes map[string]testAuthCode
+}
+
+type testAuthCode struct {
+	challenge string
+	nonce     string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iss := &testIssuer{key: key, codes: make(map[string]testAuthCode)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                iss.URL,
+			"authorization_endpoint":                iss.URL + "/authorize",
+			"token_endpoint":                        iss.URL + "/token",
+			"jwks_uri":                              iss.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "test", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("client_id") != testOIDCClientID || q.Get("code_challenge_method") != "S256" {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		code := "code-" + q.Get("state")
+		iss.mu.Lock()
+		iss.codes[code] = testAuthCode{challenge: q.Get("code_challenge"), nonce: q.Get("nonce")}
+		iss.mu.Unlock()
+
+		u, _ := url.Parse(q.Get("redirect_uri"))
+		rq := u.Query()
+		rq.Set("code", code)
+		rq.Set("state", q.Get("state"))
+		u.RawQuery = rq.Encode()
+		http.Redirect(w, r, u.String(), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		iss.mu.Lock()
+		c, ok := iss.codes[r.PostForm.Get("code")]
+		delete(iss.codes, r.PostForm.Get("code"))
+		iss.mu.Unlock()
+
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != c.challenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		nonce := c.nonce
+		if iss.overrideNonce != "" {
+			nonce = iss.overrideNonce
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     iss.idToken(t, nonce),
+		})
+	})
+	iss.Server = httptest.NewServer(mux)
+	t.Cleanup(iss.Close)
+	return iss
+}
+
+func (iss *testIssuer) idToken(t *testing.T, nonce string) string {
+	t.Helper()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: iss.key, KeyID: "test"}},
+		(&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	payload, _ := json.Marshal(map[string]interface{}{
+		"iss":                iss.URL,
+		"sub":                "sub-" + iss.subject,
+		"aud":                testOIDCClientID,
+		"iat":                now.Unix(),
+		"exp":                now.Add(time.Hour).Unix(),
+		"nonce":              nonce,
+		"preferred_username": iss.subject,
+		"groups":             iss.groups,
+	})
+	jws, err := signer.Sign(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := jws.CompactSerialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func newTestOIDCServer(t *testing.T, iss *testIssuer, users ...cosmov1alpha1.User) (*Server, http.Handler) {
+	t.Helper()
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for i := range users {
+		builder = builder.WithObjects(&users[i])
+	}
+	s := &Server{
+		Log:               clog.NewLogger(logr.Discard()),
+		Klient:            kosmo.NewClient(builder.Build()),
+		ResponseTimeout:   5 * time.Second,
+		MaxAgeSeconds:     60,
+		CookieDomain:      "example.com",
+		CookieHashKey:     "----+----1----+----2----+----3----+----4----+----5----+----6----",
+		CookieBlockKey:    "----+----1----+----2----+----3--",
+		CookieSessionName: "test-server",
+		OIDC: &OIDCConfig{
+			IssuerURL:   iss.URL,
+			ClientID:    testOIDCClientID,
+			RedirectURL: "https://dashboard.example.com" + oidcCallbackPath,
+			Scopes:      []string{"openid", "profile"},
+			HTTPClient:  iss.Client(),
+		},
+	}
+	s.setupSessionStore()
+	mux := http.NewServeMux()
+	s.OIDCHandler(mux)
+	return s, mux
+}
+
+func serve(h http.Handler, target string, cookies []*http.Cookie) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Result()
+}
+
+func TestServer_OIDCLogin(t *testing.T) {
+	oidcUser := cosmov1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "oidc-user"},
+		Spec:       cosmov1alpha1.UserSpec{AuthType: cosmov1alpha1.UserAuthTypeOIDC},
+	}
+	passwordUser := cosmov1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "password-user"},
+		Spec:       cosmov1alpha1.UserSpec{AuthType: cosmov1alpha1.UserAuthTypePasswordSecert},
+	}
+	roleMapper, err := auth.NewRoleMapper([]auth.RoleMapping{
+		{GroupPattern: "^(.+)-admins$", Roles: []string{"$1-admin"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		subject        string
+		groups         []string
+		jit            bool
+		roleMapper     *auth.RoleMapper
+		overrideNonce  string
+		redirectTo     string
+		tamperState    bool
+		tamperCode     bool
+		wantStatus     int
+		wantLocation   string
+		wantSessionFor string
+		wantRoles      []cosmov1alpha1.UserRole
+	}{
+		{
+			name:           "✅ login and redirect to redirect_to",
+			subject:        "oidc-user",
+			redirectTo:     "https://ws.example.com/path",
+			wantStatus:     http.StatusFound,
+			wantLocation:   "https://ws.example.com/path",
+			wantSessionFor: "oidc-user",
+		},
+		{
+			name:           "✅ redirect_to in other domain is ignored",
+			subject:        "oidc-user",
+			redirectTo:     "https://evil.example.org/",
+			wantStatus:     http.StatusFound,
+			wantLocation:   "/",
+			wantSessionFor: "oidc-user",
+		},
+		{
+			name:           "✅ user is created just in time",
+			subject:        "new-user",
+			groups:         []string{"teama-admins"},
+			jit:            true,
+			roleMapper:     roleMapper,
+			wantStatus:     http.StatusFound,
+			wantLocation:   "/",
+			wantSessionFor: "new-user",
+			wantRoles:      []cosmov1alpha1.UserRole{{Name: "teama-admin"}},
+		},
+		{
+			name:           "✅ roles are synced from groups claim",
+			subject:        "oidc-user",
+			groups:         []string{"teama-admins", "others"},
+			roleMapper:     roleMapper,
+			wantStatus:     http.StatusFound,
+			wantLocation:   "/",
+			wantSessionFor: "oidc-user",
+			wantRoles:      []cosmov1alpha1.UserRole{{Name: "teama-admin"}},
+		},
+		{
+			name:       "❌ user not found without jit user creation",
+			subject:    "new-user",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "❌ auth type is not oidc",
+			subject:    "password-user",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "❌ user not found",
+			subject:    "not-found",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:          "❌ nonce mismatch",
+			subject:       "oidc-user",
+			overrideNonce: "invalid-nonce",
+			wantStatus:    http.StatusForbidden,
+		},
+		{
+			name:        "❌ state mismatch",
+			subject:     "oidc-user",
+			tamperState: true,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:       "❌ code is not issued",
+			subject:    "oidc-user",
+			tamperCode: true,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iss := newTestIssuer(t)
+			iss.subject = tt.subject
+			iss.groups = tt.groups
+			iss.overrideNonce = tt.overrideNonce
+			s, h := newTestOIDCServer(t, iss, oidcUser, passwordUser)
+			s.JITUserCreation = tt.jit
+			s.RoleMapper = tt.roleMapper
+
+			// start login
+			loginRes := serve(h, oidcLoginPath+"?"+url.Values{oidcQueryParamRedirectTo: {tt.redirectTo}}.Encode(), nil)
+			if loginRes.StatusCode != http.StatusFound {
+				t.Fatalf("login status = %d, want %d", loginRes.StatusCode, http.StatusFound)
+			}
+			authURL, _ := loginRes.Location()
+
+			// authorize at the issuer
+			noRedirect := iss.Client()
+			noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+			authRes, err := noRedirect.Get(authURL.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if authRes.StatusCode != http.StatusFound {
+				t.Fatalf("authorize status = %d, want %d", authRes.StatusCode, http.StatusFound)
+			}
+			callbackURL, _ := authRes.Location()
+			q := callbackURL.Query()
+			if tt.tamperState {
+				q.Set("state", "invalid-state")
+			}
+			if tt.tamperCode {
+				q.Set("code", "invalid-code")
+			}
+
+			// callback
+			res := serve(h, oidcCallbackPath+"?"+q.Encode(), loginRes.Cookies())
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("callback status = %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusFound {
+				return
+			}
+			if loc := res.Header.Get("Location"); loc != tt.wantLocation {
+				t.Errorf("callback location = %s, want %s", loc, tt.wantLocation)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for _, c := range res.Cookies() {
+				req.AddCookie(c)
+			}
+			ses, err := s.sessionStore.Get(req, s.CookieSessionName)
+			if err != nil || ses.IsNew {
+				t.Fatalf("session is not created: %v", err)
+			}
+			if got := session.Get(ses).UserName; got != tt.wantSessionFor {
+				t.Errorf("session username = %s, want %s", got, tt.wantSessionFor)
+			}
+
+			user, err := s.Klient.GetUser(context.TODO(), tt.wantSessionFor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if user.Spec.AuthType != cosmov1alpha1.UserAuthTypeOIDC {
+				t.Errorf("user auth type = %s, want %s", user.Spec.AuthType, cosmov1alpha1.UserAuthTypeOIDC)
+			}
+			if diff := cmp.Diff(tt.wantRoles, user.Spec.Roles); diff != "" {
+				t.Errorf("user roles mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServer_OIDCCallbackWithoutLogin(t *testing.T) {
+	iss := newTestIssuer(t)
+	_, h := newTestOIDCServer(t, iss)
+
+	res := serve(h, oidcCallbackPath+"?code=xxx&state=yyy", nil)
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("callback status = %d, want %d", res.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestServer_isValidRedirectURL(t *testing.T) {
+	s := &Server{CookieDomain: "example.com"}
+	tests := []struct {
+		name string
+		url  string
+		want bool
+	}{
+		{name: "✅ path", url: "/#/workspace", want: true},
+		{name: "✅ cookie domain", url: "https://example.com/", want: true},
+		{name: "✅ subdomain of cookie domain", url: "https://ws.example.com/path", want: true},
+		{name: "❌ empty", url: "", want: false},
+		{name: "❌ protocol relative url", url: "//evil.com/", want: false},
+		{name: "❌ other domain", url: "https://evil.com/", want: false},
+		{name: "❌ suffix match without dot", url: "https://evilexample.com/", want: false},
+		{name: "❌ javascript scheme", url: "javascript:alert(1)", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.isValidRedirectURL(tt.url); got != tt.want {
+				t.Errorf("isValidRedirectURL(%s) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
@@ -77,6 +77,22 @@ type options struct {
 	LdapSearchBindPassword  string
 	LdapSearchBaseDN        string
 	LdapSearchFilter        string
+	OIDCIssuerURL           string
+	OIDCClientID            string
+	OIDCClientSecret        string
+	OIDCRedirectURL         string
+	OIDCScopes              []string
+	OIDCUsernameClaim       string
+	OIDCGroupsClaim         string
+	LdapGroupAttr           string
+	LdapGroupSearchBaseDN   string
+	LdapGroupSearchFilter   string
+	RoleMappingFile         string
+	JITUserCreation         bool
+	WebhookAuthURL          string
+	WebhookAuthCACertPath   string
+	WebhookAuthTimeoutSec   int
+	WebhookAuthNegCacheSec  int
 }
 
 func NewRootCmd(o *options) *cobra.Command {
@@ -125,6 +141,22 @@ MIT 2023 cosmo-workspace/cosmo
 	rootCmd.PersistentFlags().StringVar(&o.LdapSearchBindPassword, "ldap-search-password", "", "[search mode] password for search bindDN.")
 	rootCmd.PersistentFlags().StringVar(&o.LdapSearchBaseDN, "ldap-search-basedn", "", "[search mode] ex: dc=example,dc=com")
 	rootCmd.PersistentFlags().StringVar(&o.LdapSearchFilter, "ldap-search-filter", "", "[search mode] ex: (uid=%s)  '%s' is replaced by the userid.")
+	rootCmd.PersistentFlags().StringVar(&o.LdapGroupAttr, "ldap-group-attr", "", "Attribute of the user entry which has the group DNs. ex: memberOf")
+	rootCmd.PersistentFlags().StringVar(&o.LdapGroupSearchBaseDN, "ldap-group-search-basedn", "", "Base DN to search the groups of the user. ex: ou=groups,dc=example,dc=com")
+	rootCmd.PersistentFlags().StringVar(&o.LdapGroupSearchFilter, "ldap-group-search-filter", "", "Filter to search the groups of the user. ex: (member=%s)  '%s' is replaced by the user DN.")
+	rootCmd.PersistentFlags().StringVar(&o.WebhookAuthURL, "webhook-auth-url", "", "HTTPS URL of the authentication webhook. webhook auth type is enabled if specified")
+	rootCmd.PersistentFlags().StringVar(&o.WebhookAuthCACertPath, "webhook-auth-ca-cert", "", "CA cert file path to verify the authentication webhook")
+	rootCmd.PersistentFlags().IntVar(&o.WebhookAuthTimeoutSec, "webhook-auth-timeout-seconds", 5, "Timeout seconds for the authentication webhook")
+	rootCmd.PersistentFlags().IntVar(&o.WebhookAuthNegCacheSec, "webhook-auth-negative-cache-seconds", 30, "Seconds to cache the failed authentication by the webhook. 0 disables the cache")
+	rootCmd.PersistentFlags().StringVar(&o.OIDCIssuerURL, "oidc-issuer-url", "", "OpenID Connect issuer URL. OIDC login is enabled if specified")
+	rootCmd.PersistentFlags().StringVar(&o.OIDCClientID, "oidc-client-id", "", "OpenID Connect client ID")
+	rootCmd.PersistentFlags().StringVar(&o.OIDCClientSecret, "oidc-client-secret", "", "OpenID Connect client secret")
+	rootCmd.PersistentFlags().StringVar(&o.OIDCRedirectURL, "oidc-redirect-url", "", "OpenID Connect redirect URL. default is /oidc/callback on the host of signin-url")
+	rootCmd.PersistentFlags().StringSliceVar(&o.OIDCScopes, "oidc-scopes", []string{"openid", "profile", "email"}, "OpenID Connect scopes")
+	rootCmd.PersistentFlags().StringVar(&o.OIDCUsernameClaim, "oidc-username-claim", "preferred_username", "ID token claim used as the COSMO user name")
+	rootCmd.PersistentFlags().StringVar(&o.OIDCGroupsClaim, "oidc-groups-claim", "groups", "ID token claim used as the groups for role mapping")
+	rootCmd.PersistentFlags().StringVar(&o.RoleMappingFile, "role-mapping-file", "", "File path of the mapping from LDAP groups or OIDC groups claim to UserRoles. Roles are synced on each login if specified")
+	rootCmd.PersistentFlags().BoolVar(&o.JITUserCreation, "jit-user-creation", false, "Create the user on the first login by LDAP, webhook or OIDC")
 
 	return rootCmd
 }
@@ -141,6 +173,9 @@ func (o *options) PreRunE(cmd *cobra.Command, args []string) error {
 		cmd.MarkPersistentFlagRequired("ldap-user-attr")
 		cmd.MarkPersistentFlagRequired("ldap-basedn")
 	}
+	if o.OIDCIssuerURL != "" {
+		cmd.MarkPersistentFlagRequired("oidc-client-id")
+	}
 	return nil
 }
 
@@ -158,13 +193,81 @@ func (o *options) Validate(cmd *cobra.Command, args []string) error {
 			return err
 		}
 	}
+	if o.WebhookAuthURL != "" {
+		u, err := url.Parse(o.WebhookAuthURL)
+		if err != nil {
+			return fmt.Errorf("invalid webhook-auth-url: %w", err)
+		}
+		if u.Scheme != "https" {
+			return fmt.Errorf("webhook-auth-url must be https")
+		}
+		if o.WebhookAuthTimeoutSec <= 0 {
+			return fmt.Errorf("%s must be positive", "webhook-auth-timeout-seconds")
+		}
+	}
+	if o.OIDCIssuerURL != "" {
+		if _, err := url.Parse(o.OIDCIssuerURL); err != nil {
+			return fmt.Errorf("invalid oidc-issuer-url: %w", err)
+		}
+		if o.OIDCClientID == "" {
+			return fmt.Errorf("%s is required when oidc-issuer-url is specified", "oidc-client-id")
+		}
+		if o.OIDCRedirectURL == "" && o.SigninURL == "" {
+			return fmt.Errorf("either %s or %s is required when oidc-issuer-url is specified", "oidc-redirect-url", "signin-url")
+		}
+	}
 	return nil
 }
 
 func (o *options) Complete(cmd *cobra.Command, args []string) error {
+	if o.OIDCIssuerURL != "" && o.OIDCRedirectURL == "" {
+		u, err := url.Parse(o.SigninURL)
+		if err != nil {
+			return fmt.Errorf("failed to parse signin-url: %w", err)
+		}
+		o.OIDCRedirectURL = fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, oidcCallbackPath)
+	}
 	return nil
 }
 
+func (o *options) newWebhookAuthorizer() (*auth.WebhookAuthorizer, error) {
+	tlsConfig := &tls.Config{}
+	if o.WebhookAuthCACertPath != "" {
+		caCert, err := os.ReadFile(o.WebhookAuthCACertPath)
+		if err != nil {
+			setupLog.Error(err, "failed to read CA cert file")
+			return nil, err
+		}
+		certPool, err := x509.SystemCertPool()
+		if err != nil {
+			certPool = x509.NewCertPool()
+		}
+		certPool.AppendCertsFromPEM(caCert)
+		tlsConfig.RootCAs = certPool
+	}
+	return &auth.WebhookAuthorizer{
+		URL:              o.WebhookAuthURL,
+		TlsConfig:        tlsConfig,
+		Timeout:          time.Second * time.Duration(o.WebhookAuthTimeoutSec),
+		NegativeCacheTTL: time.Second * time.Duration(o.WebhookAuthNegCacheSec),
+	}, nil
+}
+
+func (o *options) newOIDCConfig() *OIDCConfig {
+	if o.OIDCIssuerURL == "" {
+		return nil
+	}
+	return &OIDCConfig{
+		IssuerURL:     o.OIDCIssuerURL,
+		ClientID:      o.OIDCClientID,
+		ClientSecret:  o.OIDCClientSecret,
+		RedirectURL:   o.OIDCRedirectURL,
+		Scopes:        o.OIDCScopes,
+		UsernameClaim: o.OIDCUsernameClaim,
+		GroupsClaim:   o.OIDCGroupsClaim,
+	}
+}
+
 func (o *options) newLdapAuthorizer() (*auth.LdapAuthorizer, error) {
 	u, _ := url.Parse(o.LdapURL)
 	tlsConfig := &tls.Config{
@@ -193,6 +296,9 @@ func (o *options) newLdapAuthorizer() (*auth.LdapAuthorizer, error) {
 		SearchBindPassword: o.LdapSearchBindPassword,
 		SearchBaseDN:       o.LdapSearchBaseDN,
 		SearchFilter:       o.LdapSearchFilter,
+		GroupAttribute:     o.LdapGroupAttr,
+		GroupSearchBaseDN:  o.LdapGroupSearchBaseDN,
+		GroupSearchFilter:  o.LdapGroupSearchFilter,
 	}
 
 	return authorizer, nil
@@ -237,6 +343,21 @@ func (o *options) RunE(cmd *cobra.Command, args []string) error {
 			return err
 		}
 	}
+	if o.WebhookAuthURL != "" {
+		auths[cosmov1alpha1.UserAuthTypeWebhook], err = o.newWebhookAuthorizer()
+		if err != nil {
+			return err
+		}
+	}
+
+	var roleMapper *auth.RoleMapper
+	if o.RoleMappingFile != "" {
+		roleMapper, err = auth.LoadRoleMapper(o.RoleMappingFile)
+		if err != nil {
+			setupLog.Error(err, "failed to load role mapping")
+			return err
+		}
+	}
 
 	u, err := url.Parse(o.SigninURL)
 	if err != nil {
@@ -280,6 +401,9 @@ func (o *options) RunE(cmd *cobra.Command, args []string) error {
 		TLSCertPath:         o.TLSCertPath,
 		Insecure:            o.Insecure,
 		Authorizers:         auths,
+		OIDC:                o.newOIDCConfig(),
+		RoleMapper:          roleMapper,
+		JITUserCreation:     o.JITUserCreation,
 		http:                &http.Server{Addr: fmt.Sprintf(":%d", o.ServerPort)},
 		sessionStore:        nil,
 		webauthn:            wa,
@@ -51,6 +51,25 @@ func TestNewRootCmd(t *testing.T) {
 				"--insecure",
 			},
 		},
+		{
+			name: "❌ webhook-auth-url must be https",
+			args: []string{
+				"--cookie-hashkey=1234567890123456",
+				"--cookie-blockkey=1234567890123456",
+				"--webhook-auth-url=http://auth.example.com/review",
+				"--insecure",
+			},
+		},
+		{
+			name: "❌ oidc redirect url cannot be completed",
+			args: []string{
+				"--cookie-hashkey=1234567890123456",
+				"--cookie-blockkey=1234567890123456",
+				"--oidc-issuer-url=https://issuer.example.com",
+				"--oidc-client-id=cosmo-dashboard",
+				"--insecure",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -37,6 +37,12 @@ type Server struct {
 	CookieSessionName string
 
 	Authorizers map[cosmov1alpha1.UserAuthType]auth.Authorizer
+	OIDC        *OIDCConfig
+
+	// RoleMapper syncs the roles mapped from the external groups on login if not nil
+	RoleMapper *auth.RoleMapper
+	// JITUserCreation enables to create the user on the first login by LDAP, webhook or OIDC
+	JITUserCreation bool
 
 	http         *http.Server
 	sessionStore sessions.Store
@@ -59,6 +65,12 @@ func (s *Server) setupRouter() {
 	s.WorkspaceServiceHandler(mux)
 	s.StreamServiceHandler(mux)
 
+	// setup OpenID Connect login
+	s.OIDCHandler(mux)
+
+	// setup auth endpoints for the gateway-api and ingress routing providers
+	s.WorkspaceAuthHandler(mux)
+
 	// setup serving static files
 	mux.Handle("/", http.StripPrefix("/", http.FileServer(http.Dir(s.StaticFileDir))))
 
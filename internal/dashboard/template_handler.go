@@ -2,9 +2,15 @@ package dashboard
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	connect_go "github.com/bufbuild/connect-go"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 
 	"github.com/cosmo-workspace/cosmo/pkg/apiconv"
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
@@ -70,3 +76,34 @@ func (s *Server) GetUserAddonTemplates(ctx context.Context, req *connect_go.Requ
 
 	return connect_go.NewResponse(res), nil
 }
+
+func (s *Server) DiffWorkspaceTemplate(ctx context.Context, req *connect_go.Request[dashv1alpha1.DiffWorkspaceTemplateRequest]) (*connect_go.Response[dashv1alpha1.DiffWorkspaceTemplateResponse], error) {
+	log := clog.FromContext(ctx).WithCaller()
+
+	if err := adminAuthentication(ctx, validateCallerHasAdmin); err != nil {
+		return nil, ErrResponse(log, err)
+	}
+
+	var tmpl cosmov1alpha1.Template
+	if err := yaml.Unmarshal([]byte(req.Msg.Raw), &tmpl); err != nil {
+		return nil, ErrResponse(log, apierrs.NewBadRequest(fmt.Sprintf("failed to unmarshal template: %v", err)))
+	}
+	if tmpl.Name == "" {
+		return nil, ErrResponse(log, apierrs.NewBadRequest("template name is required"))
+	}
+
+	diffs, err := s.Klient.DiffTemplate(ctx, &tmpl, ptr.Deref(req.Msg.Domain, ""))
+	if err != nil {
+		return nil, ErrResponse(log, err)
+	}
+
+	res := &dashv1alpha1.DiffWorkspaceTemplateResponse{
+		Items: apiconv.C2D_InstanceDiffs(diffs),
+	}
+
+	if len(res.Items) == 0 {
+		res.Message = "No workspaces found"
+	}
+
+	return connect_go.NewResponse(res), nil
+}
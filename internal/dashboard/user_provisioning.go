@@ -0,0 +1,69 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+)
+
+// provisionUser creates the user just in time if user is nil, and syncs the roles mapped from the external groups.
+// The roles are not synced if the groups are not resolved, not to remove the roles synced before.
+// Failures in syncing roles are only logged not to block the login.
+func (s *Server) provisionUser(ctx context.Context, user *cosmov1alpha1.User, userName string, authType cosmov1alpha1.UserAuthType, groups []string, groupsResolved bool) (*cosmov1alpha1.User, error) {
+	log := clog.FromContext(ctx).WithCaller()
+
+	if user == nil {
+		if !s.JITUserCreation {
+			return nil, fmt.Errorf("user not found")
+		}
+		log.Info("creating user just in time", "username", userName, "authType", authType)
+
+		// default user addons are appended by the user webhook
+		created, err := s.Klient.CreateUser(ctx, userName, "", nil, authType.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+		user = created
+	}
+
+	if s.RoleMapper == nil || !groupsResolved {
+		return user, nil
+	}
+	synced, err := s.Klient.SyncUserRoles(ctx, userName, s.RoleMapper.Roles(groups))
+	if err != nil {
+		log.Error(err, "failed to sync user roles", "username", userName, "groups", groups)
+		return user, nil
+	}
+	return synced, nil
+}
+
+// jitPasswordAuthType returns the auth type of the user created just in time on the password login.
+// LDAP takes precedence over webhook if both are enabled.
+func (s *Server) jitPasswordAuthType() cosmov1alpha1.UserAuthType {
+	if _, ok := s.Authorizers[cosmov1alpha1.UserAuthTypeWebhook]; ok {
+		if _, ok := s.Authorizers[cosmov1alpha1.UserAuthTypeLDAP]; !ok {
+			return cosmov1alpha1.UserAuthTypeWebhook
+		}
+	}
+	return cosmov1alpha1.UserAuthTypeLDAP
+}
+
+// claimValues returns the string values of the claim which is a string or an array of strings
+func claimValues(claims map[string]interface{}, key string) []string {
+	switch v := claims[key].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,71 @@
+package dashboard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/auth"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
+)
+
+func TestServer_provisionUser(t *testing.T) {
+	roleMapper, err := auth.NewRoleMapper([]auth.RoleMapping{
+		{Group: "cn=admins", Roles: []string{"cosmo-admin"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		groups         []string
+		groupsResolved bool
+		wantRoles      []cosmov1alpha1.UserRole
+	}{
+		{
+			name:           "✅ roles are synced with the groups",
+			groups:         []string{"cn=users"},
+			groupsResolved: true,
+			wantRoles:      []cosmov1alpha1.UserRole{{Name: "team-a"}},
+		},
+		{
+			name:           "✅ roles are kept if the groups are not resolved",
+			groupsResolved: false,
+			wantRoles:      []cosmov1alpha1.UserRole{{Name: "cosmo-admin"}, {Name: "team-a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &cosmov1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "tom",
+					Annotations: map[string]string{cosmov1alpha1.UserAnnKeySyncedRoles: "cosmo-admin"},
+				},
+				Spec: cosmov1alpha1.UserSpec{
+					AuthType: cosmov1alpha1.UserAuthTypeLDAP,
+					Roles:    []cosmov1alpha1.UserRole{{Name: "cosmo-admin"}, {Name: "team-a"}},
+				},
+			}
+			s := &Server{
+				Log:        clog.NewLogger(logr.Discard()),
+				Klient:     kosmo.NewClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(user).Build()),
+				RoleMapper: roleMapper,
+			}
+
+			got, err := s.provisionUser(context.TODO(), user.DeepCopy(), "tom", cosmov1alpha1.UserAuthTypeLDAP, tt.groups, tt.groupsResolved)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantRoles, got.Spec.Roles); diff != "" {
+				t.Errorf("provisionUser() roles mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
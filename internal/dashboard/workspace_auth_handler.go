@@ -0,0 +1,93 @@
+package dashboard
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/auth/session"
+	"github.com/cosmo-workspace/cosmo/pkg/workspace"
+)
+
+const (
+	// workspaceAuthGatewayPath is the auth endpoint for ext-auth of Gateway API implementations.
+	// The path of the original request is appended to the path, and the host is the original host.
+	workspaceAuthGatewayPath = "/auth/workspace"
+	// workspaceAuthIngressPath is the auth endpoint for auth-url of ingress-nginx.
+	// The original URL is passed in the X-Original-URL header.
+	workspaceAuthIngressPath = "/auth/ingress"
+
+	headerOriginalURL = "X-Original-URL"
+	headerUserName    = "X-Cosmo-UserName"
+)
+
+// WorkspaceAuthHandler serves the endpoints to authenticate the requests to the private network rules of workspaces
+// routed by the gateway-api or ingress routing provider, the same as the cosmo-auth Traefik plugin.
+// The allowed users are not taken from the request headers but from the network rule found by the original URL,
+// so the headers supplied by the clients are never trusted.
+func (s *Server) WorkspaceAuthHandler(mux *http.ServeMux) {
+	mux.Handle(workspaceAuthGatewayPath, s.timeoutHandler(http.HandlerFunc(s.WorkspaceAuthGateway)))
+	mux.Handle(workspaceAuthGatewayPath+"/", s.timeoutHandler(http.HandlerFunc(s.WorkspaceAuthGateway)))
+	mux.Handle(workspaceAuthIngressPath, s.timeoutHandler(http.HandlerFunc(s.WorkspaceAuthIngress)))
+}
+
+// WorkspaceAuthGateway authenticates the request forwarded by ext-auth of Gateway API implementations
+func (s *Server) WorkspaceAuthGateway(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, workspaceAuthGatewayPath)
+	s.authorizeWorkspaceAccess(w, r, r.Host, path)
+}
+
+// WorkspaceAuthIngress authenticates the request forwarded by auth-url of ingress-nginx
+func (s *Server) WorkspaceAuthIngress(w http.ResponseWriter, r *http.Request) {
+	original, err := url.Parse(r.Header.Get(headerOriginalURL))
+	if err != nil || original.Host == "" {
+		s.Log.Info("invalid original URL", "url", r.Header.Get(headerOriginalURL))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.authorizeWorkspaceAccess(w, r, original.Host, original.Path)
+}
+
+func (s *Server) authorizeWorkspaceAccess(w http.ResponseWriter, r *http.Request, host, path string) {
+	log := s.Log.WithName("workspace-auth").WithValues("host", host, "path", path)
+	ctx := r.Context()
+
+	ses, err := s.sessionStore.Get(r, s.CookieSessionName)
+	if err != nil || ses == nil || ses.IsNew {
+		log.Debug().Info("no session")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	sesInfo := session.Get(ses)
+	if sesInfo.UserName == "" || (sesInfo.Deadline > 0 && time.Unix(sesInfo.Deadline, 0).Before(time.Now())) {
+		log.Debug().Info("session expired", "userName", sesInfo.UserName)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	log = log.WithValues("userName", sesInfo.UserName)
+
+	var wsList cosmov1alpha1.WorkspaceList
+	if err := s.Klient.List(ctx, &wsList); err != nil {
+		log.Error(err, "failed to list workspaces")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	ws, netRule := workspace.FindNetworkRuleByURL(wsList.Items, host, path)
+	if ws == nil {
+		log.Info("network rule not found")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if !netRule.Public && !slices.Contains(workspace.AllowedUsers(*netRule, *ws), sesInfo.UserName) {
+		log.Info("access is denied", "workspace", ws.Name, "namespace", ws.Namespace)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	log.Debug().Info("access is allowed", "workspace", ws.Name, "namespace", ws.Namespace)
+	w.Header().Set(headerUserName, sesInfo.UserName)
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,161 @@
+package dashboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/auth/session"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
+)
+
+func TestServer_WorkspaceAuthHandler(t *testing.T) {
+	private := cosmov1alpha1.NetworkRule{Protocol: "http", PortNumber: 8080, CustomHostPrefix: "main", AllowedUsers: []string{"jerry"}}
+	public := cosmov1alpha1.NetworkRule{Protocol: "http", PortNumber: 3000, HTTPPath: "/public", CustomHostPrefix: "main", Public: true}
+	ws := &cosmov1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: cosmov1alpha1.UserNamespace("tom")},
+		Spec:       cosmov1alpha1.WorkspaceSpec{Network: []cosmov1alpha1.NetworkRule{private, public}},
+		Status: cosmov1alpha1.WorkspaceStatus{
+			URLs: map[string]string{
+				private.UniqueKey(): "https://main-ws1-tom.example.com",
+				public.UniqueKey():  "https://main-ws1-tom.example.com/public",
+			},
+		},
+	}
+
+	s := &Server{
+		Log:               clog.NewLogger(logr.Discard()),
+		Klient:            kosmo.NewClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(ws).Build()),
+		ResponseTimeout:   5 * time.Second,
+		MaxAgeSeconds:     60,
+		CookieHashKey:     "----+----1----+----2----+----3----+----4----+----5----+----6----",
+		CookieBlockKey:    "----+----1----+----2----+----3--",
+		CookieSessionName: "test-server",
+	}
+	s.setupSessionStore()
+	mux := http.NewServeMux()
+	s.WorkspaceAuthHandler(mux)
+
+	sessionCookies := func(userName string, deadline time.Time) []*http.Cookie {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		if err := s.CreateSession(rec, httptest.NewRequest(http.MethodGet, "/", nil), session.Info{UserName: userName, Deadline: deadline.Unix()}); err != nil {
+			t.Fatal(err)
+		}
+		return rec.Result().Cookies()
+	}
+	valid := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		target       string
+		host         string
+		originalURL  string
+		headers      map[string]string
+		cookies      []*http.Cookie
+		wantStatus   int
+		wantUserName string
+	}{
+		{
+			name:         "✅ owner by gateway",
+			target:       workspaceAuthGatewayPath + "/index.html",
+			host:         "main-ws1-tom.example.com",
+			cookies:      sessionCookies("tom", valid),
+			wantStatus:   http.StatusOK,
+			wantUserName: "tom",
+		},
+		{
+			name:         "✅ shared user by ingress",
+			target:       workspaceAuthIngressPath,
+			originalURL:  "https://main-ws1-tom.example.com/index.html",
+			cookies:      sessionCookies("jerry", valid),
+			wantStatus:   http.StatusOK,
+			wantUserName: "jerry",
+		},
+		{
+			name:         "✅ public network rule",
+			target:       workspaceAuthGatewayPath + "/public/index.html",
+			host:         "main-ws1-tom.example.com",
+			cookies:      sessionCookies("alice", valid),
+			wantStatus:   http.StatusOK,
+			wantUserName: "alice",
+		},
+		{
+			name:       "❌ not allowed user",
+			target:     workspaceAuthGatewayPath + "/",
+			host:       "main-ws1-tom.example.com",
+			cookies:    sessionCookies("alice", valid),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "❌ user name headers supplied by the client are ignored",
+			target: workspaceAuthGatewayPath + "/",
+			host:   "main-ws1-tom.example.com",
+			headers: map[string]string{
+				"X-Cosmo-UserName":       "alice",
+				"X-Cosmo-UserName-alice": "1",
+			},
+			cookies:    sessionCookies("alice", valid),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "❌ unknown host",
+			target:     workspaceAuthGatewayPath + "/",
+			host:       "main-ws2-tom.example.com",
+			cookies:    sessionCookies("tom", valid),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "❌ no session",
+			target:     workspaceAuthGatewayPath + "/",
+			host:       "main-ws1-tom.example.com",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "❌ session expired",
+			target:     workspaceAuthGatewayPath + "/",
+			host:       "main-ws1-tom.example.com",
+			cookies:    sessionCookies("tom", time.Now().Add(-time.Minute)),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "❌ no original URL by ingress",
+			target:     workspaceAuthIngressPath,
+			cookies:    sessionCookies("tom", valid),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			if tt.originalURL != "" {
+				req.Header.Set(headerOriginalURL, tt.originalURL)
+			}
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			for _, c := range tt.cookies {
+				req.AddCookie(c)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get(headerUserName); got != tt.wantUserName {
+				t.Errorf("%s = %s, want %s", headerUserName, got, tt.wantUserName)
+			}
+		})
+	}
+}
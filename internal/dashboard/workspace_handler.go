@@ -187,6 +187,7 @@ func (s *Server) UpdateWorkspace(ctx context.Context, req *connect_go.Request[da
 		Replicas:     req.Msg.Replicas,
 		Vars:         req.Msg.Vars,
 		DeletePolicy: delPolicy,
+		Schedule:     apiconv.D2C_WorkspaceSchedule(req.Msg.Schedule),
 	})
 	if err != nil {
 		return nil, ErrResponse(log, err)
@@ -200,6 +201,43 @@ func (s *Server) UpdateWorkspace(ctx context.Context, req *connect_go.Request[da
 	return connect_go.NewResponse(res), nil
 }
 
+func (s *Server) CloneWorkspace(ctx context.Context, req *connect_go.Request[dashv1alpha1.CloneWorkspaceRequest]) (*connect_go.Response[dashv1alpha1.CloneWorkspaceResponse], error) {
+	log := clog.FromContext(ctx).WithCaller()
+	log.Debug().Info("request", "req", req)
+
+	dstUserName := req.Msg.UserName
+	if req.Msg.DstUserName != nil && *req.Msg.DstUserName != "" {
+		dstUserName = *req.Msg.DstUserName
+	}
+
+	// caller must be the owner or the admin of both source and destination users
+	for _, userName := range slices.Compact([]string{req.Msg.UserName, dstUserName}) {
+		if err := userAuthentication(ctx, userName); err != nil {
+			targetUser, err := s.Klient.GetUser(ctx, userName)
+			if err != nil {
+				return nil, ErrResponse(log, err)
+			}
+
+			// group-admin user can clone workspaces of users which have only the their groups
+			if err := adminAuthentication(ctx, validateCallerHasAdminForAtLeastOneRole(targetUser.Spec.Roles)); err != nil {
+				return nil, ErrResponse(log, err)
+			}
+		}
+	}
+
+	ws, err := s.Klient.CloneWorkspace(ctx, req.Msg.UserName, req.Msg.WsName, dstUserName, req.Msg.DstWsName)
+	if err != nil {
+		return nil, ErrResponse(log, err)
+	}
+
+	res := &dashv1alpha1.CloneWorkspaceResponse{
+		Message:   "Successfully cloned",
+		Workspace: apiconv.C2D_Workspace(*ws),
+	}
+	log.Info(res.Message, "username", req.Msg.UserName, "workspaceName", req.Msg.WsName, "dstUsername", dstUserName, "dstWorkspaceName", req.Msg.DstWsName)
+	return connect_go.NewResponse(res), nil
+}
+
 func (s *Server) sharedWorkspaceAuthorization(ctx context.Context, wsName, wsOwnerName string, update bool) error {
 	log := clog.FromContext(ctx).WithCaller()
 
@@ -0,0 +1,90 @@
+package dashboard
+
+import (
+	"context"
+
+	connect_go "github.com/bufbuild/connect-go"
+
+	"github.com/cosmo-workspace/cosmo/pkg/apiconv"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	dashv1alpha1 "github.com/cosmo-workspace/cosmo/proto/gen/dashboard/v1alpha1"
+)
+
+func (s *Server) snapshotAuthorization(ctx context.Context, userName string) error {
+	if err := userAuthentication(ctx, userName); err != nil {
+		targetUser, err := s.Klient.GetUser(ctx, userName)
+		if err != nil {
+			return err
+		}
+
+		// group-admin user can manipulate snapshots of users which have only the their groups
+		if err := adminAuthentication(ctx, validateCallerHasAdminForAtLeastOneRole(targetUser.Spec.Roles)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) CreateWorkspaceSnapshot(ctx context.Context, req *connect_go.Request[dashv1alpha1.CreateWorkspaceSnapshotRequest]) (*connect_go.Response[dashv1alpha1.CreateWorkspaceSnapshotResponse], error) {
+	log := clog.FromContext(ctx).WithCaller()
+	log.Debug().Info("request", "req", req)
+
+	if err := s.snapshotAuthorization(ctx, req.Msg.UserName); err != nil {
+		return nil, ErrResponse(log, err)
+	}
+
+	snap, err := s.Klient.CreateWorkspaceSnapshot(ctx, req.Msg.UserName, req.Msg.WsName, req.Msg.SnapshotName, req.Msg.VolumeSnapshotClassName)
+	if err != nil {
+		return nil, ErrResponse(log, err)
+	}
+
+	res := &dashv1alpha1.CreateWorkspaceSnapshotResponse{
+		Message:  "Successfully created",
+		Snapshot: apiconv.C2D_WorkspaceSnapshot(*snap),
+	}
+	log.Info(res.Message, "username", req.Msg.UserName, "workspaceName", req.Msg.WsName, "snapshotName", req.Msg.SnapshotName)
+	return connect_go.NewResponse(res), nil
+}
+
+func (s *Server) GetWorkspaceSnapshots(ctx context.Context, req *connect_go.Request[dashv1alpha1.GetWorkspaceSnapshotsRequest]) (*connect_go.Response[dashv1alpha1.GetWorkspaceSnapshotsResponse], error) {
+	log := clog.FromContext(ctx).WithCaller()
+	log.Debug().Info("request", "req", req)
+
+	if err := s.snapshotAuthorization(ctx, req.Msg.UserName); err != nil {
+		return nil, ErrResponse(log, err)
+	}
+
+	snaps, err := s.Klient.ListWorkspaceSnapshots(ctx, req.Msg.UserName, req.Msg.WsName)
+	if err != nil {
+		return nil, ErrResponse(log, err)
+	}
+
+	res := &dashv1alpha1.GetWorkspaceSnapshotsResponse{
+		Items: apiconv.C2D_WorkspaceSnapshots(snaps),
+	}
+	if len(res.Items) == 0 {
+		res.Message = "No items found"
+	}
+	return connect_go.NewResponse(res), nil
+}
+
+func (s *Server) RestoreWorkspaceSnapshot(ctx context.Context, req *connect_go.Request[dashv1alpha1.RestoreWorkspaceSnapshotRequest]) (*connect_go.Response[dashv1alpha1.RestoreWorkspaceSnapshotResponse], error) {
+	log := clog.FromContext(ctx).WithCaller()
+	log.Debug().Info("request", "req", req)
+
+	if err := s.snapshotAuthorization(ctx, req.Msg.UserName); err != nil {
+		return nil, ErrResponse(log, err)
+	}
+
+	snap, err := s.Klient.RestoreWorkspaceSnapshot(ctx, req.Msg.SnapshotName, req.Msg.UserName)
+	if err != nil {
+		return nil, ErrResponse(log, err)
+	}
+
+	res := &dashv1alpha1.RestoreWorkspaceSnapshotResponse{
+		Message:  "Successfully requested to restore",
+		Snapshot: apiconv.C2D_WorkspaceSnapshot(*snap),
+	}
+	log.Info(res.Message, "username", req.Msg.UserName, "snapshotName", req.Msg.SnapshotName)
+	return connect_go.NewResponse(res), nil
+}
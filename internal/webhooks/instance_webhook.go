@@ -165,6 +165,10 @@ func (h *InstanceValidationWebhookHandler) Handle(ctx context.Context, req admis
 
 	// validate if satisfy template's required vars
 	for _, v := range tmpl.GetSpec().RequiredVars {
+		// secret vars are resolved from the Secret on build
+		if v.Secret {
+			continue
+		}
 		ok := false
 		for key := range inst.GetSpec().Vars {
 			if template.FixupTemplateVarKey(key) == template.FixupTemplateVarKey(v.Var) {
@@ -203,7 +207,11 @@ func (h *InstanceValidationWebhookHandler) Handle(ctx context.Context, req admis
 func dryrunReconcile(ctx context.Context, c client.Client, fieldManager string, inst cosmov1alpha1.InstanceObject, tmpl cosmov1alpha1.TemplateObject) []error {
 	log := clog.FromContext(ctx).WithCaller()
 
-	objects, err := template.BuildObjects(*tmpl.GetSpec(), inst, "dummy.example.com")
+	secretVars, err := getSecretVarsForDryrun(ctx, c, inst, *tmpl.GetSpec())
+	if err != nil {
+		return []error{err}
+	}
+	objects, err := template.BuildObjects(tmpl, inst, "dummy.example.com", secretVars)
 	if err != nil {
 		return []error{err}
 	}
@@ -230,3 +238,13 @@ func dryrunReconcile(ctx context.Context, c client.Client, fieldManager string,
 	}
 	return errs
 }
+
+// getSecretVarsForDryrun returns the secret vars of the instance to build the objects in the same way as the controllers.
+// The default values are used if the Secret is not found, as the Secret is created after the workspace.
+func getSecretVarsForDryrun(ctx context.Context, c client.Client, inst cosmov1alpha1.InstanceObject, tmplSpec cosmov1alpha1.TemplateSpec) (map[string]string, error) {
+	secretVars, err := template.GetSecretVars(ctx, c, inst, tmplSpec)
+	if apierrs.IsNotFound(err) {
+		return template.GetSecretVars(ctx, c, &cosmov1alpha1.Instance{}, tmplSpec)
+	}
+	return secretVars, err
+}
@@ -0,0 +1,72 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func Test_getSecretVarsForDryrun(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cosmov1alpha1.AddToScheme(scheme))
+
+	ns := cosmov1alpha1.UserNamespace("tom")
+	tmplSpec := cosmov1alpha1.TemplateSpec{
+		RequiredVars: []cosmov1alpha1.RequiredVarSpec{
+			{Var: "{{PASSWORD}}", Secret: true, Default: "default"},
+			{Var: "{{IMAGE}}"},
+		},
+	}
+	inst := &cosmov1alpha1.Instance{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: ns},
+		Spec: cosmov1alpha1.InstanceSpec{
+			SecretVarsRef: &cosmov1alpha1.SecretVarsReference{Name: "ws1-secret-vars"},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1-secret-vars", Namespace: ns},
+		Data:       map[string][]byte{"PASSWORD": []byte("p@ssw0rd")},
+	}
+
+	tests := []struct {
+		name    string
+		secret  *corev1.Secret
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "✅ values in the Secret",
+			secret: secret,
+			want:   map[string]string{"PASSWORD": "p@ssw0rd"},
+		},
+		{
+			name: "✅ default values if the Secret is not created yet",
+			want: map[string]string{"PASSWORD": "default"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.secret != nil {
+				builder = builder.WithObjects(tt.secret.DeepCopy())
+			}
+			got, err := getSecretVarsForDryrun(context.TODO(), builder.Build(), inst, tmplSpec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getSecretVarsForDryrun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("getSecretVarsForDryrun() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
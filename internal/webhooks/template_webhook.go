@@ -14,6 +14,7 @@ import (
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/template"
 )
 
 type TemplateValidationWebhookHandler struct {
@@ -46,6 +47,15 @@ func (h *TemplateValidationWebhookHandler) Handle(ctx context.Context, req admis
 		}
 		log.DebugAll().DumpObject(h.Client.Scheme(), tmpl, "request template")
 
+		if err := template.ValidateVarSpecs(tmpl.Spec.RequiredVars); err != nil {
+			return admission.Denied(err.Error())
+		}
+		if template.IsGoTemplateRenderMode(tmpl) {
+			if err := template.ValidateGoTemplate(tmpl.Spec.RawYaml); err != nil {
+				return admission.Denied(fmt.Sprintf("invalid rawYaml: %v", err))
+			}
+		}
+
 		clusterTmpl := &cosmov1alpha1.ClusterTemplate{}
 		err = h.Client.Get(ctx, types.NamespacedName{Name: tmpl.Name}, clusterTmpl)
 		if err == nil {
@@ -65,6 +75,15 @@ func (h *TemplateValidationWebhookHandler) Handle(ctx context.Context, req admis
 		}
 		log.DebugAll().DumpObject(h.Client.Scheme(), clusterTmpl, "request cluster template")
 
+		if err := template.ValidateVarSpecs(clusterTmpl.Spec.RequiredVars); err != nil {
+			return admission.Denied(err.Error())
+		}
+		if template.IsGoTemplateRenderMode(clusterTmpl) {
+			if err := template.ValidateGoTemplate(clusterTmpl.Spec.RawYaml); err != nil {
+				return admission.Denied(fmt.Sprintf("invalid rawYaml: %v", err))
+			}
+		}
+
 		tmpl := &cosmov1alpha1.Template{}
 		err = h.Client.Get(ctx, types.NamespacedName{Name: clusterTmpl.Name}, tmpl)
 		if err == nil {
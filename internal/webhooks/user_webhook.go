@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"reflect"
 	"regexp"
 	"strconv"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -20,6 +22,7 @@ import (
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
 	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
 	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+	"github.com/cosmo-workspace/cosmo/pkg/template"
 	"github.com/cosmo-workspace/cosmo/pkg/useraddon"
 )
 
@@ -156,6 +159,15 @@ func (h *UserValidationWebhookHandler) Handle(ctx context.Context, req admission
 	}
 	log.DumpObject(h.Client.Scheme(), user, "request user")
 
+	var old *cosmov1alpha1.User
+	if req.Operation == admissionv1.Update {
+		old = &cosmov1alpha1.User{}
+		if err := h.Decoder.DecodeRaw(req.OldObject, old); err != nil {
+			log.Error(err, "failed to decode old object")
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
 	// check user name is valid for namespace
 	if !validName(user.Name) {
 		return admission.Errored(http.StatusBadRequest, fmt.Errorf("metadata.name: Invalid value: '%s': a DNS-1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')", user.Name))
@@ -208,12 +220,36 @@ func (h *UserValidationWebhookHandler) Handle(ctx context.Context, req admission
 				log.Info("user does not have required addons for template", "user", user.Name, "addon", tmpl.GetName(), "requiredAddons", requiredAddons)
 				return admission.Denied(fmt.Sprintf("addon '%s' requires addon '%s'", tmpl.GetName(), requiredAddons))
 			}
+
+			// check addon vars satisfy the var specs of the template
+			if !addonVarsChanged(addon, old) {
+				continue
+			}
+			if err := template.ValidateVars(tmpl.GetSpec().RequiredVars, addon.Vars); err != nil {
+				log.Info("addon vars are invalid", "user", user.Name, "addon", tmpl.GetName(), "error", err)
+				return admission.Denied(fmt.Sprintf("addon '%s': %v", tmpl.GetName(), err))
+			}
 		}
 	}
 
 	return admission.Allowed("Validation OK")
 }
 
+// addonVarsChanged returns whether the vars of the addon are new or changed from the old user.
+// The vars are not validated again if not changed, not to deny the updates of other fields
+// even after the var specs of the template are changed.
+func addonVarsChanged(addon cosmov1alpha1.UserAddon, old *cosmov1alpha1.User) bool {
+	if old == nil {
+		return true
+	}
+	for _, oldAddon := range old.Spec.Addons {
+		if oldAddon.Template == addon.Template {
+			return !maps.Equal(oldAddon.Vars, addon.Vars)
+		}
+	}
+	return true
+}
+
 func validName(v string) bool {
 	r, _ := regexp.Compile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
 	return r.MatchString(v)
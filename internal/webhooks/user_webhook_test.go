@@ -403,3 +403,54 @@ func Test_validName(t *testing.T) {
 		})
 	}
 }
+
+func Test_addonVarsChanged(t *testing.T) {
+	addon := cosmov1alpha1.UserAddon{
+		Template: cosmov1alpha1.UserAddonTemplateRef{Name: "addon1"},
+		Vars:     map[string]string{"{{CPU}}": "2"},
+	}
+	tests := []struct {
+		name string
+		old  *cosmov1alpha1.User
+		want bool
+	}{
+		{
+			name: "✅ create",
+			old:  nil,
+			want: true,
+		},
+		{
+			name: "✅ new addon",
+			old:  &cosmov1alpha1.User{},
+			want: true,
+		},
+		{
+			name: "✅ vars changed",
+			old: &cosmov1alpha1.User{Spec: cosmov1alpha1.UserSpec{Addons: []cosmov1alpha1.UserAddon{
+				{Template: cosmov1alpha1.UserAddonTemplateRef{Name: "addon1"}, Vars: map[string]string{"{{CPU}}": "1"}},
+			}}},
+			want: true,
+		},
+		{
+			name: "❌ vars not changed",
+			old: &cosmov1alpha1.User{Spec: cosmov1alpha1.UserSpec{Addons: []cosmov1alpha1.UserAddon{
+				{Template: cosmov1alpha1.UserAddonTemplateRef{Name: "addon1"}, Vars: map[string]string{"{{CPU}}": "2"}},
+			}}},
+			want: false,
+		},
+		{
+			name: "✅ same vars in another addon",
+			old: &cosmov1alpha1.User{Spec: cosmov1alpha1.UserSpec{Addons: []cosmov1alpha1.UserAddon{
+				{Template: cosmov1alpha1.UserAddonTemplateRef{Name: "addon1", ClusterScoped: true}, Vars: map[string]string{"{{CPU}}": "2"}},
+			}}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := addonVarsChanged(addon, tt.old); got != tt.want {
+				t.Errorf("addonVarsChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
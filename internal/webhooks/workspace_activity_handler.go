@@ -0,0 +1,106 @@
+package webhooks
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+	"github.com/cosmo-workspace/cosmo/pkg/workspace"
+)
+
+const WorkspaceActivityPath = "/workspace-activity"
+
+// WorkspaceActivityRequest is reported by cosmoauth middleware when the proxied request to the workspace is allowed
+type WorkspaceActivityRequest struct {
+	UserName string `json:"userName"`
+	Host     string `json:"host"`
+}
+
+// WorkspaceActivityHandler records the last accessed time on Workspace annotation
+type WorkspaceActivityHandler struct {
+	Client client.Client
+	Log    *clog.Logger
+
+	// MinInterval is the minimum interval to update the annotation of the same workspace
+	MinInterval time.Duration
+	// Token is the shared token which cosmoauth middleware sends in Authorization header as a bearer token.
+	// All requests are rejected if empty
+	Token string
+}
+
+func (h *WorkspaceActivityHandler) SetupWebhookWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(WorkspaceActivityPath, h)
+}
+
+func (h *WorkspaceActivityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorized(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	ctx := r.Context()
+
+	var req WorkspaceActivityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.Log.Error(err, "failed to decode activity request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	log := h.Log.WithValues("userName", req.UserName, "host", req.Host)
+
+	if req.UserName == "" || req.Host == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var wsList cosmov1alpha1.WorkspaceList
+	if err := h.Client.List(ctx, &wsList, client.InNamespace(cosmov1alpha1.UserNamespace(req.UserName))); err != nil {
+		log.Error(err, "failed to list workspaces")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	ws := workspace.FindWorkspaceByHost(wsList.Items, req.Host)
+	if ws == nil {
+		log.Debug().Info("workspace not found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	now := time.Now()
+	if last := kubeutil.GetAnnotation(ws, cosmov1alpha1.WorkspaceAnnKeyLastAccessedAt); last != "" {
+		if t, err := time.Parse(time.RFC3339, last); err == nil && now.Sub(t) < h.MinInterval {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	patch := client.MergeFrom(ws.DeepCopy())
+	kubeutil.SetAnnotation(ws, cosmov1alpha1.WorkspaceAnnKeyLastAccessedAt, now.Format(time.RFC3339))
+	if err := h.Client.Patch(ctx, ws, patch); err != nil {
+		log.Error(err, "failed to patch workspace last accessed time", "workspace", ws.Name)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	log.Debug().Info("workspace activity recorded", "workspace", ws.Name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WorkspaceActivityHandler) authorized(r *http.Request) bool {
+	if h.Token == "" {
+		return false
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(h.Token)) == 1
+}
@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+)
+
+func TestWorkspaceActivityHandler_ServeHTTP(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cosmov1alpha1.AddToScheme(scheme))
+
+	ws := &cosmov1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: cosmov1alpha1.UserNamespace("tom")},
+		Status: cosmov1alpha1.WorkspaceStatus{
+			URLs: map[string]string{"main": "https://main-ws1-tom.example.com"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		wantStatus    int
+		wantRecorded  bool
+	}{
+		{
+			name:          "✅ recorded with valid token",
+			token:         "secret",
+			authorization: "Bearer secret",
+			wantStatus:    http.StatusNoContent,
+			wantRecorded:  true,
+		},
+		{
+			name:          "❌ invalid token",
+			token:         "secret",
+			authorization: "Bearer invalid",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:       "❌ no token",
+			token:      "secret",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "❌ token is not configured",
+			token:         "",
+			authorization: "Bearer ",
+			wantStatus:    http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ws.DeepCopy()).Build()
+			h := &WorkspaceActivityHandler{
+				Client: c,
+				Log:    clog.NewLogger(ctrl.Log.WithName("WorkspaceActivityHandler")),
+				Token:  tt.token,
+			}
+
+			req := httptest.NewRequest(http.MethodPost, WorkspaceActivityPath,
+				strings.NewReader(`{"userName":"tom","host":"main-ws1-tom.example.com"}`))
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			res := httptest.NewRecorder()
+			h.ServeHTTP(res, req)
+
+			if res.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", res.Code, tt.wantStatus)
+			}
+
+			got := &cosmov1alpha1.Workspace{}
+			if err := c.Get(ctx, client.ObjectKeyFromObject(ws), got); err != nil {
+				t.Fatal(err)
+			}
+			recorded := kubeutil.GetAnnotation(got, cosmov1alpha1.WorkspaceAnnKeyLastAccessedAt) != ""
+			if recorded != tt.wantRecorded {
+				t.Errorf("recorded = %v, want %v", recorded, tt.wantRecorded)
+			}
+		})
+	}
+}
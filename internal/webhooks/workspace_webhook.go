@@ -4,10 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 	"sort"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -23,7 +28,9 @@ import (
 	"github.com/cosmo-workspace/cosmo/pkg/instance"
 	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
 	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+	"github.com/cosmo-workspace/cosmo/pkg/quota"
 	"github.com/cosmo-workspace/cosmo/pkg/template"
+	"github.com/cosmo-workspace/cosmo/pkg/transformer"
 	"github.com/cosmo-workspace/cosmo/pkg/workspace"
 )
 
@@ -56,6 +63,13 @@ func (h *WorkspaceMutationWebhookHandler) Handle(ctx context.Context, req admiss
 	before := ws.DeepCopy()
 	log.DebugAll().DumpObject(h.Client.Scheme(), before, "request workspace")
 
+	if req.Operation == admissionv1.Create {
+		if err := h.pinTemplateRevision(ctx, ws); err != nil {
+			log.Error(err, "failed to pin template revision")
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
 	err = h.mutateWorkspace(ctx, ws)
 	if err != nil {
 		log.Error(err, "muration error")
@@ -72,9 +86,34 @@ func (h *WorkspaceMutationWebhookHandler) Handle(ctx context.Context, req admiss
 	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
 }
 
-func (h *WorkspaceMutationWebhookHandler) mutateWorkspace(ctx context.Context, ws *cosmov1alpha1.Workspace) error {
+// pinTemplateRevision pins the latest revision of the template on the new workspace
+// if the template records the revisions and the revision is not specified.
+func (h *WorkspaceMutationWebhookHandler) pinTemplateRevision(ctx context.Context, ws *cosmov1alpha1.Workspace) error {
+	if ws.Spec.Template.Revision != "" {
+		return nil
+	}
 	tmpl := &cosmov1alpha1.Template{}
-	err := h.Client.Get(ctx, types.NamespacedName{Name: ws.Spec.Template.Name}, tmpl)
+	if err := h.Client.Get(ctx, types.NamespacedName{Name: ws.Spec.Template.Name}, tmpl); err != nil {
+		return fmt.Errorf("failed to fetch template '%s': %w", ws.Spec.Template.Name, err)
+	}
+	if !template.IsPinRevision(tmpl) {
+		return nil
+	}
+
+	revs, err := template.ListTemplateRevisions(ctx, h.Client, tmpl.Name)
+	if err != nil {
+		return fmt.Errorf("failed to list revisions of template '%s': %w", tmpl.Name, err)
+	}
+	if len(revs) == 0 {
+		clog.FromContext(ctx).Info("no revision is recorded yet. the current template is used", "template", tmpl.Name)
+		return nil
+	}
+	ws.Spec.Template.Revision = revs[len(revs)-1].Name
+	return nil
+}
+
+func (h *WorkspaceMutationWebhookHandler) mutateWorkspace(ctx context.Context, ws *cosmov1alpha1.Workspace) error {
+	tmpl, err := template.GetTemplate(ctx, h.Client, ws.Spec.Template)
 	if err != nil {
 		return fmt.Errorf("failed to fetch template '%s': %w", ws.Spec.Template.Name, err)
 	}
@@ -90,7 +129,7 @@ func (h *WorkspaceMutationWebhookHandler) mutateWorkspace(ctx context.Context, w
 	if err != nil {
 		return fmt.Errorf("failed to get config from template: %w", err)
 	}
-	if err := h.migrateTmplServiceToNetworkRule(ctx, ws, tmpl.Spec.RawYaml, cfg); err != nil {
+	if err := h.migrateTmplServiceToNetworkRule(ctx, ws, tmpl, cfg); err != nil {
 		return fmt.Errorf("failed to migrate service to network rule: %w", err)
 	}
 
@@ -105,8 +144,8 @@ func (h *WorkspaceMutationWebhookHandler) mutateWorkspace(ctx context.Context, w
 	return nil
 }
 
-func (h *WorkspaceMutationWebhookHandler) migrateTmplServiceToNetworkRule(ctx context.Context, ws *cosmov1alpha1.Workspace, rawTmpl string, cfg cosmov1alpha1.Config) error {
-	unst, err := preTemplateBuild(rawTmpl)
+func (h *WorkspaceMutationWebhookHandler) migrateTmplServiceToNetworkRule(ctx context.Context, ws *cosmov1alpha1.Workspace, tmpl cosmov1alpha1.TemplateObject, cfg cosmov1alpha1.Config) error {
+	unst, err := preTemplateBuild(tmpl, ws)
 	if err != nil {
 		return err
 	}
@@ -134,11 +173,51 @@ func appendNetworkRuleIfNotExist(ws *cosmov1alpha1.Workspace, netRule cosmov1alp
 	ws.Spec.Network = append(ws.Spec.Network, netRule)
 }
 
-func preTemplateBuild(rawTmpl string) ([]unstructured.Unstructured, error) {
+func preTemplateBuild(tmpl cosmov1alpha1.TemplateObject, ws *cosmov1alpha1.Workspace) ([]unstructured.Unstructured, error) {
+	rawTmpl := tmpl.GetSpec().RawYaml
+	if template.IsGoTemplateRenderMode(tmpl) {
+		// render with the workspace vars but keep the instance name as var to find the resources by name
+		inst := &cosmov1alpha1.Instance{}
+		inst.SetName(template.DefaultVarsInstance)
+		inst.SetNamespace(ws.Namespace)
+		inst.Spec.Template.Name = tmpl.GetName()
+		inst.Spec.Vars = maps.Clone(ws.Spec.Vars)
+		// fill the default values of the required vars as same as the instance build
+		instance.Mutate(inst, tmpl)
+
+		// the values of the secret vars are not available until the Secret is created,
+		// so the placeholders are used for the secret vars which are not given
+		secretVars := make(map[string]string)
+		for _, v := range tmpl.GetSpec().RequiredVars {
+			if !v.Secret || hasVar(inst.Spec.Vars, v.Var) {
+				continue
+			}
+			if v.Default != "" {
+				secretVars[v.Var] = v.Default
+			} else {
+				secretVars[v.Var] = template.RedactedSecretVarValue
+			}
+		}
+
+		var err error
+		rawTmpl, err = template.RenderGoTemplate(rawTmpl, template.NewGoTemplateData(inst, "", secretVars))
+		if err != nil {
+			return nil, fmt.Errorf("failed to render template: %w", err)
+		}
+	}
 	builder := template.NewRawYAMLBuilder(rawTmpl)
 	return builder.Build()
 }
 
+func hasVar(vars map[string]string, key string) bool {
+	for k := range vars {
+		if template.FixupTemplateVarKey(k) == template.FixupTemplateVarKey(key) {
+			return true
+		}
+	}
+	return false
+}
+
 func pickServiceInUnstructureds(objects []unstructured.Unstructured, serviceName string) (*corev1.Service, error) {
 	var svc corev1.Service
 	found := false
@@ -198,6 +277,9 @@ type WorkspaceValidationWebhookHandler struct {
 	Client  client.Client
 	Log     *clog.Logger
 	Decoder admission.Decoder
+
+	// Routing rejects the network rules not supported by the routing provider if not nil
+	Routing workspace.RoutingProvider
 }
 
 //+kubebuilder:webhook:path=/validate-cosmo-workspace-github-io-v1alpha1-workspace,mutating=false,failurePolicy=fail,sideEffects=None,groups=cosmo-workspace.github.io,resources=workspaces,verbs=create;update,versions=v1alpha1,name=vworkspace.kb.io,admissionReviewVersions={v1,v1alpha1}
@@ -212,6 +294,7 @@ func (h *WorkspaceValidationWebhookHandler) SetupWebhookWithManager(mgr ctrl.Man
 // Handle validates the fields in Workspace
 func (h *WorkspaceValidationWebhookHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
 	log := h.Log.WithValues("UID", req.UID, "GroupVersionKind", req.Kind.String(), "Name", req.Name, "Namespace", req.Namespace)
+	ctx = clog.IntoContext(ctx, log)
 
 	ws := &cosmov1alpha1.Workspace{}
 	err := h.Decoder.Decode(req, ws)
@@ -221,7 +304,16 @@ func (h *WorkspaceValidationWebhookHandler) Handle(ctx context.Context, req admi
 	}
 	log.DebugAll().DumpObject(h.Client.Scheme(), ws, "request workspace")
 
-	err = h.validateWorkspace(ctx, ws)
+	var old *cosmov1alpha1.Workspace
+	if req.Operation == admissionv1.Update {
+		old = &cosmov1alpha1.Workspace{}
+		if err := h.Decoder.DecodeRaw(req.OldObject, old); err != nil {
+			log.Error(err, "failed to decode old object")
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	err = h.validateWorkspace(ctx, ws, old)
 	if err != nil {
 		log.Error(err, "validation failed")
 		return admission.Errored(http.StatusForbidden, err)
@@ -233,10 +325,22 @@ func (h *WorkspaceValidationWebhookHandler) Handle(ctx context.Context, req admi
 		return admission.Errored(http.StatusForbidden, err)
 	}
 
+	err = h.validateTemplateVars(ctx, ws, old)
+	if err != nil {
+		log.Error(err, "validation failed")
+		return admission.Errored(http.StatusForbidden, err)
+	}
+
+	err = h.validateQuota(ctx, ws, old)
+	if err != nil {
+		log.Error(err, "validation failed")
+		return admission.Errored(http.StatusForbidden, err)
+	}
+
 	return admission.Allowed("Validation OK")
 }
 
-func (h *WorkspaceValidationWebhookHandler) validateWorkspace(ctx context.Context, ws *cosmov1alpha1.Workspace) error {
+func (h *WorkspaceValidationWebhookHandler) validateWorkspace(ctx context.Context, ws, old *cosmov1alpha1.Workspace) error {
 	// check namespace for Workspace
 	username := cosmov1alpha1.UserNameByNamespace(ws.GetNamespace())
 	if username == "" {
@@ -244,10 +348,24 @@ func (h *WorkspaceValidationWebhookHandler) validateWorkspace(ctx context.Contex
 	}
 
 	// check netrules
-	if err := checkNetworkRules(ws.Spec.Network); err != nil {
+	var oldNetRules []cosmov1alpha1.NetworkRule
+	if old != nil {
+		oldNetRules = old.Spec.Network
+	}
+	if err := checkNetworkRules(ws.Spec.Network, oldNetRules); err != nil {
+		return fmt.Errorf("network rules check failed: %w", err)
+	}
+	if err := h.checkRouting(ws.Spec.Network, oldNetRules); err != nil {
 		return fmt.Errorf("network rules check failed: %w", err)
 	}
 
+	// check schedule
+	if ws.Spec.Schedule != nil {
+		if err := workspace.ValidateSchedule(*ws.Spec.Schedule); err != nil {
+			return fmt.Errorf("schedule check failed: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -283,11 +401,116 @@ func (h *WorkspaceValidationWebhookHandler) validateTemplatePermission(ctx conte
 	return nil
 }
 
-func checkNetworkRules(netRules []cosmov1alpha1.NetworkRule) error {
+// validateTemplateVars checks the vars of the workspace satisfy the var specs of the template.
+// It is checked on creation and on changing the vars not to block the existing workspaces when the template is changed.
+func (h *WorkspaceValidationWebhookHandler) validateTemplateVars(ctx context.Context, ws, old *cosmov1alpha1.Workspace) error {
+	if old != nil && maps.Equal(old.Spec.Vars, ws.Spec.Vars) {
+		return nil
+	}
+
+	tmpl, err := template.GetTemplate(ctx, h.Client, ws.Spec.Template)
+	if err != nil {
+		return fmt.Errorf("failed to fetch template %s :%w", ws.Spec.Template.Name, err)
+	}
+	for key := range ws.Spec.Vars {
+		if template.IsSecretVar(tmpl.Spec.RequiredVars, key) {
+			return fmt.Errorf("secret var %s must be stored in the Secret referenced by spec.secretVarsRef", key)
+		}
+	}
+	return template.ValidateVars(tmpl.Spec.RequiredVars, ws.Spec.Vars)
+}
+
+// validateQuota checks the resources of the workspace do not exceed the ResourceQuota in the user namespace.
+// It is checked on creation and on starting the stopped workspace.
+func (h *WorkspaceValidationWebhookHandler) validateQuota(ctx context.Context, ws, old *cosmov1alpha1.Workspace) error {
+	log := clog.FromContext(ctx)
+
+	// volumes are already created when starting the stopped workspace
+	includeVolumes := old == nil
+	if old != nil && !isStarting(old, ws) {
+		return nil
+	}
+
+	var rq corev1.ResourceQuota
+	if err := h.Client.Get(ctx, types.NamespacedName{Name: cosmov1alpha1.UserResourceQuotaName, Namespace: ws.Namespace}, &rq); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to fetch resource quota: %w", err)
+	}
+
+	var lr *corev1.LimitRange
+	var limitRange corev1.LimitRange
+	if err := h.Client.Get(ctx, types.NamespacedName{Name: cosmov1alpha1.UserLimitRangeName, Namespace: ws.Namespace}, &limitRange); err == nil {
+		lr = &limitRange
+	} else if !apierrs.IsNotFound(err) {
+		return fmt.Errorf("failed to fetch limit range: %w", err)
+	}
+
+	objects, err := h.buildWorkspaceObjects(ctx, ws)
+	if err != nil {
+		return fmt.Errorf("failed to build workspace resources: %w", err)
+	}
+
+	requested, err := quota.RequestedResources(objects, lr, includeVolumes)
+	if err != nil {
+		return fmt.Errorf("failed to calculate requested resources: %w", err)
+	}
+	log.Debug().Info("checking quota", "requested", requested, "used", rq.Status.Used, "hard", rq.Status.Hard)
+
+	return quota.CheckQuota(&rq, requested)
+}
+
+// isStarting returns true if the workspace is scaled up from zero
+func isStarting(old, ws *cosmov1alpha1.Workspace) bool {
+	return ptr.Deref(old.Spec.Replicas, 1) == 0 && ptr.Deref(ws.Spec.Replicas, 1) > 0
+}
+
+// buildWorkspaceObjects builds the resources of the workspace in the same way as the controllers
+func (h *WorkspaceValidationWebhookHandler) buildWorkspaceObjects(ctx context.Context, ws *cosmov1alpha1.Workspace) ([]unstructured.Unstructured, error) {
+	tmpl, err := template.GetTemplate(ctx, h.Client, ws.Spec.Template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch template '%s': %w", ws.Spec.Template.Name, err)
+	}
+
+	ws = ws.DeepCopy()
+	ws.Status.Config, err = workspace.ConfigFromTemplateAnnotations(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config from template: %w", err)
+	}
+
+	inst := &cosmov1alpha1.Instance{}
+	inst.SetName(ws.Name)
+	inst.SetNamespace(ws.Namespace)
+	if err := workspace.PatchWorkspaceInstanceAsDesired(inst, ws, h.Client.Scheme()); err != nil {
+		return nil, err
+	}
+	instance.Mutate(inst, tmpl)
+
+	secretVars, err := getSecretVarsForDryrun(ctx, h.Client, inst, tmpl.Spec)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := template.BuildObjects(tmpl, inst, "", secretVars)
+	if err != nil {
+		return nil, err
+	}
+	return transformer.ApplyTransformers(ctx, transformer.AllTransformers(inst, h.Client.Scheme(), tmpl), objects)
+}
+
+// checkNetworkRules validates the network rules.
+// The protocol is validated only for the rules new or changed from the old ones,
+// not to block updating the workspaces which already have the rules valid before.
+func checkNetworkRules(netRules, oldNetRules []cosmov1alpha1.NetworkRule) error {
 	for i, netRule := range netRules {
 		if errs := validation.IsValidPortNum(int(netRule.PortNumber)); len(errs) > 0 {
 			return fmt.Errorf("port validation failed: port=%d", netRule.PortNumber)
 		}
+		if networkRuleChanged(netRule, oldNetRules) {
+			if err := checkNetworkRuleProtocol(netRule); err != nil {
+				return err
+			}
+		}
 		for j, v := range netRules {
 			if i == j {
 				continue
@@ -300,3 +523,47 @@ func checkNetworkRules(netRules []cosmov1alpha1.NetworkRule) error {
 	}
 	return nil
 }
+
+func checkNetworkRuleProtocol(netRule cosmov1alpha1.NetworkRule) error {
+	switch netRule.Protocol {
+	case cosmov1alpha1.NetworkRuleProtocolHTTP:
+	case cosmov1alpha1.NetworkRuleProtocolTCP:
+		// tcp is routed only by the hostname in TLS SNI and the authentication middlewares are not available
+		if netRule.HTTPPath != "" && netRule.HTTPPath != "/" {
+			return fmt.Errorf("http path is not available for tcp: port=%d path=%s", netRule.PortNumber, netRule.HTTPPath)
+		}
+		if !netRule.Public {
+			return fmt.Errorf("tcp network rule must be public: port=%d", netRule.PortNumber)
+		}
+	default:
+		// udp is not supported as it cannot be routed by the hostname
+		return fmt.Errorf("unsupported protocol: protocol=%s port=%d", netRule.Protocol, netRule.PortNumber)
+	}
+	return nil
+}
+
+// checkRouting rejects the new or changed network rules which cannot be routed by the routing provider such as tcp.
+// Private network rules are checked as public ones, as they are allowed but not routed without the authentication configured.
+func (h *WorkspaceValidationWebhookHandler) checkRouting(netRules, oldNetRules []cosmov1alpha1.NetworkRule) error {
+	if h.Routing == nil {
+		return nil
+	}
+	for _, r := range netRules {
+		if !networkRuleChanged(r, oldNetRules) {
+			continue
+		}
+		public := r
+		public.Public = true
+		if !h.Routing.Supports(public) {
+			return fmt.Errorf("network rule %s is not supported by the routing provider", r.UniqueKey())
+		}
+	}
+	return nil
+}
+
+// networkRuleChanged returns true if the network rule is not in the old network rules as is
+func networkRuleChanged(netRule cosmov1alpha1.NetworkRule, oldNetRules []cosmov1alpha1.NetworkRule) bool {
+	return !slices.ContainsFunc(oldNetRules, func(old cosmov1alpha1.NetworkRule) bool {
+		return equality.Semantic.DeepEqual(netRule, old)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/workspace"
 )
 
 var _ = Describe("Workspace webhook", func() {
@@ -429,6 +430,31 @@ spec:
 		})
 	})
 
+	Context("when creating workspace with invalid schedule", func() {
+		It("should deny", func() {
+			ctx := context.Background()
+
+			ws := cosmov1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testws-schedule",
+					Namespace: "cosmo-user-testuser-ws",
+				},
+				Spec: cosmov1alpha1.WorkspaceSpec{
+					Template: cosmov1alpha1.TemplateRef{Name: tmpl.GetName()},
+					Vars:     map[string]string{"DOMAIN": "example.com", "IMAGE_TAG": "latest"},
+					Schedule: &cosmov1alpha1.WorkspaceSchedule{
+						TimeZone:  "Mars/Olympus",
+						StartTime: "08:30",
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, &ws)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("schedule check failed"))
+		})
+	})
+
 	Context("when creating workspace with required role for template", func() {
 		It("should pass", func() {
 			ctx := context.Background()
@@ -709,6 +735,148 @@ func Test_appendNetworkRuleIfNotExist(t *testing.T) {
 	}
 }
 
+func Test_checkNetworkRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		netRules    []cosmov1alpha1.NetworkRule
+		oldNetRules []cosmov1alpha1.NetworkRule
+		wantErr     bool
+	}{
+		{
+			name: "✅ http and tcp",
+			netRules: []cosmov1alpha1.NetworkRule{
+				{Protocol: "http", PortNumber: 8080, HTTPPath: "/"},
+				{Protocol: "tcp", PortNumber: 5432, Public: true},
+				{Protocol: "tcp", PortNumber: 8080, Public: true},
+			},
+		},
+		{
+			name: "❌ duplicated tcp",
+			netRules: []cosmov1alpha1.NetworkRule{
+				{Protocol: "tcp", PortNumber: 5432, Public: true},
+				{Protocol: "tcp", PortNumber: 5432, Public: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "❌ tcp with path",
+			netRules: []cosmov1alpha1.NetworkRule{
+				{Protocol: "tcp", PortNumber: 5432, HTTPPath: "/db", Public: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "❌ tcp not public",
+			netRules: []cosmov1alpha1.NetworkRule{
+				{Protocol: "tcp", PortNumber: 5432},
+			},
+			wantErr: true,
+		},
+		{
+			name: "❌ udp",
+			netRules: []cosmov1alpha1.NetworkRule{
+				{Protocol: "udp", PortNumber: 53, Public: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "❌ invalid port",
+			netRules: []cosmov1alpha1.NetworkRule{
+				{Protocol: "http", PortNumber: 0, HTTPPath: "/"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "✅ existing rules are not validated",
+			netRules: []cosmov1alpha1.NetworkRule{
+				{Protocol: "udp", PortNumber: 53, Public: true},
+				{Protocol: "tcp", PortNumber: 5432},
+				{Protocol: "http", PortNumber: 8080, HTTPPath: "/"},
+			},
+			oldNetRules: []cosmov1alpha1.NetworkRule{
+				{Protocol: "udp", PortNumber: 53, Public: true},
+				{Protocol: "tcp", PortNumber: 5432},
+			},
+		},
+		{
+			name: "❌ changed rule is validated",
+			netRules: []cosmov1alpha1.NetworkRule{
+				{Protocol: "tcp", PortNumber: 5432, AllowedUsers: []string{"jerry"}},
+			},
+			oldNetRules: []cosmov1alpha1.NetworkRule{
+				{Protocol: "tcp", PortNumber: 5432},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkNetworkRules(tt.netRules, tt.oldNetRules); (err != nil) != tt.wantErr {
+				t.Errorf("checkNetworkRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkspaceValidationWebhookHandler_validateWorkspace_routing(t *testing.T) {
+	ws := func(netRules ...cosmov1alpha1.NetworkRule) *cosmov1alpha1.Workspace {
+		return &cosmov1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: cosmov1alpha1.UserNamespace("tom")},
+			Spec:       cosmov1alpha1.WorkspaceSpec{Network: netRules},
+		}
+	}
+	tests := []struct {
+		name    string
+		routing workspace.RoutingProvider
+		ws      *cosmov1alpha1.Workspace
+		old     *cosmov1alpha1.Workspace
+		wantErr bool
+	}{
+		{
+			name:    "✅ supported network rules",
+			routing: &workspace.IngressConfig{AuthAnnotations: map[string]string{"auth-url": "https://dashboard.example.com/auth/ingress"}},
+			ws:      ws(cosmov1alpha1.NetworkRule{Protocol: "http", PortNumber: 8080, HTTPPath: "/"}),
+		},
+		{
+			name: "✅ routing provider is not set",
+			ws:   ws(cosmov1alpha1.NetworkRule{Protocol: "tcp", PortNumber: 5432, Public: true}),
+		},
+		{
+			name:    "❌ tcp network rule by ingress",
+			routing: &workspace.IngressConfig{},
+			ws:      ws(cosmov1alpha1.NetworkRule{Protocol: "tcp", PortNumber: 5432, Public: true}),
+			wantErr: true,
+		},
+		{
+			name:    "✅ private network rule by gateway-api without auth configured",
+			routing: &workspace.GatewayHTTPRouteConfig{},
+			ws:      ws(cosmov1alpha1.NetworkRule{Protocol: "http", PortNumber: 8080, HTTPPath: "/"}),
+		},
+		{
+			name:    "✅ private network rule by ingress without auth configured",
+			routing: &workspace.IngressConfig{},
+			ws:      ws(cosmov1alpha1.NetworkRule{Protocol: "http", PortNumber: 8080, HTTPPath: "/"}),
+		},
+		{
+			name:    "✅ existing tcp network rule",
+			routing: &workspace.IngressConfig{},
+			ws: ws(
+				cosmov1alpha1.NetworkRule{Protocol: "tcp", PortNumber: 5432, Public: true},
+				cosmov1alpha1.NetworkRule{Protocol: "http", PortNumber: 8080, HTTPPath: "/"},
+			),
+			old: ws(cosmov1alpha1.NetworkRule{Protocol: "tcp", PortNumber: 5432, Public: true}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &WorkspaceValidationWebhookHandler{Routing: tt.routing}
+			if err := h.validateWorkspace(context.TODO(), tt.ws, tt.old); (err != nil) != tt.wantErr {
+				t.Errorf("validateWorkspace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_sortNetworkRule(t *testing.T) {
 	type args struct {
 		netRules []cosmov1alpha1.NetworkRule
@@ -804,3 +972,51 @@ func Test_sortNetworkRule(t *testing.T) {
 		})
 	}
 }
+
+func Test_preTemplateBuild(t *testing.T) {
+	tmpl := &cosmov1alpha1.Template{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tmpl1",
+			Annotations: map[string]string{cosmov1alpha1.TemplateAnnKeyRenderMode: cosmov1alpha1.TemplateRenderModeGoTemplate},
+		},
+		Spec: cosmov1alpha1.TemplateSpec{
+			RawYaml: `apiVersion: v1
+kind: Service
+metadata:
+  name: '{{INSTANCE}}-svc'
+  annotations:
+    password: '{{PASSWORD}}'
+spec:
+  ports:
+  {{- range splitList "," .Vars.PORTS }}
+  - name: port{{ . }}
+    port: {{ . }}
+  {{- end }}
+  - name: main
+    port: {{ .Vars.MAIN_PORT }}`,
+			RequiredVars: []cosmov1alpha1.RequiredVarSpec{
+				{Var: "{{MAIN_PORT}}", Default: "18080"},
+				{Var: "{{PASSWORD}}", Secret: true},
+			},
+		},
+	}
+	ws := &cosmov1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: "cosmo-user-xxx"},
+		Spec:       cosmov1alpha1.WorkspaceSpec{Vars: map[string]string{"{{PORTS}}": "8080,9090"}},
+	}
+
+	unst, err := preTemplateBuild(tmpl, ws)
+	if err != nil {
+		t.Fatalf("preTemplateBuild() error = %v", err)
+	}
+	svc, err := pickServiceInUnstructureds(unst, "svc")
+	if err != nil {
+		t.Fatalf("pickServiceInUnstructureds() error = %v", err)
+	}
+	if len(svc.Spec.Ports) != 3 || svc.Spec.Ports[2].Port != 18080 {
+		t.Errorf("ports = %v, want 3 ports including the default var", svc.Spec.Ports)
+	}
+	if _, ok := ws.Spec.Vars["{{MAIN_PORT}}"]; ok {
+		t.Errorf("workspace vars are changed: %v", ws.Spec.Vars)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
 	dashv1alpha1 "github.com/cosmo-workspace/cosmo/proto/gen/dashboard/v1alpha1"
 )
 
@@ -34,8 +35,15 @@ func C2D_Template(tmpl cosmov1alpha1.TemplateObject, opts ...TemplateConvertOpti
 	requiredVars := make([]*dashv1alpha1.TemplateRequiredVars, len(tmpl.GetSpec().RequiredVars))
 	for i, v := range tmpl.GetSpec().RequiredVars {
 		requiredVars[i] = &dashv1alpha1.TemplateRequiredVars{
-			VarName:      v.Var,
-			DefaultValue: v.Default,
+			VarName:       v.Var,
+			DefaultValue:  v.Default,
+			Type:          string(v.Type),
+			Description:   v.Description,
+			Pattern:       v.Pattern,
+			Minimum:       v.Minimum,
+			Maximum:       v.Maximum,
+			AllowedValues: v.AllowedValues,
+			Secret:        v.Secret,
 		}
 	}
 
@@ -74,3 +82,56 @@ func C2D_Template(tmpl cosmov1alpha1.TemplateObject, opts ...TemplateConvertOpti
 	}
 	return d
 }
+
+func D2C_RequiredVars(vars []*dashv1alpha1.TemplateRequiredVars) []cosmov1alpha1.RequiredVarSpec {
+	specs := make([]cosmov1alpha1.RequiredVarSpec, len(vars))
+	for i, v := range vars {
+		specs[i] = cosmov1alpha1.RequiredVarSpec{
+			Var:           v.VarName,
+			Default:       v.DefaultValue,
+			Type:          cosmov1alpha1.VarType(v.Type),
+			Description:   v.Description,
+			Pattern:       v.Pattern,
+			Minimum:       v.Minimum,
+			Maximum:       v.Maximum,
+			AllowedValues: v.AllowedValues,
+			Secret:        v.Secret,
+		}
+	}
+	return specs
+}
+
+func C2D_InstanceDiffs(diffs []kosmo.InstanceDiff) []*dashv1alpha1.InstanceDiff {
+	dDiffs := make([]*dashv1alpha1.InstanceDiff, len(diffs))
+	for i, v := range diffs {
+		dDiffs[i] = C2D_InstanceDiff(v)
+	}
+	return dDiffs
+}
+
+func C2D_InstanceDiff(diff kosmo.InstanceDiff) *dashv1alpha1.InstanceDiff {
+	objects := make([]*dashv1alpha1.ObjectDiff, len(diff.Objects))
+	for i, v := range diff.Objects {
+		objects[i] = &dashv1alpha1.ObjectDiff{
+			ApiVersion: v.APIVersion,
+			Kind:       v.Kind,
+			Name:       v.Name,
+			Diff:       v.Diff,
+			Created:    v.Created,
+			Error:      errorString(v.Error),
+		}
+	}
+	return &dashv1alpha1.InstanceDiff{
+		UserName:      cosmov1alpha1.UserNameByNamespace(diff.Instance.Namespace),
+		WorkspaceName: diff.Instance.Name,
+		Objects:       objects,
+		Error:         errorString(diff.Error),
+	}
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
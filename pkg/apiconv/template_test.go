@@ -1,6 +1,7 @@
 package apiconv
 
 import (
+	"errors"
 	"reflect"
 	"slices"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/kosmo"
 	dashv1alpha1 "github.com/cosmo-workspace/cosmo/proto/gen/dashboard/v1alpha1"
 )
 
@@ -140,6 +142,14 @@ func TestC2D_Template(t *testing.T) {
 							{
 								Var: "var2",
 							},
+							{
+								Var:           "var3",
+								Type:          cosmov1alpha1.VarTypeInt,
+								Description:   "var3 desc",
+								Minimum:       ptr.To[int64](1),
+								Maximum:       ptr.To[int64](4),
+								AllowedValues: []string{"1", "2", "4"},
+							},
 						},
 					},
 				},
@@ -155,6 +165,14 @@ func TestC2D_Template(t *testing.T) {
 					{
 						VarName: "var2",
 					},
+					{
+						VarName:       "var3",
+						Type:          "int",
+						Description:   "var3 desc",
+						Minimum:       ptr.To[int64](1),
+						Maximum:       ptr.To[int64](4),
+						AllowedValues: []string{"1", "2", "4"},
+					},
 				},
 				IsDefaultUserAddon: ptr.To(true),
 				RequiredUseraddons: []string{
@@ -248,3 +266,84 @@ spec:
 		})
 	}
 }
+
+func TestD2C_RequiredVars(t *testing.T) {
+	want := []cosmov1alpha1.RequiredVarSpec{
+		{Var: "var1", Default: "def1"},
+		{Var: "var2", Type: cosmov1alpha1.VarTypeEnum, Description: "var2 desc", Pattern: "^s", AllowedValues: []string{"small", "large"}},
+		{Var: "var3", Type: cosmov1alpha1.VarTypeInt, Minimum: ptr.To[int64](1), Maximum: ptr.To[int64](4)},
+		{Var: "var4", Secret: true},
+	}
+	tmpl := &cosmov1alpha1.Template{Spec: cosmov1alpha1.TemplateSpec{RequiredVars: want}}
+
+	got := D2C_RequiredVars(C2D_Template(tmpl).RequiredVars)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("D2C_RequiredVars() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestC2D_InstanceDiffs(t *testing.T) {
+	tests := []struct {
+		name  string
+		diffs []kosmo.InstanceDiff
+		want  []*dashv1alpha1.InstanceDiff
+	}{
+		{
+			name:  "empty",
+			diffs: []kosmo.InstanceDiff{},
+			want:  []*dashv1alpha1.InstanceDiff{},
+		},
+		{
+			name: "OK",
+			diffs: []kosmo.InstanceDiff{
+				{
+					Instance: cosmov1alpha1.Instance{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "ws1",
+							Namespace: "cosmo-user-tom",
+						},
+					},
+					Objects: []kosmo.ObjectDiff{
+						{APIVersion: "apps/v1", Kind: "Deployment", Name: "ws1-workspace", Diff: "diff"},
+						{APIVersion: "v1", Kind: "Service", Name: "ws1-workspace", Created: true},
+						{APIVersion: "v1", Kind: "ConfigMap", Name: "ws1-cm", Error: errors.New("forbidden")},
+					},
+				},
+				{
+					Instance: cosmov1alpha1.Instance{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "ws2",
+							Namespace: "cosmo-user-tom",
+						},
+					},
+					Error: errors.New("failed to build objects"),
+				},
+			},
+			want: []*dashv1alpha1.InstanceDiff{
+				{
+					UserName:      "tom",
+					WorkspaceName: "ws1",
+					Objects: []*dashv1alpha1.ObjectDiff{
+						{ApiVersion: "apps/v1", Kind: "Deployment", Name: "ws1-workspace", Diff: "diff"},
+						{ApiVersion: "v1", Kind: "Service", Name: "ws1-workspace", Created: true},
+						{ApiVersion: "v1", Kind: "ConfigMap", Name: "ws1-cm", Error: "forbidden"},
+					},
+				},
+				{
+					UserName:      "tom",
+					WorkspaceName: "ws2",
+					Objects:       []*dashv1alpha1.ObjectDiff{},
+					Error:         "failed to build objects",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := C2D_InstanceDiffs(tt.diffs)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("C2D_InstanceDiffs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
@@ -71,6 +72,24 @@ func D2C_DeletePolicy(deletePolicy *dashv1alpha1.DeletePolicy) string {
 	}
 }
 
+func C2D_Conditions(conditions []metav1.Condition) []*dashv1alpha1.Condition {
+	if len(conditions) == 0 {
+		return nil
+	}
+	d := make([]*dashv1alpha1.Condition, len(conditions))
+	for i, c := range conditions {
+		d[i] = &dashv1alpha1.Condition{
+			Type:               c.Type,
+			Status:             string(c.Status),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			ObservedGeneration: c.ObservedGeneration,
+			LastTransitionTime: timestamppb.New(c.LastTransitionTime.Time),
+		}
+	}
+	return d
+}
+
 func C2D_User(user cosmov1alpha1.User, opts ...UserConvertOptions) *dashv1alpha1.User {
 	d := &dashv1alpha1.User{
 		Name:         user.Name,
@@ -80,6 +99,7 @@ func C2D_User(user cosmov1alpha1.User, opts ...UserConvertOptions) *dashv1alpha1
 		Addons:       C2D_UserAddons(user.Spec.Addons),
 		Status:       string(user.Status.Phase),
 		DeletePolicy: C2D_DeletePolicy(kubeutil.GetAnnotation(&user, cosmov1alpha1.ResourceAnnKeyDeletePolicy)),
+		Conditions:   C2D_Conditions(user.Status.Conditions),
 	}
 
 	for _, opt := range opts {
@@ -106,6 +106,49 @@ func TestC2D_User(t *testing.T) {
 				AuthType:    cosmov1alpha1.UserAuthTypePasswordSecert.String(),
 			},
 		},
+		{
+			name: "WithConditions",
+			args: args{
+				user: cosmov1alpha1.User{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testuser",
+					},
+					Spec: cosmov1alpha1.UserSpec{
+						DisplayName: "testdisplay",
+						AuthType:    cosmov1alpha1.UserAuthTypePasswordSecert,
+					},
+					Status: cosmov1alpha1.UserStatus{
+						Phase: "Active",
+						Conditions: []metav1.Condition{
+							{
+								Type:               cosmov1alpha1.ConditionTypeReady,
+								Status:             metav1.ConditionFalse,
+								ObservedGeneration: 2,
+								LastTransitionTime: metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+								Reason:             cosmov1alpha1.ConditionReasonAddonsFailed,
+								Message:            "failed to create or update addon",
+							},
+						},
+					},
+				},
+			},
+			want: &dashv1alpha1.User{
+				Name:        "testuser",
+				DisplayName: "testdisplay",
+				AuthType:    cosmov1alpha1.UserAuthTypePasswordSecert.String(),
+				Status:      "Active",
+				Conditions: []*dashv1alpha1.Condition{
+					{
+						Type:               "Ready",
+						Status:             "False",
+						Reason:             "AddonsFailed",
+						Message:            "failed to create or update addon",
+						ObservedGeneration: 2,
+						LastTransitionTime: timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+					},
+				},
+			},
+		},
 		{
 			name: "WithRaw",
 			args: args{
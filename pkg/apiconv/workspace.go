@@ -9,6 +9,7 @@ import (
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+	"github.com/cosmo-workspace/cosmo/pkg/template"
 	dashv1alpha1 "github.com/cosmo-workspace/cosmo/proto/gen/dashboard/v1alpha1"
 )
 
@@ -52,12 +53,14 @@ func C2D_Workspace(ws cosmov1alpha1.Workspace, opts ...WorkspaceConvertOptions)
 		Spec: &dashv1alpha1.WorkspaceSpec{
 			Template: ws.Spec.Template.Name,
 			Replicas: *replicas,
-			Vars:     ws.Spec.Vars,
+			Vars:     redactSecretVars(ws.Spec.Vars, ws.Spec.SecretVarsRef),
 			Network:  C2D_NetworkRules(ws.Spec.Network, ws.Status.URLs),
+			Schedule: C2D_WorkspaceSchedule(ws.Spec.Schedule),
 		},
 		Status: &dashv1alpha1.WorkspaceStatus{
-			Phase:   string(ws.Status.Phase),
-			MainUrl: ws.Status.URLs[cosmov1alpha1.MainRuleKey(ws.Status.Config)],
+			Phase:      string(ws.Status.Phase),
+			MainUrl:    ws.Status.URLs[cosmov1alpha1.MainRuleKey(ws.Status.Config)],
+			Conditions: C2D_Conditions(ws.Status.Conditions),
 		},
 		DeletePolicy: C2D_DeletePolicy(kubeutil.GetAnnotation(&ws, cosmov1alpha1.ResourceAnnKeyDeletePolicy)),
 	}
@@ -73,6 +76,22 @@ func C2D_Workspace(ws cosmov1alpha1.Workspace, opts ...WorkspaceConvertOptions)
 	return d
 }
 
+// redactSecretVars returns the vars with the secret vars whose values are redacted.
+// The secret vars are listed so that the clients can tell which vars are set.
+func redactSecretVars(vars map[string]string, ref *cosmov1alpha1.SecretVarsReference) map[string]string {
+	if ref == nil {
+		return vars
+	}
+	redacted := make(map[string]string, len(vars)+len(ref.Vars))
+	for k, v := range vars {
+		redacted[k] = v
+	}
+	for _, k := range ref.Vars {
+		redacted[k] = template.RedactedSecretVarValue
+	}
+	return redacted
+}
+
 func C2D_NetworkRules(netRules []cosmov1alpha1.NetworkRule, urlMap map[string]string) []*dashv1alpha1.NetworkRule {
 	apirules := make([]*dashv1alpha1.NetworkRule, 0, len(netRules))
 	for _, v := range netRules {
@@ -84,13 +103,18 @@ func C2D_NetworkRules(netRules []cosmov1alpha1.NetworkRule, urlMap map[string]st
 }
 
 func C2D_NetworkRule(v cosmov1alpha1.NetworkRule) *dashv1alpha1.NetworkRule {
-	return &dashv1alpha1.NetworkRule{
+	r := &dashv1alpha1.NetworkRule{
 		PortNumber:       int32(v.PortNumber),
 		CustomHostPrefix: v.CustomHostPrefix,
 		HttpPath:         v.HTTPPath,
 		Public:           v.Public,
 		AllowedUsers:     v.AllowedUsers,
 	}
+	// empty means http
+	if v.Protocol != cosmov1alpha1.NetworkRuleProtocolHTTP {
+		r.Protocol = v.Protocol
+	}
+	return r
 }
 
 func D2C_NetworkRules(netRules []*dashv1alpha1.NetworkRule) []cosmov1alpha1.NetworkRule {
@@ -109,7 +133,68 @@ func D2C_NetworkRule(v *dashv1alpha1.NetworkRule) cosmov1alpha1.NetworkRule {
 		HTTPPath:         v.HttpPath,
 		Public:           v.Public,
 		AllowedUsers:     v.AllowedUsers,
+		Protocol:         v.Protocol,
 	}
 	r.Default()
 	return r
 }
+
+func C2D_WorkspaceSchedule(v *cosmov1alpha1.WorkspaceSchedule) *dashv1alpha1.WorkspaceSchedule {
+	if v == nil {
+		return nil
+	}
+	days := make([]string, len(v.Days))
+	for i, d := range v.Days {
+		days[i] = string(d)
+	}
+	return &dashv1alpha1.WorkspaceSchedule{
+		TimeZone:  v.TimeZone,
+		Days:      days,
+		StartTime: v.StartTime,
+		StopTime:  v.StopTime,
+	}
+}
+
+func D2C_WorkspaceSchedule(v *dashv1alpha1.WorkspaceSchedule) *cosmov1alpha1.WorkspaceSchedule {
+	if v == nil {
+		return nil
+	}
+	days := make([]cosmov1alpha1.ScheduleDay, len(v.Days))
+	for i, d := range v.Days {
+		days[i] = cosmov1alpha1.ScheduleDay(d)
+	}
+	return &cosmov1alpha1.WorkspaceSchedule{
+		TimeZone:  v.TimeZone,
+		Days:      days,
+		StartTime: v.StartTime,
+		StopTime:  v.StopTime,
+	}
+}
+
+func C2D_WorkspaceSnapshots(snaps []cosmov1alpha1.WorkspaceSnapshot) []*dashv1alpha1.WorkspaceSnapshot {
+	apisnaps := make([]*dashv1alpha1.WorkspaceSnapshot, len(snaps))
+	for i, v := range snaps {
+		apisnaps[i] = C2D_WorkspaceSnapshot(v)
+	}
+	return apisnaps
+}
+
+func C2D_WorkspaceSnapshot(snap cosmov1alpha1.WorkspaceSnapshot) *dashv1alpha1.WorkspaceSnapshot {
+	d := &dashv1alpha1.WorkspaceSnapshot{
+		Name:    snap.Name,
+		WsName:  snap.Spec.Workspace,
+		Phase:   snap.Status.Phase,
+		Message: snap.Status.Message,
+		Volumes: make([]string, 0, len(snap.Status.Volumes)),
+	}
+	for _, v := range snap.Status.Volumes {
+		d.Volumes = append(d.Volumes, v.PersistentVolumeClaimName)
+	}
+	if !snap.CreationTimestamp.IsZero() {
+		d.CreationTimestamp = timestamppb.New(snap.CreationTimestamp.Time)
+	}
+	if snap.Status.LastRestoredAt != nil {
+		d.LastRestoredAt = timestamppb.New(snap.Status.LastRestoredAt.Time)
+	}
+	return d
+}
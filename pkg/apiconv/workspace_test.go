@@ -4,10 +4,12 @@ import (
 	"reflect"
 	"slices"
 	"testing"
+	"time"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 	dashv1alpha1 "github.com/cosmo-workspace/cosmo/proto/gen/dashboard/v1alpha1"
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -237,6 +239,41 @@ func TestC2D_Workspace(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "OK with secret vars",
+			args: args{
+				ws: cosmov1alpha1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "ws1",
+					},
+					Spec: cosmov1alpha1.WorkspaceSpec{
+						Template: cosmov1alpha1.TemplateRef{
+							Name: "tmpl1",
+						},
+						Vars: map[string]string{
+							"key1": "val1",
+						},
+						SecretVarsRef: &cosmov1alpha1.SecretVarsReference{
+							Name: "ws1-secret-vars",
+							Vars: []string{"PASSWORD"},
+						},
+					},
+				},
+			},
+			want: &dashv1alpha1.Workspace{
+				Name: "ws1",
+				Spec: &dashv1alpha1.WorkspaceSpec{
+					Template: "tmpl1",
+					Replicas: int64(1),
+					Vars: map[string]string{
+						"key1":     "val1",
+						"PASSWORD": "********",
+					},
+					Network: []*dashv1alpha1.NetworkRule{},
+				},
+				Status: &dashv1alpha1.WorkspaceStatus{},
+			},
+		},
 		{
 			name: "WithRaw",
 			args: args{
@@ -457,6 +494,21 @@ func TestC2D_NetworkRule(t *testing.T) {
 				Public:           true,
 			},
 		},
+		{
+			name: "tcp",
+			args: args{
+				v: cosmov1alpha1.NetworkRule{
+					Protocol:   "tcp",
+					PortNumber: 5432,
+					Public:     true,
+				},
+			},
+			want: &dashv1alpha1.NetworkRule{
+				Protocol:   "tcp",
+				PortNumber: 5432,
+				Public:     true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -559,6 +611,21 @@ func TestD2C_NetworkRule(t *testing.T) {
 				Public:           true,
 			},
 		},
+		{
+			name: "tcp",
+			args: args{
+				v: &dashv1alpha1.NetworkRule{
+					Protocol:   "tcp",
+					PortNumber: 5432,
+					Public:     true,
+				},
+			},
+			want: cosmov1alpha1.NetworkRule{
+				Protocol:   "tcp",
+				PortNumber: 5432,
+				Public:     true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -568,3 +635,123 @@ func TestD2C_NetworkRule(t *testing.T) {
 		})
 	}
 }
+
+func TestC2D_WorkspaceSchedule(t *testing.T) {
+	tests := []struct {
+		name string
+		v    *cosmov1alpha1.WorkspaceSchedule
+		want *dashv1alpha1.WorkspaceSchedule
+	}{
+		{
+			name: "OK",
+			v: &cosmov1alpha1.WorkspaceSchedule{
+				TimeZone:  "Asia/Tokyo",
+				Days:      []cosmov1alpha1.ScheduleDay{cosmov1alpha1.ScheduleDayMonday, cosmov1alpha1.ScheduleDayFriday},
+				StartTime: "08:30",
+				StopTime:  "20:00",
+			},
+			want: &dashv1alpha1.WorkspaceSchedule{
+				TimeZone:  "Asia/Tokyo",
+				Days:      []string{"Mon", "Fri"},
+				StartTime: "08:30",
+				StopTime:  "20:00",
+			},
+		},
+		{
+			name: "nil",
+			v:    nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := C2D_WorkspaceSchedule(tt.v); got.String() != tt.want.String() {
+				t.Errorf("C2D_WorkspaceSchedule() = %s, want %s", got.String(), tt.want.String())
+			}
+		})
+	}
+}
+
+func TestD2C_WorkspaceSchedule(t *testing.T) {
+	tests := []struct {
+		name string
+		v    *dashv1alpha1.WorkspaceSchedule
+		want *cosmov1alpha1.WorkspaceSchedule
+	}{
+		{
+			name: "OK",
+			v: &dashv1alpha1.WorkspaceSchedule{
+				Days:     []string{"Sat"},
+				StopTime: "20:00",
+			},
+			want: &cosmov1alpha1.WorkspaceSchedule{
+				Days:     []cosmov1alpha1.ScheduleDay{cosmov1alpha1.ScheduleDaySaturday},
+				StopTime: "20:00",
+			},
+		},
+		{
+			name: "nil",
+			v:    nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := D2C_WorkspaceSchedule(tt.v); !cmp.Equal(got, tt.want) {
+				t.Errorf("D2C_WorkspaceSchedule() diff = %s", cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestC2D_WorkspaceSnapshot(t *testing.T) {
+	created := metav1.NewTime(time.Date(2024, 5, 20, 14, 0, 0, 0, time.UTC))
+	restored := metav1.NewTime(time.Date(2024, 5, 21, 9, 30, 0, 0, time.UTC))
+	tests := []struct {
+		name string
+		v    cosmov1alpha1.WorkspaceSnapshot
+		want *dashv1alpha1.WorkspaceSnapshot
+	}{
+		{
+			name: "OK",
+			v: cosmov1alpha1.WorkspaceSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "snap1", CreationTimestamp: created},
+				Spec:       cosmov1alpha1.WorkspaceSnapshotSpec{Workspace: "ws1"},
+				Status: cosmov1alpha1.WorkspaceSnapshotStatus{
+					Phase: cosmov1alpha1.WorkspaceSnapshotPhaseReady,
+					Volumes: []cosmov1alpha1.VolumeSnapshot{
+						{PersistentVolumeClaimName: "home", VolumeSnapshotName: "snap1-home", ReadyToUse: true},
+					},
+					LastRestoredAt: &restored,
+				},
+			},
+			want: &dashv1alpha1.WorkspaceSnapshot{
+				Name:              "snap1",
+				WsName:            "ws1",
+				Phase:             "Ready",
+				Volumes:           []string{"home"},
+				CreationTimestamp: timestamppb.New(created.Time),
+				LastRestoredAt:    timestamppb.New(restored.Time),
+			},
+		},
+		{
+			name: "Pending",
+			v: cosmov1alpha1.WorkspaceSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "snap1"},
+				Spec:       cosmov1alpha1.WorkspaceSnapshotSpec{Workspace: "ws1"},
+			},
+			want: &dashv1alpha1.WorkspaceSnapshot{
+				Name:    "snap1",
+				WsName:  "ws1",
+				Volumes: []string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := C2D_WorkspaceSnapshot(tt.v); got.String() != tt.want.String() {
+				t.Errorf("C2D_WorkspaceSnapshot() = %s, want %s", got.String(), tt.want.String())
+			}
+		})
+	}
+}
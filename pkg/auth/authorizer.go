@@ -2,12 +2,24 @@ package auth
 
 import (
 	"context"
+	"errors"
 )
 
+// ErrGroupsNotResolved is returned by GroupAuthorizer with verified true
+// when the user is authenticated but the groups cannot be resolved.
+// The roles must not be synced with the groups not to remove the roles by a transient failure.
+var ErrGroupsNotResolved = errors.New("groups are not resolved")
+
 type Authorizer interface {
 	Authorize(ctx context.Context, msg AuthRequest) (bool, error)
 }
 
+// GroupAuthorizer is an Authorizer which also resolves the external groups of the user
+type GroupAuthorizer interface {
+	Authorizer
+	AuthorizeWithGroups(ctx context.Context, msg AuthRequest) (verified bool, groups []string, err error)
+}
+
 type AuthRequest interface {
 	GetPassword() string
 	GetUserName() string
@@ -19,42 +19,64 @@ type LdapAuthorizer struct {
 	SearchBindPassword string // [for Search mode] The password to bind.
 	SearchBaseDN       string // [for Search mode] Base DN used for all LDAP queries. ex: dc=example,dc=com
 	SearchFilter       string // [for Search mode] ex: "(sAMAccountname=%s)"  "%s" is replaced by the user name.
+
+	// for group resolution
+	GroupAttribute    string // Attribute of the user entry which has the group DNs. ex: memberOf
+	GroupSearchBaseDN string // Base DN to search groups. ex: ou=groups,dc=example,dc=com
+	GroupSearchFilter string // ex: "(member=%s)"  "%s" is replaced by the user DN.
 }
 
 func (a *LdapAuthorizer) Authorize(ctx context.Context, msg AuthRequest) (bool, error) {
+	verified, _, err := a.AuthorizeWithGroups(ctx, msg)
+	return verified, err
+}
+
+// AuthorizeWithGroups authorize the user and returns the DNs of the groups which the user belongs to
+func (a *LdapAuthorizer) AuthorizeWithGroups(ctx context.Context, msg AuthRequest) (bool, []string, error) {
 
 	conn, err := ldap.DialURL(a.URL, ldap.DialWithTLSConfig(a.TlsConfig))
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	defer conn.Close()
 
 	if a.StartTLS {
 		if err := conn.StartTLS(a.TlsConfig); err != nil {
-			return false, err
+			return false, nil, err
 		}
 	}
+
+	var (
+		userDN   string
+		verified bool
+	)
 	if a.SearchFilter == "" {
-		return a.checkWithBindMode(ctx, conn, msg)
+		userDN, verified, err = a.checkWithBindMode(ctx, conn, msg)
 	} else {
-		return a.checkWithSearchMode(ctx, conn, msg)
+		userDN, verified, err = a.checkWithSearchMode(ctx, conn, msg)
+	}
+	if !verified || err != nil {
+		return verified, nil, err
+	}
+
+	// the user is authenticated even if the groups cannot be resolved
+	groups, err := a.searchGroups(ctx, conn, userDN)
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: userDN=%s: %v", ErrGroupsNotResolved, userDN, err)
 	}
+	return true, groups, nil
 }
 
-func (a *LdapAuthorizer) checkWithBindMode(ctx context.Context, conn *ldap.Conn, msg AuthRequest) (bool, error) {
+func (a *LdapAuthorizer) checkWithBindMode(ctx context.Context, conn *ldap.Conn, msg AuthRequest) (string, bool, error) {
 	userDN := fmt.Sprintf(a.BindDN, msg.GetUserName())
 	err := conn.Bind(userDN, msg.GetPassword())
-	return err == nil, err
+	return userDN, err == nil, err
 }
 
-func (a *LdapAuthorizer) checkWithSearchMode(ctx context.Context, conn *ldap.Conn, msg AuthRequest) (bool, error) {
+func (a *LdapAuthorizer) checkWithSearchMode(ctx context.Context, conn *ldap.Conn, msg AuthRequest) (string, bool, error) {
 
-	if a.SearchBindDN != "" && a.SearchBindPassword != "" {
-		if err := conn.Bind(a.SearchBindDN, a.SearchBindPassword); err != nil {
-			return false, err
-		}
-	} else {
-		_ = conn.UnauthenticatedBind("")
+	if err := a.searchBind(conn); err != nil {
+		return "", false, err
 	}
 
 	searchFilter := fmt.Sprintf(a.SearchFilter, msg.GetUserName())
@@ -73,15 +95,81 @@ func (a *LdapAuthorizer) checkWithSearchMode(ctx context.Context, conn *ldap.Con
 
 	result, err := conn.Search(search)
 	if err != nil {
-		return false, err
+		return "", false, err
 	}
 	if len(result.Entries) < 1 {
-		return false, fmt.Errorf("not found user")
+		return "", false, fmt.Errorf("not found user")
 	} else if len(result.Entries) > 1 {
-		return false, fmt.Errorf(fmt.Sprintf("found too many user (%d)", len(result.Entries)))
+		return "", false, fmt.Errorf(fmt.Sprintf("found too many user (%d)", len(result.Entries)))
 	}
 
 	userDN := result.Entries[0].DN
 	err = conn.Bind(userDN, msg.GetPassword())
-	return err == nil, err
+	return userDN, err == nil, err
+}
+
+func (a *LdapAuthorizer) searchBind(conn *ldap.Conn) error {
+	if a.SearchBindDN != "" && a.SearchBindPassword != "" {
+		return conn.Bind(a.SearchBindDN, a.SearchBindPassword)
+	}
+	_ = conn.UnauthenticatedBind("")
+	return nil
+}
+
+// searchGroups returns the group DNs of the user.
+// It is called after binding as the user, so the search is done with the user's privilege
+// unless SearchBindDN is configured.
+func (a *LdapAuthorizer) searchGroups(ctx context.Context, conn *ldap.Conn, userDN string) ([]string, error) {
+	if a.GroupAttribute == "" && a.GroupSearchFilter == "" {
+		return nil, nil
+	}
+	if a.SearchBindDN != "" && a.SearchBindPassword != "" {
+		if err := conn.Bind(a.SearchBindDN, a.SearchBindPassword); err != nil {
+			return nil, err
+		}
+	}
+
+	groups := make([]string, 0)
+	if a.GroupAttribute != "" {
+		search := ldap.NewSearchRequest(
+			userDN,
+			ldap.ScopeBaseObject,
+			ldap.NeverDerefAliases,
+			0,
+			0,
+			false,
+			"(objectClass=*)",
+			[]string{a.GroupAttribute},
+			nil,
+		)
+		result, err := conn.Search(search)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range result.Entries {
+			groups = append(groups, e.GetAttributeValues(a.GroupAttribute)...)
+		}
+	}
+
+	if a.GroupSearchFilter != "" {
+		search := ldap.NewSearchRequest(
+			a.GroupSearchBaseDN,
+			ldap.ScopeWholeSubtree,
+			ldap.NeverDerefAliases,
+			0,
+			0,
+			false,
+			fmt.Sprintf(a.GroupSearchFilter, ldap.EscapeFilter(userDN)),
+			[]string{"dn"},
+			nil,
+		)
+		result, err := conn.Search(search)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range result.Entries {
+			groups = append(groups, e.DN)
+		}
+	}
+	return groups, nil
 }
@@ -21,3 +21,24 @@ func (a *MockAuthorizer) Authorize(ctx context.Context, msg AuthRequest) (bool,
 	}
 	return true, nil
 }
+
+// MockGroupAuthorizer is a MockAuthorizer which returns the groups of the user
+type MockGroupAuthorizer struct {
+	MockAuthorizer
+	UserGroupsMap map[string][]string // key: user, value: groups
+}
+
+func NewMockGroupAuthorizer(userPassMap map[string]string, userGroupsMap map[string][]string) *MockGroupAuthorizer {
+	return &MockGroupAuthorizer{
+		MockAuthorizer: MockAuthorizer{UserPassMap: userPassMap},
+		UserGroupsMap:  userGroupsMap,
+	}
+}
+
+func (a *MockGroupAuthorizer) AuthorizeWithGroups(ctx context.Context, msg AuthRequest) (bool, []string, error) {
+	verified, err := a.Authorize(ctx, msg)
+	if !verified {
+		return verified, nil, err
+	}
+	return true, a.UserGroupsMap[msg.GetUserName()], nil
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+// RoleMappingConfig is a configuration file format for RoleMapper
+//
+//	mappings:
+//	- group: "cn=teama-admins,ou=groups,dc=example,dc=com"
+//	  roles: ["teama-admin"]
+//	- groupPattern: "^cn=(.+)-developers,ou=groups,dc=example,dc=com$"
+//	  roles: ["$1-developer"]
+type RoleMappingConfig struct {
+	Mappings []RoleMapping `json:"mappings"`
+}
+
+// RoleMapping maps external groups (LDAP group DN or OIDC claim value) to cosmo UserRoles
+type RoleMapping struct {
+	// Group is an exact group name
+	Group string `json:"group,omitempty"`
+	// GroupPattern is a regular expression of group names. Roles can refer to the submatches like "$1"
+	GroupPattern string   `json:"groupPattern,omitempty"`
+	Roles        []string `json:"roles"`
+}
+
+// RoleMapper resolves cosmo UserRoles from external groups
+type RoleMapper struct {
+	mappings []roleMapping
+}
+
+type roleMapping struct {
+	RoleMapping
+	pattern *regexp.Regexp
+}
+
+func NewRoleMapper(mappings []RoleMapping) (*RoleMapper, error) {
+	m := &RoleMapper{mappings: make([]roleMapping, 0, len(mappings))}
+	for i, v := range mappings {
+		if (v.Group == "") == (v.GroupPattern == "") {
+			return nil, fmt.Errorf("mappings[%d]: either group or groupPattern is required", i)
+		}
+		if len(v.Roles) == 0 {
+			return nil, fmt.Errorf("mappings[%d]: roles is required", i)
+		}
+		rm := roleMapping{RoleMapping: v}
+		if v.GroupPattern != "" {
+			p, err := regexp.Compile(v.GroupPattern)
+			if err != nil {
+				return nil, fmt.Errorf("mappings[%d]: invalid groupPattern: %w", i, err)
+			}
+			rm.pattern = p
+		}
+		m.mappings = append(m.mappings, rm)
+	}
+	return m, nil
+}
+
+// LoadRoleMapper loads RoleMappingConfig from the file and returns RoleMapper
+func LoadRoleMapper(path string) (*RoleMapper, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read role mapping file: %w", err)
+	}
+	var c RoleMappingConfig
+	if err := yaml.UnmarshalStrict(b, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse role mapping file: %w", err)
+	}
+	return NewRoleMapper(c.Mappings)
+}
+
+// Roles returns the sorted role names mapped from the groups
+func (m *RoleMapper) Roles(groups []string) []string {
+	roles := make([]string, 0)
+	for _, g := range groups {
+		for _, v := range m.mappings {
+			if v.pattern == nil {
+				if v.Group == g {
+					roles = append(roles, v.Roles...)
+				}
+				continue
+			}
+			match := v.pattern.FindStringSubmatchIndex(g)
+			if match == nil {
+				continue
+			}
+			for _, r := range v.Roles {
+				roles = append(roles, string(v.pattern.ExpandString(nil, r, g, match)))
+			}
+		}
+	}
+	sort.Strings(roles)
+	return slices.Compact(roles)
+}
+
+// SyncRoles replaces the roles synced previously with the mapped roles.
+// Roles which are not synced previously are kept as they are and they are not marked as synced.
+// It returns the new roles and the role names to be recorded as synced.
+func SyncRoles(current []cosmov1alpha1.UserRole, prevSynced, mapped []string) ([]cosmov1alpha1.UserRole, []string) {
+	roles := make([]cosmov1alpha1.UserRole, 0, len(current)+len(mapped))
+	manual := make([]string, 0, len(current))
+	for _, r := range current {
+		if slices.Contains(prevSynced, r.Name) {
+			continue
+		}
+		roles = append(roles, r)
+		manual = append(manual, r.Name)
+	}
+
+	synced := make([]string, 0, len(mapped))
+	for _, r := range mapped {
+		if r == "" || slices.Contains(manual, r) || slices.Contains(synced, r) {
+			continue
+		}
+		roles = append(roles, cosmov1alpha1.UserRole{Name: r})
+		synced = append(synced, r)
+	}
+	return roles, synced
+}
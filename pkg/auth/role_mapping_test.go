@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func TestNewRoleMapper(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings []RoleMapping
+		wantErr  bool
+	}{
+		{
+			name: "✅ group and groupPattern",
+			mappings: []RoleMapping{
+				{Group: "cn=admins,dc=example,dc=com", Roles: []string{"cosmo-admin"}},
+				{GroupPattern: "^cn=(.+)-admins,", Roles: []string{"$1-admin"}},
+			},
+		},
+		{
+			name:     "❌ both group and groupPattern",
+			mappings: []RoleMapping{{Group: "a", GroupPattern: "a", Roles: []string{"a"}}},
+			wantErr:  true,
+		},
+		{
+			name:     "❌ neither group nor groupPattern",
+			mappings: []RoleMapping{{Roles: []string{"a"}}},
+			wantErr:  true,
+		},
+		{
+			name:     "❌ no roles",
+			mappings: []RoleMapping{{Group: "a"}},
+			wantErr:  true,
+		},
+		{
+			name:     "❌ invalid groupPattern",
+			mappings: []RoleMapping{{GroupPattern: "(", Roles: []string{"a"}}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRoleMapper(tt.mappings)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRoleMapper() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoleMapper_Roles(t *testing.T) {
+	m, err := NewRoleMapper([]RoleMapping{
+		{Group: "cn=cosmo-admins,ou=groups,dc=example,dc=com", Roles: []string{"cosmo-admin"}},
+		{GroupPattern: "^cn=(.+)-admins,ou=groups,", Roles: []string{"$1-admin", "$1-developer"}},
+		{GroupPattern: "^cn=(.+)-developers,ou=groups,", Roles: []string{"${1}-developer"}},
+		{Group: "platform", Roles: []string{"platform"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		groups []string
+		want   []string
+	}{
+		{
+			name:   "✅ exact group",
+			groups: []string{"platform"},
+			want:   []string{"platform"},
+		},
+		{
+			name: "✅ group pattern with submatch",
+			groups: []string{
+				"cn=teama-admins,ou=groups,dc=example,dc=com",
+				"cn=teamb-developers,ou=groups,dc=example,dc=com",
+			},
+			want: []string{"teama-admin", "teama-developer", "teamb-developer"},
+		},
+		{
+			name: "✅ duplicated roles are compacted",
+			groups: []string{
+				"cn=teama-admins,ou=groups,dc=example,dc=com",
+				"cn=teama-developers,ou=groups,dc=example,dc=com",
+			},
+			want: []string{"teama-admin", "teama-developer"},
+		},
+		{
+			name: "✅ exact group and pattern both match",
+			groups: []string{
+				"cn=cosmo-admins,ou=groups,dc=example,dc=com",
+			},
+			want: []string{"cosmo-admin", "cosmo-developer"},
+		},
+		{
+			name:   "✅ no match",
+			groups: []string{"unknown"},
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.Roles(tt.groups)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("RoleMapper.Roles() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadRoleMapper(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	os.WriteFile(valid, []byte(`mappings:
+- group: platform
+  roles: [platform]
+- groupPattern: "^(.+)-admins$"
+  roles: ["$1-admin"]
+`), 0644)
+	unknownField := filepath.Join(dir, "unknown.yaml")
+	os.WriteFile(unknownField, []byte(`mappings:
+- groups: platform
+  roles: [platform]
+`), 0644)
+
+	m, err := LoadRoleMapper(valid)
+	if err != nil {
+		t.Fatalf("LoadRoleMapper() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"platform", "teama-admin"}, m.Roles([]string{"platform", "teama-admins"})); diff != "" {
+		t.Errorf("RoleMapper.Roles() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := LoadRoleMapper(unknownField); err == nil {
+		t.Errorf("LoadRoleMapper() expected error for unknown field")
+	}
+	if _, err := LoadRoleMapper(filepath.Join(dir, "notfound.yaml")); err == nil {
+		t.Errorf("LoadRoleMapper() expected error for missing file")
+	}
+}
+
+func TestSyncRoles(t *testing.T) {
+	roles := func(names ...string) []cosmov1alpha1.UserRole {
+		r := make([]cosmov1alpha1.UserRole, len(names))
+		for i, n := range names {
+			r[i] = cosmov1alpha1.UserRole{Name: n}
+		}
+		return r
+	}
+	tests := []struct {
+		name       string
+		current    []cosmov1alpha1.UserRole
+		prevSynced []string
+		mapped     []string
+		wantRoles  []cosmov1alpha1.UserRole
+		wantSynced []string
+	}{
+		{
+			name:       "✅ first sync appends mapped roles",
+			current:    roles("manual"),
+			prevSynced: nil,
+			mapped:     []string{"teama-admin"},
+			wantRoles:  roles("manual", "teama-admin"),
+			wantSynced: []string{"teama-admin"},
+		},
+		{
+			name:       "✅ synced roles no longer mapped are removed",
+			current:    roles("manual", "teama-admin", "teamb-developer"),
+			prevSynced: []string{"teama-admin", "teamb-developer"},
+			mapped:     []string{"teamb-developer"},
+			wantRoles:  roles("manual", "teamb-developer"),
+			wantSynced: []string{"teamb-developer"},
+		},
+		{
+			name:       "✅ manual role is kept and not marked as synced",
+			current:    roles("teama-admin"),
+			prevSynced: nil,
+			mapped:     []string{"teama-admin"},
+			wantRoles:  roles("teama-admin"),
+			wantSynced: []string{},
+		},
+		{
+			name:       "✅ no mapped roles removes all synced roles",
+			current:    roles("manual", "teama-admin"),
+			prevSynced: []string{"teama-admin"},
+			mapped:     []string{},
+			wantRoles:  roles("manual"),
+			wantSynced: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRoles, gotSynced := SyncRoles(tt.current, tt.prevSynced, tt.mapped)
+			if diff := cmp.Diff(tt.wantRoles, gotRoles); diff != "" {
+				t.Errorf("SyncRoles() roles mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantSynced, gotSynced); diff != "" {
+				t.Errorf("SyncRoles() synced mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	PasswordReviewAPIVersion = "authentication.cosmo-workspace.github.io/v1alpha1"
+	PasswordReviewKind       = "PasswordReview"
+
+	webhookNegativeCacheMaxEntries = 10000
+)
+
+// PasswordReview is a request and response body of the webhook authentication,
+// which is similar to Kubernetes TokenReview.
+//
+//	request:  {"apiVersion": "authentication.cosmo-workspace.github.io/v1alpha1", "kind": "PasswordReview", "spec": {"username": "tom", "password": "xxx"}}
+//	response: {"apiVersion": "authentication.cosmo-workspace.github.io/v1alpha1", "kind": "PasswordReview", "status": {"authenticated": true, "user": {"username": "tom", "groups": ["developers"]}}}
+type PasswordReview struct {
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Spec       PasswordReviewSpec   `json:"spec,omitempty"`
+	Status     PasswordReviewStatus `json:"status,omitempty"`
+}
+
+type PasswordReviewSpec struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type PasswordReviewStatus struct {
+	Authenticated bool               `json:"authenticated"`
+	User          PasswordReviewUser `json:"user,omitempty"`
+	Error         string             `json:"error,omitempty"`
+}
+
+type PasswordReviewUser struct {
+	Username string   `json:"username,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// WebhookAuthorizer authorize by POSTing PasswordReview to the webhook endpoint
+type WebhookAuthorizer struct {
+	URL        string
+	TlsConfig  *tls.Config
+	Timeout    time.Duration
+	HTTPClient *http.Client // used if not nil instead of the client built from TlsConfig and Timeout
+
+	// NegativeCacheTTL is a duration to cache the failed authentication result not to call the webhook.
+	// Zero disables the cache.
+	NegativeCacheTTL time.Duration
+
+	once          sync.Once
+	client        *http.Client
+	mu            sync.Mutex
+	negativeCache map[string]time.Time
+	now           func() time.Time
+}
+
+func (a *WebhookAuthorizer) init() {
+	a.once.Do(func() {
+		a.client = a.HTTPClient
+		if a.client == nil {
+			a.client = &http.Client{
+				Timeout:   a.Timeout,
+				Transport: &http.Transport{TLSClientConfig: a.TlsConfig},
+			}
+		}
+		a.negativeCache = make(map[string]time.Time)
+		if a.now == nil {
+			a.now = time.Now
+		}
+	})
+}
+
+func (a *WebhookAuthorizer) Authorize(ctx context.Context, msg AuthRequest) (bool, error) {
+	verified, _, err := a.AuthorizeWithGroups(ctx, msg)
+	return verified, err
+}
+
+// AuthorizeWithGroups authorize the user and returns the groups in the webhook response
+func (a *WebhookAuthorizer) AuthorizeWithGroups(ctx context.Context, msg AuthRequest) (bool, []string, error) {
+	a.init()
+
+	key := negativeCacheKey(msg)
+	if a.isNegativeCached(key) {
+		return false, nil, fmt.Errorf("authentication failed (cached)")
+	}
+
+	review, err := a.review(ctx, msg)
+	if err != nil {
+		return false, nil, err
+	}
+	if !review.Status.Authenticated {
+		a.setNegativeCache(key)
+		if review.Status.Error != "" {
+			return false, nil, fmt.Errorf("authentication failed: %s", review.Status.Error)
+		}
+		return false, nil, nil
+	}
+	if u := review.Status.User.Username; u != "" && u != msg.GetUserName() {
+		return false, nil, fmt.Errorf("username mismatch in webhook response: %s", u)
+	}
+	return true, review.Status.User.Groups, nil
+}
+
+func (a *WebhookAuthorizer) review(ctx context.Context, msg AuthRequest) (*PasswordReview, error) {
+	body, err := json.Marshal(PasswordReview{
+		APIVersion: PasswordReviewAPIVersion,
+		Kind:       PasswordReviewKind,
+		Spec: PasswordReviewSpec{
+			Username: msg.GetUserName(),
+			Password: msg.GetPassword(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("webhook returned unexpected status: %d", res.StatusCode)
+	}
+
+	var review PasswordReview
+	if err := json.Unmarshal(resBody, &review); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook response: %w", err)
+	}
+	if review.APIVersion != PasswordReviewAPIVersion || review.Kind != PasswordReviewKind {
+		return nil, fmt.Errorf("unexpected webhook response: apiVersion=%s kind=%s", review.APIVersion, review.Kind)
+	}
+	return &review, nil
+}
+
+func (a *WebhookAuthorizer) isNegativeCached(key string) bool {
+	if a.NegativeCacheTTL <= 0 {
+		return false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	exp, ok := a.negativeCache[key]
+	if !ok {
+		return false
+	}
+	if a.now().After(exp) {
+		delete(a.negativeCache, key)
+		return false
+	}
+	return true
+}
+
+func (a *WebhookAuthorizer) setNegativeCache(key string) {
+	if a.NegativeCacheTTL <= 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	if len(a.negativeCache) >= webhookNegativeCacheMaxEntries {
+		for k, exp := range a.negativeCache {
+			if now.After(exp) {
+				delete(a.negativeCache, k)
+			}
+		}
+		if len(a.negativeCache) >= webhookNegativeCacheMaxEntries {
+			a.negativeCache = make(map[string]time.Time)
+		}
+	}
+	a.negativeCache[key] = now.Add(a.NegativeCacheTTL)
+}
+
+// negativeCacheKey returns the hash of the credential not to keep the raw password in memory
+func negativeCacheKey(msg AuthRequest) string {
+	h := sha256.New()
+	h.Write([]byte(msg.GetUserName()))
+	h.Write([]byte{0})
+	h.Write([]byte(msg.GetPassword()))
+	return hex.EncodeToString(h.Sum(nil))
+}
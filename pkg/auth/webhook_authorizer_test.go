@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestWebhookServer(t *testing.T, calls *int32, handler func(PasswordReview) (int, interface{})) (*httptest.Server, *tls.Config) {
+	t.Helper()
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		var req PasswordReview
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&req) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		status, body := handler(req)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(ts.Close)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ts.Certificate())
+	return ts, &tls.Config{RootCAs: pool}
+}
+
+func passwordReviewHandler(req PasswordReview) (int, interface{}) {
+	res := PasswordReview{APIVersion: PasswordReviewAPIVersion, Kind: PasswordReviewKind}
+	switch {
+	case req.Spec.Username == "tom" && req.Spec.Password == "secret":
+		res.Status = PasswordReviewStatus{Authenticated: true, User: PasswordReviewUser{Username: "tom", Groups: []string{"developers"}}}
+	case req.Spec.Username == "mismatch":
+		res.Status = PasswordReviewStatus{Authenticated: true, User: PasswordReviewUser{Username: "other"}}
+	case req.Spec.Username == "error":
+		return http.StatusInternalServerError, map[string]string{"message": "error"}
+	case req.Spec.Username == "invalid":
+		return http.StatusOK, map[string]string{"kind": "Unknown"}
+	case req.Spec.Username == "locked":
+		res.Status = PasswordReviewStatus{Authenticated: false, Error: "account is locked"}
+	default:
+		res.Status = PasswordReviewStatus{Authenticated: false}
+	}
+	return http.StatusOK, res
+}
+
+func TestWebhookAuthorizer_AuthorizeWithGroups(t *testing.T) {
+	tests := []struct {
+		name         string
+		msg          AuthRequest
+		wantVerified bool
+		wantGroups   []string
+		wantErr      bool
+	}{
+		{
+			name:         "✅ authenticated",
+			msg:          NewIdPass("tom", "secret"),
+			wantVerified: true,
+			wantGroups:   []string{"developers"},
+		},
+		{
+			name:         "❌ not authenticated",
+			msg:          NewIdPass("tom", "invalid"),
+			wantVerified: false,
+		},
+		{
+			name:         "❌ not authenticated with error",
+			msg:          NewIdPass("locked", "secret"),
+			wantVerified: false,
+			wantErr:      true,
+		},
+		{
+			name:         "❌ username mismatch",
+			msg:          NewIdPass("mismatch", "secret"),
+			wantVerified: false,
+			wantErr:      true,
+		},
+		{
+			name:         "❌ unexpected status",
+			msg:          NewIdPass("error", "secret"),
+			wantVerified: false,
+			wantErr:      true,
+		},
+		{
+			name:         "❌ unexpected response",
+			msg:          NewIdPass("invalid", "secret"),
+			wantVerified: false,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			ts, tlsConfig := newTestWebhookServer(t, &calls, passwordReviewHandler)
+			a := &WebhookAuthorizer{URL: ts.URL, TlsConfig: tlsConfig, Timeout: 5 * time.Second}
+
+			verified, groups, err := a.AuthorizeWithGroups(context.TODO(), tt.msg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AuthorizeWithGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if verified != tt.wantVerified {
+				t.Errorf("AuthorizeWithGroups() verified = %v, want %v", verified, tt.wantVerified)
+			}
+			if diff := cmp.Diff(tt.wantGroups, groups); diff != "" {
+				t.Errorf("AuthorizeWithGroups() groups mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWebhookAuthorizer_UnknownCA(t *testing.T) {
+	var calls int32
+	ts, _ := newTestWebhookServer(t, &calls, passwordReviewHandler)
+	a := &WebhookAuthorizer{URL: ts.URL, Timeout: 5 * time.Second}
+
+	if verified, err := a.Authorize(context.TODO(), NewIdPass("tom", "secret")); err == nil || verified {
+		t.Errorf("Authorize() verified = %v, err = %v, want error", verified, err)
+	}
+}
+
+func TestWebhookAuthorizer_NegativeCache(t *testing.T) {
+	var calls int32
+	ts, tlsConfig := newTestWebhookServer(t, &calls, passwordReviewHandler)
+
+	now := time.Now()
+	a := &WebhookAuthorizer{
+		URL:              ts.URL,
+		TlsConfig:        tlsConfig,
+		Timeout:          5 * time.Second,
+		NegativeCacheTTL: time.Minute,
+		now:              func() time.Time { return now },
+	}
+	ctx := context.TODO()
+
+	// failed result is cached
+	for i := 0; i < 3; i++ {
+		if verified, _ := a.Authorize(ctx, NewIdPass("tom", "invalid")); verified {
+			t.Fatalf("Authorize() verified with invalid password")
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("webhook calls = %d, want 1", got)
+	}
+
+	// other credential is not affected by the cache
+	if verified, err := a.Authorize(ctx, NewIdPass("tom", "secret")); !verified || err != nil {
+		t.Errorf("Authorize() verified = %v, err = %v", verified, err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("webhook calls = %d, want 2", got)
+	}
+
+	// cache is expired
+	now = now.Add(2 * time.Minute)
+	a.Authorize(ctx, NewIdPass("tom", "invalid"))
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("webhook calls = %d, want 3", got)
+	}
+
+	// errors are not cached
+	a.Authorize(ctx, NewIdPass("error", "secret"))
+	a.Authorize(ctx, NewIdPass("error", "secret"))
+	if got := atomic.LoadInt32(&calls); got != 5 {
+		t.Errorf("webhook calls = %d, want 5", got)
+	}
+}
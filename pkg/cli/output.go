@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"k8s.io/cli-runtime/pkg/printers"
+
+	dashv1alpha1 "github.com/cosmo-workspace/cosmo/proto/gen/dashboard/v1alpha1"
 )
 
 func OutputTable(output io.Writer, headers []string, data [][]string) {
@@ -17,3 +19,18 @@ func OutputTable(output io.Writer, headers []string, data [][]string) {
 		fmt.Fprintf(w, "%s\n", strings.Join(v, "\t"))
 	}
 }
+
+// ConditionString returns the status of the condition type to show in the table.
+// The reason is appended if the status is not True.
+func ConditionString(conditions []*dashv1alpha1.Condition, condType string) string {
+	for _, c := range conditions {
+		if c.Type != condType {
+			continue
+		}
+		if c.Status == "True" || c.Reason == "" {
+			return c.Status
+		}
+		return fmt.Sprintf("%s(%s)", c.Status, c.Reason)
+	}
+	return "Unknown"
+}
@@ -16,7 +16,11 @@ func Mutate(inst cosmov1alpha1.InstanceObject, tmpl cosmov1alpha1.TemplateObject
 	}
 
 	// defaulting required vars
+	// secret vars are defaulted on build not to mix with the value in the Secret
 	for _, v := range tmplSpec.RequiredVars {
+		if v.Secret {
+			continue
+		}
 		found := false
 		for key := range instSpec.Vars {
 			if template.FixupTemplateVarKey(key) == template.FixupTemplateVarKey(v.Var) {
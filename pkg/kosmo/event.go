@@ -118,7 +118,7 @@ func regardingPriority(ref corev1.ObjectReference) int {
 	case "traefik.io/v1alpha1", "traefik.io/v1":
 		return 10
 	case "apps/v1":
-		if ref.Kind == "Deployment" {
+		if ref.Kind == "Deployment" || ref.Kind == "StatefulSet" {
 			return 20
 		}
 		return 30
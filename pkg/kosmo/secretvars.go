@@ -0,0 +1,122 @@
+package kosmo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+	"github.com/cosmo-workspace/cosmo/pkg/template"
+)
+
+// splitWorkspaceVars splits the vars into the plain vars in the workspace spec and the secret vars stored in the Secret.
+// The values of the secret vars are validated here as they are not seen by the webhook.
+// The redacted values are replaced with the current values in the Secret.
+func (c *Client) splitWorkspaceVars(ctx context.Context, tmplName string, vars map[string]string, current map[string]string) (plain, secret map[string]string, err error) {
+	tmpl := cosmov1alpha1.Template{}
+	if err := c.Get(ctx, types.NamespacedName{Name: tmplName}, &tmpl); err != nil {
+		return nil, nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	plain, secret = template.SplitSecretVars(tmpl.Spec.RequiredVars, vars)
+	for key, val := range secret {
+		if val == template.RedactedSecretVarValue {
+			if cur, ok := current[key]; ok {
+				secret[key] = cur
+			} else {
+				return nil, nil, apierrs.NewBadRequest(fmt.Sprintf("secret var %s is not set", key))
+			}
+		}
+	}
+	if err := template.ValidateVars(tmpl.Spec.RequiredVars, secret); err != nil {
+		return nil, nil, apierrs.NewBadRequest(err.Error())
+	}
+	return plain, secret, nil
+}
+
+// getSecretVars returns the current values of the secret vars of the workspace
+func (c *Client) getSecretVars(ctx context.Context, ws *cosmov1alpha1.Workspace) (map[string]string, error) {
+	if ws.Spec.SecretVarsRef == nil {
+		return nil, nil
+	}
+	secret := corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ws.Spec.SecretVarsRef.Name, Namespace: ws.Namespace}, &secret); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get secret vars: %w", err)
+	}
+	vars := make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		vars[k] = string(v)
+	}
+	return vars, nil
+}
+
+// applySecretVars creates or updates the Secret which stores the secret vars of the workspace.
+// The Secret is owned by the workspace to be deleted with it.
+func (c *Client) applySecretVars(ctx context.Context, ws *cosmov1alpha1.Workspace, vars map[string]string) error {
+	log := clog.FromContext(ctx).WithCaller()
+
+	secret := &corev1.Secret{}
+	secret.SetName(ws.Spec.SecretVarsRef.Name)
+	secret.SetNamespace(ws.Namespace)
+
+	op, err := controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+		kubeutil.SetLabel(secret, cosmov1alpha1.LabelKeyWorkspaceName, ws.Name)
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = make(map[string][]byte, len(vars))
+		for k, v := range vars {
+			secret.Data[k] = []byte(v)
+		}
+		return controllerutil.SetControllerReference(ws, secret, c.Scheme())
+	})
+	if err != nil {
+		log.Error(err, "failed to apply secret vars", "workspace", ws.Name, "namespace", ws.Namespace)
+		return fmt.Errorf("failed to apply secret vars: %w", err)
+	}
+	log.Debug().Info("secret vars applied", "workspace", ws.Name, "namespace", ws.Namespace, "operation", op)
+	return nil
+}
+
+// rollbackSecretVars restores the Secret of the secret vars to the values before the failed update of the workspace.
+// The Secret is deleted if it did not exist.
+func (c *Client) rollbackSecretVars(ctx context.Context, before *cosmov1alpha1.Workspace, vars map[string]string) {
+	log := clog.FromContext(ctx).WithCaller()
+
+	if before.Spec.SecretVarsRef != nil && vars != nil {
+		if err := c.applySecretVars(ctx, before, vars); err != nil {
+			log.Error(err, "failed to rollback secret vars", "workspace", before.Name, "namespace", before.Namespace)
+		}
+		return
+	}
+	secret := &corev1.Secret{}
+	secret.SetName(cosmov1alpha1.WorkspaceSecretVarsName(before.Name))
+	secret.SetNamespace(before.Namespace)
+	if err := c.Delete(ctx, secret); err != nil && !apierrs.IsNotFound(err) {
+		log.Error(err, "failed to rollback secret vars", "workspace", before.Name, "namespace", before.Namespace)
+	}
+}
+
+// secretVarsRef returns the reference to the Secret of the secret vars. nil if no secret vars.
+func secretVarsRef(wsName string, vars map[string]string) *cosmov1alpha1.SecretVarsReference {
+	if len(vars) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return &cosmov1alpha1.SecretVarsReference{
+		Name: cosmov1alpha1.WorkspaceSecretVarsName(wsName),
+		Vars: keys,
+	}
+}
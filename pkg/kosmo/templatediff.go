@@ -0,0 +1,174 @@
+package kosmo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+	"github.com/cosmo-workspace/cosmo/pkg/template"
+	"github.com/cosmo-workspace/cosmo/pkg/transformer"
+)
+
+// InstanceControllerFieldManager is the field manager used by the instance controller to apply child objects.
+// The dry-run diff uses the same field manager so that managedFields are not reported as changed.
+const InstanceControllerFieldManager = "cosmo-instance-controller"
+
+// redactedChangedValue replaces the values of Secret data changed by the template in the diff
+const redactedChangedValue = template.RedactedSecretVarValue + " (changed)"
+
+// InstanceDiff is the result of dry-run diff of a Template for an Instance
+type InstanceDiff struct {
+	Instance cosmov1alpha1.Instance
+	Objects  []ObjectDiff
+	Error    error
+}
+
+// ObjectDiff is the result of dry-run diff of a child object of an Instance
+type ObjectDiff struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	// Diff is the difference between the live object and the dry-run applied object. Empty if no change.
+	Diff string
+	// Created is true if the object does not exist and will be created
+	Created bool
+	Error   error
+}
+
+// Changed returns whether the object will be changed by the template
+func (d ObjectDiff) Changed() bool {
+	return d.Created || d.Diff != ""
+}
+
+// DiffTemplate dry-runs the template against all the existing Instances of the template on server-side
+// and returns the differences from the live objects.
+// Instances pinned to a revision are skipped as they are not affected by the change of the template.
+func (c *Client) DiffTemplate(ctx context.Context, tmpl *cosmov1alpha1.Template, domain string) ([]InstanceDiff, error) {
+	log := clog.FromContext(ctx).WithCaller()
+
+	instList := cosmov1alpha1.InstanceList{}
+	if err := c.List(ctx, &instList); err != nil {
+		log.Error(err, "failed to list instances")
+		return nil, fmt.Errorf("failed to list instances: %w", err)
+	}
+
+	diffs := make([]InstanceDiff, 0)
+	for _, inst := range instList.Items {
+		if inst.Spec.Template.Name != tmpl.Name || inst.Spec.Template.Revision != "" {
+			continue
+		}
+		diffs = append(diffs, c.diffInstance(ctx, tmpl, inst, domain))
+	}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Instance.Namespace != diffs[j].Instance.Namespace {
+			return diffs[i].Instance.Namespace < diffs[j].Instance.Namespace
+		}
+		return diffs[i].Instance.Name < diffs[j].Instance.Name
+	})
+	return diffs, nil
+}
+
+func (c *Client) diffInstance(ctx context.Context, tmpl *cosmov1alpha1.Template, inst cosmov1alpha1.Instance, domain string) InstanceDiff {
+	log := clog.FromContext(ctx).WithCaller().WithValues("instance", inst.Name, "namespace", inst.Namespace)
+	diff := InstanceDiff{Instance: inst}
+
+	secretVars, err := template.GetSecretVars(ctx, c, &inst, tmpl.Spec)
+	if err != nil {
+		diff.Error = err
+		return diff
+	}
+	objects, err := template.BuildObjects(tmpl, &inst, domain, secretVars)
+	if err != nil {
+		diff.Error = fmt.Errorf("failed to build objects: %w", err)
+		return diff
+	}
+	objects, err = transformer.ApplyTransformers(ctx, transformer.AllTransformers(&inst, c.Scheme(), tmpl), objects)
+	if err != nil {
+		diff.Error = fmt.Errorf("failed to transform objects: %w", err)
+		return diff
+	}
+
+	diff.Objects = make([]ObjectDiff, len(objects))
+	for i, built := range objects {
+		diff.Objects[i] = c.diffObject(ctx, built, secretVars)
+		if err := diff.Objects[i].Error; err != nil {
+			log.Debug().Info("dryrun failed", "kind", built.GetKind(), "name", built.GetName(), "error", err)
+		}
+	}
+	return diff
+}
+
+func (c *Client) diffObject(ctx context.Context, built unstructured.Unstructured, secretVars map[string]string) ObjectDiff {
+	d := ObjectDiff{APIVersion: built.GetAPIVersion(), Kind: built.GetKind(), Name: built.GetName()}
+
+	current, err := kubeutil.GetUnstructured(ctx, c, built.GroupVersionKind(), built.GetName(), built.GetNamespace())
+	if err != nil && !apierrs.IsNotFound(err) {
+		d.Error = fmt.Errorf("failed to get resource: %w", err)
+		return d
+	}
+
+	desired, err := kubeutil.Apply(ctx, c, &built, InstanceControllerFieldManager, true, true)
+	if err != nil {
+		d.Error = fmt.Errorf("dryrun failed: %w", err)
+		return d
+	}
+
+	if current == nil {
+		d.Created = true
+		return d
+	}
+
+	redactSecretData(current, desired)
+
+	var buf bytes.Buffer
+	if !kubeutil.LooseDeepEqual(current, desired, kubeutil.WithPrintDiff(&buf)) {
+		d.Diff = redactSecretVars(buf.String(), secretVars)
+	}
+	return d
+}
+
+// redactSecretData replaces the values of Secret data not to print them in the diff.
+// The values changed by the template are replaced with the different placeholder to show the change.
+func redactSecretData(current, desired *unstructured.Unstructured) {
+	if desired.GetAPIVersion() != "v1" || desired.GetKind() != "Secret" {
+		return
+	}
+	cur, _, _ := unstructured.NestedStringMap(current.Object, "data")
+	des, found, _ := unstructured.NestedStringMap(desired.Object, "data")
+	if found {
+		redacted := make(map[string]interface{}, len(des))
+		for k, v := range des {
+			if cv, ok := cur[k]; ok && cv == v {
+				redacted[k] = template.RedactedSecretVarValue
+			} else {
+				redacted[k] = redactedChangedValue
+			}
+		}
+		desired.Object["data"] = redacted
+	}
+	if cur != nil {
+		redacted := make(map[string]interface{}, len(cur))
+		for k := range cur {
+			redacted[k] = template.RedactedSecretVarValue
+		}
+		current.Object["data"] = redacted
+	}
+}
+
+// redactSecretVars replaces the values of secret vars embedded in the objects
+func redactSecretVars(diff string, secretVars map[string]string) string {
+	for _, v := range secretVars {
+		if v != "" {
+			diff = strings.ReplaceAll(diff, v, template.RedactedSecretVarValue)
+		}
+	}
+	return diff
+}
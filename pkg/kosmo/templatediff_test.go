@@ -0,0 +1,134 @@
+package kosmo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/template"
+)
+
+func TestClient_DiffTemplate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cosmov1alpha1.AddToScheme(scheme))
+
+	ns := cosmov1alpha1.UserNamespace("tom")
+	tmpl := &cosmov1alpha1.Template{
+		ObjectMeta: metav1.ObjectMeta{Name: "tmpl1"},
+		Spec: cosmov1alpha1.TemplateSpec{
+			RequiredVars: []cosmov1alpha1.RequiredVarSpec{{Var: "{{PASSWORD}}", Secret: true}},
+			RawYaml: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  image: v2
+  password: "{{PASSWORD}}"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+data:
+  changed: bmV3
+  same: c2FtZQ==
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: created
+`,
+		},
+	}
+	inst := func(name, revision string) *cosmov1alpha1.Instance {
+		return &cosmov1alpha1.Instance{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec: cosmov1alpha1.InstanceSpec{
+				Template:      cosmov1alpha1.TemplateRef{Name: "tmpl1", Revision: revision},
+				SecretVarsRef: &cosmov1alpha1.SecretVarsReference{Name: name + "-vars"},
+			},
+		}
+	}
+	vars := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1-vars", Namespace: ns},
+		Data:       map[string][]byte{"PASSWORD": []byte("p@ssw0rd")},
+	}
+	liveConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1-config", Namespace: ns},
+		Data:       map[string]string{"image": "v1", "password": "p@ssw0rd"},
+	}
+	liveSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1-secret", Namespace: ns},
+		Data:       map[string][]byte{"changed": []byte("old"), "same": []byte("same")},
+	}
+
+	// fake client does not support server-side apply. dry-run apply returns the applied object as is.
+	dryrunApply := interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			o := &client.PatchOptions{}
+			o.ApplyOptions(opts)
+			if patch.Type() != types.ApplyPatchType || len(o.DryRun) == 0 {
+				t.Errorf("unexpected patch: type=%s dryrun=%v", patch.Type(), o.DryRun)
+			}
+			return nil
+		},
+	}
+
+	c := NewClient(fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(inst("ws1", ""), inst("ws2", "tmpl1-1"), vars, liveConfig, liveSecret).
+		WithInterceptorFuncs(dryrunApply).
+		Build())
+
+	diffs, err := c.DiffTemplate(context.TODO(), tmpl, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 1 || diffs[0].Instance.Name != "ws1" {
+		t.Fatalf("pinned instance is not skipped: %v", diffs)
+	}
+	if diffs[0].Error != nil {
+		t.Fatal(diffs[0].Error)
+	}
+
+	got := make(map[string]ObjectDiff)
+	for _, d := range diffs[0].Objects {
+		if d.Error != nil {
+			t.Errorf("%s %s: %v", d.Kind, d.Name, d.Error)
+		}
+		got[d.Kind+"/"+d.Name] = d
+	}
+
+	config := got["ConfigMap/ws1-config"]
+	if !config.Changed() || !strings.Contains(config.Diff, "v2") {
+		t.Errorf("ConfigMap change is not reported: %s", config.Diff)
+	}
+	if strings.Contains(config.Diff, "p@ssw0rd") || !strings.Contains(config.Diff, template.RedactedSecretVarValue) {
+		t.Errorf("secret var is not redacted: %s", config.Diff)
+	}
+
+	secret := got["Secret/ws1-secret"]
+	if !secret.Changed() || !strings.Contains(secret.Diff, redactedChangedValue) {
+		t.Errorf("Secret change is not reported: %s", secret.Diff)
+	}
+	for _, v := range []string{"bmV3", "b2xk", "c2FtZQ=="} {
+		if strings.Contains(secret.Diff, v) {
+			t.Errorf("Secret data %s is not redacted: %s", v, secret.Diff)
+		}
+	}
+
+	if created := got["ConfigMap/ws1-created"]; !created.Created {
+		t.Errorf("created object is not reported: %v", created)
+	}
+}
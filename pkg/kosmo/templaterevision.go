@@ -0,0 +1,74 @@
+package kosmo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/template"
+)
+
+// ListTemplateRevisions returns the revisions of the template sorted by the revision number
+func (c *Client) ListTemplateRevisions(ctx context.Context, tmplName string) ([]cosmov1alpha1.TemplateRevision, error) {
+	log := clog.FromContext(ctx).WithCaller()
+
+	revs, err := template.ListTemplateRevisions(ctx, c, tmplName)
+	if err != nil {
+		log.Error(err, "failed to list template revisions", "template", tmplName)
+		return nil, fmt.Errorf("failed to list template revisions: %w", err)
+	}
+	return revs, nil
+}
+
+// GetTemplateRevision returns the revision of the template.
+// The revision can be specified by the number or the name of TemplateRevision.
+// The latest revision is returned if revision is empty.
+func (c *Client) GetTemplateRevision(ctx context.Context, tmplName, revision string) (*cosmov1alpha1.TemplateRevision, error) {
+	revs, err := c.ListTemplateRevisions(ctx, tmplName)
+	if err != nil {
+		return nil, err
+	}
+	if len(revs) == 0 {
+		return nil, apierrs.NewBadRequest(fmt.Sprintf("template %s has no revision. annotate %s=true on the template to record revisions", tmplName, cosmov1alpha1.TemplateAnnKeyPinRevision))
+	}
+	if revision == "" {
+		return &revs[len(revs)-1], nil
+	}
+
+	for _, rev := range revs {
+		if rev.Name == revision || strconv.FormatInt(rev.Spec.Revision, 10) == revision {
+			return rev.DeepCopy(), nil
+		}
+	}
+	return nil, apierrs.NewNotFound(cosmov1alpha1.GroupVersion.WithResource("templaterevisions").GroupResource(), revision)
+}
+
+// ListWorkspacesByTemplate returns all workspaces using the template sorted by the namespace and the name
+func (c *Client) ListWorkspacesByTemplate(ctx context.Context, tmplName string) ([]cosmov1alpha1.Workspace, error) {
+	log := clog.FromContext(ctx).WithCaller()
+
+	wsList := cosmov1alpha1.WorkspaceList{}
+	if err := c.List(ctx, &wsList); err != nil {
+		log.Error(err, "failed to list workspaces")
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	wss := make([]cosmov1alpha1.Workspace, 0)
+	for _, ws := range wsList.Items {
+		if ws.Spec.Template.Name == tmplName {
+			wss = append(wss, ws)
+		}
+	}
+	sort.SliceStable(wss, func(i, j int) bool {
+		if wss[i].Namespace != wss[j].Namespace {
+			return wss[i].Namespace < wss[j].Namespace
+		}
+		return wss[i].Name < wss[j].Name
+	})
+	return wss, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -11,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/auth"
 	"github.com/cosmo-workspace/cosmo/pkg/clog"
 	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
 )
@@ -182,3 +184,39 @@ func (c *Client) UpdateUser(ctx context.Context, username string, opts UpdateUse
 
 	return user, nil
 }
+
+// SyncUserRoles replaces the roles synced from external groups previously with the given roles.
+// Roles assigned manually are kept as they are.
+func (c *Client) SyncUserRoles(ctx context.Context, username string, roles []string) (*cosmov1alpha1.User, error) {
+	logr := clog.FromContext(ctx).WithCaller()
+
+	user, err := c.GetUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	before := user.DeepCopy()
+
+	var prevSynced []string
+	if v := kubeutil.GetAnnotation(user, cosmov1alpha1.UserAnnKeySyncedRoles); v != "" {
+		prevSynced = strings.Split(v, ",")
+	}
+	newRoles, synced := auth.SyncRoles(user.Spec.Roles, prevSynced, roles)
+	user.Spec.Roles = newRoles
+	if len(synced) > 0 {
+		kubeutil.SetAnnotation(user, cosmov1alpha1.UserAnnKeySyncedRoles, strings.Join(synced, ","))
+	} else if ann := user.GetAnnotations(); ann != nil {
+		delete(ann, cosmov1alpha1.UserAnnKeySyncedRoles)
+	}
+
+	if equality.Semantic.DeepEqual(before, user) {
+		logr.Debug().Info("no change in synced roles", "user", username)
+		return user, nil
+	}
+	logr.Info("syncing user roles", "user", username, "before", before.Spec.Roles, "after", user.Spec.Roles)
+
+	if err := c.Update(ctx, user); err != nil {
+		logr.Error(err, "failed to update user", "user", user)
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,72 @@
+package kosmo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+)
+
+func TestClient_SyncUserRoles(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cosmov1alpha1.AddToScheme(scheme))
+
+	tests := []struct {
+		name       string
+		user       *cosmov1alpha1.User
+		roles      []string
+		wantRoles  []cosmov1alpha1.UserRole
+		wantSynced string
+	}{
+		{
+			name: "✅ sync roles and record synced roles",
+			user: &cosmov1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{Name: "ldap-user"},
+				Spec:       cosmov1alpha1.UserSpec{Roles: []cosmov1alpha1.UserRole{{Name: "manual"}}},
+			},
+			roles:      []string{"teama-admin"},
+			wantRoles:  []cosmov1alpha1.UserRole{{Name: "manual"}, {Name: "teama-admin"}},
+			wantSynced: "teama-admin",
+		},
+		{
+			name: "✅ remove synced roles",
+			user: &cosmov1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ldap-user",
+					Annotations: map[string]string{cosmov1alpha1.UserAnnKeySyncedRoles: "teama-admin,teamb-admin"},
+				},
+				Spec: cosmov1alpha1.UserSpec{Roles: []cosmov1alpha1.UserRole{{Name: "teama-admin"}, {Name: "teamb-admin"}}},
+			},
+			roles:      []string{},
+			wantRoles:  nil,
+			wantSynced: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			c := NewClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.user).Build())
+
+			if _, err := c.SyncUserRoles(ctx, tt.user.Name, tt.roles); err != nil {
+				t.Fatalf("SyncUserRoles() error = %v", err)
+			}
+			got, err := c.GetUser(ctx, tt.user.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantRoles, got.Spec.Roles); diff != "" {
+				t.Errorf("roles mismatch (-want +got):\n%s", diff)
+			}
+			if synced := kubeutil.GetAnnotation(got, cosmov1alpha1.UserAnnKeySyncedRoles); synced != tt.wantSynced {
+				t.Errorf("synced roles = %s, want %s", synced, tt.wantSynced)
+			}
+		})
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"sort"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -96,7 +98,12 @@ func (c *Client) ListWorkspacesByUserName(ctx context.Context, username string,
 }
 
 func (c *Client) CreateWorkspace(ctx context.Context, username, wsName, tmplName string, vars map[string]string, opts ...client.CreateOption) (*cosmov1alpha1.Workspace, error) {
+	return c.createWorkspace(ctx, username, wsName, cosmov1alpha1.TemplateRef{Name: tmplName}, vars, opts...)
+}
+
+func (c *Client) createWorkspace(ctx context.Context, username, wsName string, tmplRef cosmov1alpha1.TemplateRef, vars map[string]string, opts ...client.CreateOption) (*cosmov1alpha1.Workspace, error) {
 	log := clog.FromContext(ctx).WithCaller()
+	tmplName := tmplRef.Name
 
 	if _, err := c.GetUser(ctx, username); err != nil {
 		return nil, err
@@ -108,21 +115,36 @@ func (c *Client) CreateWorkspace(ctx context.Context, username, wsName, tmplName
 		return nil, fmt.Errorf("failed to get workspace config in template: %w", err)
 	}
 
+	plainVars, secretVars, err := c.splitWorkspaceVars(ctx, tmplName, vars, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	ws := &cosmov1alpha1.Workspace{}
 	ws.SetName(wsName)
 	ws.SetNamespace(cosmov1alpha1.UserNamespace(username))
 	ws.Spec = cosmov1alpha1.WorkspaceSpec{
-		Template: cosmov1alpha1.TemplateRef{
-			Name: tmplName,
-		},
-		Vars: vars,
+		Template:      tmplRef,
+		Vars:          plainVars,
+		SecretVarsRef: secretVarsRef(wsName, secretVars),
 	}
 	log.Debug().Info("creating workspace", "ws", ws, "dryrun", opts)
 
 	if err := c.Create(ctx, ws, opts...); err != nil {
-		log.Error(err, "failed to create workspace", "username", username, "workspace", ws.Name, "template", tmplName, "vars", fmt.Sprintf("%v", vars))
+		log.Error(err, "failed to create workspace", "username", username, "workspace", ws.Name, "template", tmplName, "vars", fmt.Sprintf("%v", plainVars))
 		return nil, fmt.Errorf("failed to create workspace: %w", err)
 	}
+
+	createOpts := &client.CreateOptions{}
+	createOpts.ApplyOptions(opts)
+	if ws.Spec.SecretVarsRef != nil && len(createOpts.DryRun) == 0 {
+		if err := c.applySecretVars(ctx, ws, secretVars); err != nil {
+			if err := c.Delete(ctx, ws); err != nil {
+				log.Error(err, "failed to cleanup workspace", "username", username, "workspace", ws.Name)
+			}
+			return nil, err
+		}
+	}
 	ws.Status.Phase = "Pending"
 	ws.Status.Config = cfg
 
@@ -152,6 +174,10 @@ type UpdateWorkspaceOpts struct {
 	Replicas     *int64
 	Vars         map[string]string
 	DeletePolicy *string
+	// Schedule is set if not nil. Schedule without both StartTime and StopTime removes the schedule.
+	Schedule *cosmov1alpha1.WorkspaceSchedule
+	// TemplateRevision is set if not nil. Empty string unpins the revision.
+	TemplateRevision *string
 }
 
 func (c *Client) UpdateWorkspace(ctx context.Context, name, username string, opts UpdateWorkspaceOpts) (*cosmov1alpha1.Workspace, error) {
@@ -167,17 +193,55 @@ func (c *Client) UpdateWorkspace(ctx context.Context, name, username string, opt
 	if opts.Replicas != nil {
 		ws.Spec.Replicas = opts.Replicas
 	}
+
+	var secretVars, currentSecretVars map[string]string
+	secretChanged := false
 	if opts.Vars != nil {
-		ws.Spec.Vars = opts.Vars
+		current, err := c.getSecretVars(ctx, ws)
+		if err != nil {
+			return nil, err
+		}
+		plainVars, newSecretVars, err := c.splitWorkspaceVars(ctx, ws.Spec.Template.Name, opts.Vars, current)
+		if err != nil {
+			return nil, err
+		}
+		ws.Spec.Vars = plainVars
+		ws.Spec.SecretVarsRef = secretVarsRef(ws.Name, newSecretVars)
+		secretVars = newSecretVars
+		currentSecretVars = current
+		secretChanged = !maps.Equal(current, newSecretVars)
 	}
 	if opts.DeletePolicy != nil {
 		kubeutil.SetAnnotation(ws, cosmov1alpha1.ResourceAnnKeyDeletePolicy, *opts.DeletePolicy)
 	}
+	if opts.Schedule != nil {
+		if opts.Schedule.StartTime == "" && opts.Schedule.StopTime == "" {
+			ws.Spec.Schedule = nil
+		} else {
+			ws.Spec.Schedule = opts.Schedule
+		}
+	}
 
-	if equality.Semantic.DeepEqual(before, ws) {
+	if opts.TemplateRevision != nil {
+		ws.Spec.Template.Revision = *opts.TemplateRevision
+	}
+
+	specChanged := !equality.Semantic.DeepEqual(before, ws)
+	if !specChanged && !secretChanged {
 		return nil, apierrs.NewBadRequest("no change")
 	}
 
+	// the Secret is updated before the workspace not to build the instance without the new secret vars
+	secretApplied := secretChanged && ws.Spec.SecretVarsRef != nil
+	if secretApplied {
+		if err := c.applySecretVars(ctx, ws, secretVars); err != nil {
+			return nil, err
+		}
+	}
+	if !specChanged {
+		return ws, nil
+	}
+
 	if opts.Replicas != nil {
 		if *opts.Replicas == 0 {
 			kubeutil.SetAnnotation(ws, cosmov1alpha1.WorkspaceAnnKeyLastStoppedAt, time.Now().Format(time.RFC3339))
@@ -185,12 +249,31 @@ func (c *Client) UpdateWorkspace(ctx context.Context, name, username string, opt
 			kubeutil.SetAnnotation(ws, cosmov1alpha1.WorkspaceAnnKeyLastStartedAt, time.Now().Format(time.RFC3339))
 		}
 	}
+	if !equality.Semantic.DeepEqual(before.Spec.Schedule, ws.Spec.Schedule) {
+		// apply the current state of the new schedule
+		ann := ws.GetAnnotations()
+		delete(ann, cosmov1alpha1.WorkspaceAnnKeyScheduleLastAppliedAt)
+		ws.SetAnnotations(ann)
+	}
 
 	if err := c.Update(ctx, ws); err != nil {
 		log.Error(err, "failed to update workspace", "username", username, "workspace", ws.Name)
+		if secretApplied {
+			c.rollbackSecretVars(ctx, before, currentSecretVars)
+		}
 		return nil, fmt.Errorf("failed to update workspace: %w", err)
 	}
 
+	if before.Spec.SecretVarsRef != nil && ws.Spec.SecretVarsRef == nil {
+		// no secret vars remain
+		secret := &corev1.Secret{}
+		secret.SetName(before.Spec.SecretVarsRef.Name)
+		secret.SetNamespace(ws.Namespace)
+		if err := c.Delete(ctx, secret); err != nil && !apierrs.IsNotFound(err) {
+			log.Error(err, "failed to delete secret vars", "username", username, "workspace", ws.Name)
+		}
+	}
+
 	return ws, nil
 }
 
@@ -266,3 +349,88 @@ func (c *Client) GetWorkspaceConfig(ctx context.Context, tmplName string) (cfg c
 	}
 	return workspace.ConfigFromTemplateAnnotations(tmpl)
 }
+
+// CloneWorkspace creates a new workspace with the template, vars and network rules of the source workspace,
+// and clones the PVCs of the source workspace by CSI volume cloning.
+// The cloned PVCs are created before the workspace so that the instance controller adopts them instead of creating empty ones.
+func (c *Client) CloneWorkspace(ctx context.Context, srcUsername, srcWsName, dstUsername, dstWsName string) (*cosmov1alpha1.Workspace, error) {
+	log := clog.FromContext(ctx).WithCaller()
+
+	src, err := c.GetWorkspaceByUserName(ctx, srcWsName, srcUsername)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.GetUser(ctx, dstUsername); err != nil {
+		return nil, err
+	}
+	dstNamespace := cosmov1alpha1.UserNamespace(dstUsername)
+	if src.Namespace == dstNamespace && src.Name == dstWsName {
+		return nil, apierrs.NewBadRequest("source and destination are the same workspace")
+	}
+
+	inst := cosmov1alpha1.Instance{}
+	if err := c.Get(ctx, types.NamespacedName{Name: src.Name, Namespace: src.Namespace}, &inst); err != nil {
+		log.Error(err, "failed to get workspace instance", "username", srcUsername, "workspace", srcWsName)
+		return nil, fmt.Errorf("failed to get workspace instance: %w", err)
+	}
+
+	clonedPVCs := make([]*corev1.PersistentVolumeClaim, 0)
+	cleanup := func() {
+		for _, pvc := range clonedPVCs {
+			if err := c.Delete(ctx, pvc); err != nil && !apierrs.IsNotFound(err) {
+				log.Error(err, "failed to cleanup cloned PersistentVolumeClaim", "pvc", pvc.Name, "namespace", pvc.Namespace)
+			}
+		}
+	}
+
+	for _, name := range workspace.PersistentVolumeClaimNames(inst.Status.LastApplied) {
+		dstName := workspace.ClonedResourceName(name, src.Name, dstWsName)
+		if dstName == "" {
+			log.Info("skip cloning PersistentVolumeClaim whose name is not prefixed with workspace name", "pvc", name)
+			continue
+		}
+
+		srcPVC := corev1.PersistentVolumeClaim{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: src.Namespace}, &srcPVC); err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to get PersistentVolumeClaim %s: %w", name, err)
+		}
+
+		pvc := workspace.ClonedPersistentVolumeClaim(&srcPVC, dstName, dstNamespace)
+		if err := c.Create(ctx, pvc); err != nil {
+			log.Error(err, "failed to create cloned PersistentVolumeClaim", "pvc", dstName, "namespace", dstNamespace)
+			cleanup()
+			return nil, fmt.Errorf("failed to clone PersistentVolumeClaim %s: %w", name, err)
+		}
+		clonedPVCs = append(clonedPVCs, pvc)
+	}
+
+	// secret vars are passed as vars to be stored in the Secret of the cloned workspace
+	vars := maps.Clone(src.Spec.Vars)
+	secretVars, err := c.getSecretVars(ctx, src)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	if len(secretVars) > 0 && vars == nil {
+		vars = make(map[string]string, len(secretVars))
+	}
+	maps.Copy(vars, secretVars)
+
+	// the pinned template revision is kept not to change the resources of the cloned volumes
+	tmplRef := cosmov1alpha1.TemplateRef{Name: src.Spec.Template.Name, Revision: src.Spec.Template.Revision}
+	ws, err := c.createWorkspace(ctx, dstUsername, dstWsName, tmplRef, vars)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	patch := client.MergeFrom(ws.DeepCopy())
+	ws.Spec.Network = src.Spec.Network
+	kubeutil.SetAnnotation(ws, cosmov1alpha1.WorkspaceAnnKeyClonedFrom, fmt.Sprintf("%s/%s", src.Namespace, src.Name))
+	if err := c.Patch(ctx, ws, patch); err != nil {
+		log.Error(err, "failed to patch cloned workspace", "username", dstUsername, "workspace", dstWsName)
+		return nil, fmt.Errorf("failed to copy network rules: %w", err)
+	}
+	return ws, nil
+}
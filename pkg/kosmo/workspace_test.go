@@ -0,0 +1,247 @@
+package kosmo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+)
+
+func TestClient_CloneWorkspace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cosmov1alpha1.AddToScheme(scheme))
+
+	tmpl := &cosmov1alpha1.Template{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "code-server",
+			Labels: map[string]string{cosmov1alpha1.TemplateLabelKeyType: cosmov1alpha1.TemplateLabelEnumTypeWorkspace},
+		},
+	}
+	srcNs := cosmov1alpha1.UserNamespace("tom")
+	srcWs := &cosmov1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: srcNs},
+		Spec: cosmov1alpha1.WorkspaceSpec{
+			Template: cosmov1alpha1.TemplateRef{Name: "code-server", Revision: "code-server-1"},
+			Vars:     map[string]string{"{{HOME_SIZE}}": "20Gi"},
+			Network:  []cosmov1alpha1.NetworkRule{{Protocol: "http", PortNumber: 3000, HTTPPath: "/"}},
+		},
+	}
+	srcInst := &cosmov1alpha1.Instance{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: srcNs},
+		Status: cosmov1alpha1.InstanceStatus{
+			LastApplied: []cosmov1alpha1.ObjectRef{
+				{ObjectReference: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "ws1-workspace"}},
+				{ObjectReference: corev1.ObjectReference{APIVersion: "v1", Kind: "PersistentVolumeClaim", Name: "ws1-home"}},
+				{ObjectReference: corev1.ObjectReference{APIVersion: "v1", Kind: "PersistentVolumeClaim", Name: "shared-cache"}},
+			},
+		},
+	}
+	srcPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1-home", Namespace: srcNs},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: ptr.To("csi"),
+			VolumeName:       "pvc-xxx",
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		dstUser       string
+		dstWs         string
+		wantPVCName   string
+		wantSource    *corev1.TypedLocalObjectReference
+		wantSourceRef *corev1.TypedObjectReference
+		wantErr       bool
+	}{
+		{
+			name:        "✅ clone in the same namespace",
+			dstUser:     "tom",
+			dstWs:       "ws2",
+			wantPVCName: "ws2-home",
+			wantSource:  &corev1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: "ws1-home"},
+		},
+		{
+			name:          "✅ clone into another user",
+			dstUser:       "alice",
+			dstWs:         "ws1",
+			wantPVCName:   "ws1-home",
+			wantSourceRef: &corev1.TypedObjectReference{Kind: "PersistentVolumeClaim", Name: "ws1-home", Namespace: ptr.To(srcNs)},
+		},
+		{
+			name:    "❌ same workspace",
+			dstUser: "tom",
+			dstWs:   "ws1",
+			wantErr: true,
+		},
+		{
+			name:    "❌ destination user not found",
+			dstUser: "bob",
+			dstWs:   "ws2",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			c := NewClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&cosmov1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "tom"}},
+				&cosmov1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "alice"}},
+				tmpl.DeepCopy(), srcWs.DeepCopy(), srcInst.DeepCopy(), srcPVC.DeepCopy(),
+			).Build())
+
+			_, err := c.CloneWorkspace(ctx, "tom", "ws1", tt.dstUser, tt.dstWs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CloneWorkspace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			dstNs := cosmov1alpha1.UserNamespace(tt.dstUser)
+			ws := cosmov1alpha1.Workspace{}
+			if err := c.Get(ctx, types.NamespacedName{Name: tt.dstWs, Namespace: dstNs}, &ws); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(srcWs.Spec, ws.Spec); diff != "" {
+				t.Errorf("workspace spec mismatch (-want +got):\n%s", diff)
+			}
+			if v := kubeutil.GetAnnotation(&ws, cosmov1alpha1.WorkspaceAnnKeyClonedFrom); v != srcNs+"/ws1" {
+				t.Errorf("cloned-from = %s", v)
+			}
+
+			pvcs := corev1.PersistentVolumeClaimList{}
+			if err := c.List(ctx, &pvcs, client.InNamespace(dstNs)); err != nil {
+				t.Fatal(err)
+			}
+			cloned := make([]corev1.PersistentVolumeClaim, 0)
+			for _, v := range pvcs.Items {
+				if v.Spec.DataSource != nil || v.Spec.DataSourceRef != nil {
+					cloned = append(cloned, v)
+				}
+			}
+			if len(cloned) != 1 {
+				t.Fatalf("cloned PVCs = %v", cloned)
+			}
+			pvc := cloned[0]
+			if pvc.Name != tt.wantPVCName {
+				t.Errorf("PVC name = %s, want %s", pvc.Name, tt.wantPVCName)
+			}
+			if pvc.Spec.VolumeName != "" {
+				t.Errorf("volumeName must be cleared: %s", pvc.Spec.VolumeName)
+			}
+			if diff := cmp.Diff(tt.wantSource, pvc.Spec.DataSource); diff != "" {
+				t.Errorf("dataSource mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantSourceRef, pvc.Spec.DataSourceRef); diff != "" {
+				t.Errorf("dataSourceRef mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateWorkspace_rollbackSecretVars(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cosmov1alpha1.AddToScheme(scheme))
+
+	ns := cosmov1alpha1.UserNamespace("tom")
+	secretName := cosmov1alpha1.WorkspaceSecretVarsName("ws1")
+	tmpl := &cosmov1alpha1.Template{
+		ObjectMeta: metav1.ObjectMeta{Name: "code-server"},
+		Spec: cosmov1alpha1.TemplateSpec{
+			RequiredVars: []cosmov1alpha1.RequiredVarSpec{{Var: "{{PASSWORD}}", Secret: true}},
+		},
+	}
+	ws := func(secretVarsRef *cosmov1alpha1.SecretVarsReference) *cosmov1alpha1.Workspace {
+		return &cosmov1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: ns},
+			Spec: cosmov1alpha1.WorkspaceSpec{
+				Template:      cosmov1alpha1.TemplateRef{Name: "code-server"},
+				SecretVarsRef: secretVarsRef,
+			},
+		}
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: ns},
+		Data:       map[string][]byte{"PASSWORD": []byte("old")},
+	}
+
+	// the update of the workspace always fails
+	failUpdate := interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if _, ok := obj.(*cosmov1alpha1.Workspace); ok {
+				return apierrs.NewConflict(cosmov1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), obj.GetName(), nil)
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	}
+
+	tests := []struct {
+		name       string
+		objects    []client.Object
+		wantSecret map[string][]byte
+	}{
+		{
+			name: "✅ restore the current secret vars",
+			objects: []client.Object{
+				ws(&cosmov1alpha1.SecretVarsReference{Name: secretName, Vars: []string{"PASSWORD"}}),
+				secret.DeepCopy(),
+			},
+			wantSecret: map[string][]byte{"PASSWORD": []byte("old")},
+		},
+		{
+			name:    "✅ delete the created secret vars",
+			objects: []client.Object{ws(nil)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			objects := append([]client.Object{&cosmov1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "tom"}}, tmpl.DeepCopy()}, tt.objects...)
+			c := NewClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(failUpdate).Build())
+
+			_, err := c.UpdateWorkspace(ctx, "ws1", "tom", UpdateWorkspaceOpts{
+				Replicas: ptr.To[int64](0),
+				Vars:     map[string]string{"{{PASSWORD}}": "new"},
+			})
+			if err == nil {
+				t.Fatal("UpdateWorkspace() must fail")
+			}
+
+			got := corev1.Secret{}
+			err = c.Get(ctx, types.NamespacedName{Name: secretName, Namespace: ns}, &got)
+			if tt.wantSecret == nil {
+				if !apierrs.IsNotFound(err) {
+					t.Errorf("secret vars must be deleted: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantSecret, got.Data); diff != "" {
+				t.Errorf("secret vars mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
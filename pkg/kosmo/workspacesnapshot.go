@@ -0,0 +1,108 @@
+package kosmo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/clog"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+	"github.com/cosmo-workspace/cosmo/pkg/workspace"
+)
+
+func (c *Client) GetWorkspaceSnapshot(ctx context.Context, name, username string) (*cosmov1alpha1.WorkspaceSnapshot, error) {
+	log := clog.FromContext(ctx).WithCaller()
+
+	snap := cosmov1alpha1.WorkspaceSnapshot{}
+	key := types.NamespacedName{Namespace: cosmov1alpha1.UserNamespace(username), Name: name}
+	if err := c.Get(ctx, key, &snap); err != nil {
+		log.Error(err, "failed to get workspace snapshot", "username", username, "snapshot", name)
+		return nil, fmt.Errorf("failed to get workspace snapshot: %w", err)
+	}
+	return &snap, nil
+}
+
+// ListWorkspaceSnapshots returns the snapshots of the user's workspace sorted by creation time.
+// All snapshots of the user are returned if wsName is empty.
+func (c *Client) ListWorkspaceSnapshots(ctx context.Context, username, wsName string) ([]cosmov1alpha1.WorkspaceSnapshot, error) {
+	log := clog.FromContext(ctx).WithCaller()
+
+	if _, err := c.GetUser(ctx, username); err != nil {
+		return nil, err
+	}
+
+	opts := []client.ListOption{client.InNamespace(cosmov1alpha1.UserNamespace(username))}
+	if wsName != "" {
+		opts = append(opts, client.MatchingLabels{cosmov1alpha1.LabelKeyWorkspaceName: wsName})
+	}
+
+	snapList := cosmov1alpha1.WorkspaceSnapshotList{}
+	if err := c.List(ctx, &snapList, opts...); err != nil {
+		log.Error(err, "failed to list workspace snapshots", "username", username, "workspace", wsName)
+		return nil, fmt.Errorf("failed to list workspace snapshots: %w", err)
+	}
+	sort.SliceStable(snapList.Items, func(i, j int) bool {
+		return snapList.Items[i].CreationTimestamp.Before(&snapList.Items[j].CreationTimestamp)
+	})
+	return snapList.Items, nil
+}
+
+func (c *Client) CreateWorkspaceSnapshot(ctx context.Context, username, wsName, snapName string, volumeSnapshotClassName *string) (*cosmov1alpha1.WorkspaceSnapshot, error) {
+	log := clog.FromContext(ctx).WithCaller()
+
+	if _, err := c.GetWorkspaceByUserName(ctx, wsName, username); err != nil {
+		return nil, err
+	}
+
+	snap := &cosmov1alpha1.WorkspaceSnapshot{}
+	snap.SetName(snapName)
+	snap.SetNamespace(cosmov1alpha1.UserNamespace(username))
+	snap.SetLabels(map[string]string{cosmov1alpha1.LabelKeyWorkspaceName: wsName})
+	snap.Spec = cosmov1alpha1.WorkspaceSnapshotSpec{
+		Workspace:               wsName,
+		VolumeSnapshotClassName: volumeSnapshotClassName,
+	}
+
+	if err := c.Create(ctx, snap); err != nil {
+		log.Error(err, "failed to create workspace snapshot", "username", username, "workspace", wsName, "snapshot", snapName)
+		return nil, fmt.Errorf("failed to create workspace snapshot: %w", err)
+	}
+	snap.Status.Phase = cosmov1alpha1.WorkspaceSnapshotPhasePending
+
+	return snap, nil
+}
+
+// RestoreWorkspaceSnapshot requests the controller to restore the workspace volumes from the snapshot.
+// The workspace must be suspended and the snapshot must be ready.
+func (c *Client) RestoreWorkspaceSnapshot(ctx context.Context, snapName, username string) (*cosmov1alpha1.WorkspaceSnapshot, error) {
+	log := clog.FromContext(ctx).WithCaller()
+
+	snap, err := c.GetWorkspaceSnapshot(ctx, snapName, username)
+	if err != nil {
+		return nil, err
+	}
+	if !snap.IsReady() {
+		return nil, apierrs.NewBadRequest(fmt.Sprintf("snapshot is not ready: phase=%s", snap.Status.Phase))
+	}
+
+	ws, err := c.GetWorkspaceByUserName(ctx, snap.Spec.Workspace, username)
+	if err != nil {
+		return nil, err
+	}
+	if !workspace.IsSuspended(ws) {
+		return nil, apierrs.NewBadRequest(fmt.Sprintf("workspace %s must be suspended to restore", ws.Name))
+	}
+
+	kubeutil.SetAnnotation(snap, cosmov1alpha1.WorkspaceSnapshotAnnKeyRestoreRequestedAt, time.Now().Format(time.RFC3339))
+	if err := c.Update(ctx, snap); err != nil {
+		log.Error(err, "failed to update workspace snapshot", "username", username, "snapshot", snapName)
+		return nil, fmt.Errorf("failed to request restore: %w", err)
+	}
+	return snap, nil
+}
@@ -0,0 +1,87 @@
+package kosmo
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+)
+
+func TestClient_RestoreWorkspaceSnapshot(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cosmov1alpha1.AddToScheme(scheme))
+
+	user := &cosmov1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "tom"}}
+	ws := func(replicas int64, phase string) *cosmov1alpha1.Workspace {
+		return &cosmov1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: cosmov1alpha1.UserNamespace("tom")},
+			Spec:       cosmov1alpha1.WorkspaceSpec{Replicas: ptr.To(replicas)},
+			Status:     cosmov1alpha1.WorkspaceStatus{Phase: phase},
+		}
+	}
+	snap := func(phase string) *cosmov1alpha1.WorkspaceSnapshot {
+		return &cosmov1alpha1.WorkspaceSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "snap1", Namespace: cosmov1alpha1.UserNamespace("tom")},
+			Spec:       cosmov1alpha1.WorkspaceSnapshotSpec{Workspace: "ws1"},
+			Status:     cosmov1alpha1.WorkspaceSnapshotStatus{Phase: phase},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		ws      *cosmov1alpha1.Workspace
+		snap    *cosmov1alpha1.WorkspaceSnapshot
+		wantErr bool
+	}{
+		{
+			name: "✅ restore requested",
+			ws:   ws(0, "Stopped"),
+			snap: snap(cosmov1alpha1.WorkspaceSnapshotPhaseReady),
+		},
+		{
+			name:    "❌ workspace is running",
+			ws:      ws(1, "Running"),
+			snap:    snap(cosmov1alpha1.WorkspaceSnapshotPhaseReady),
+			wantErr: true,
+		},
+		{
+			name:    "❌ workspace is stopping",
+			ws:      ws(0, "Stopping"),
+			snap:    snap(cosmov1alpha1.WorkspaceSnapshotPhaseReady),
+			wantErr: true,
+		},
+		{
+			name:    "❌ snapshot is not ready",
+			ws:      ws(0, "Stopped"),
+			snap:    snap(cosmov1alpha1.WorkspaceSnapshotPhasePending),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			c := NewClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(user.DeepCopy(), tt.ws, tt.snap).Build())
+
+			_, err := c.RestoreWorkspaceSnapshot(ctx, tt.snap.Name, "tom")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RestoreWorkspaceSnapshot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got, err := c.GetWorkspaceSnapshot(ctx, tt.snap.Name, "tom")
+			if err != nil {
+				t.Fatal(err)
+			}
+			requested := kubeutil.GetAnnotation(got, cosmov1alpha1.WorkspaceSnapshotAnnKeyRestoreRequestedAt) != ""
+			if requested == tt.wantErr {
+				t.Errorf("restore requested = %v, wantErr %v", requested, tt.wantErr)
+			}
+		})
+	}
+}
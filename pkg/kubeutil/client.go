@@ -27,7 +27,7 @@ func Apply(ctx context.Context, c client.Client, obj *unstructured.Unstructured,
 	return patched, nil
 }
 
-func GetUnstructured(ctx context.Context, c client.Client, gvk schema.GroupVersionKind, name, namespace string) (*unstructured.Unstructured, error) {
+func GetUnstructured(ctx context.Context, c client.Reader, gvk schema.GroupVersionKind, name, namespace string) (*unstructured.Unstructured, error) {
 	var obj unstructured.Unstructured
 	obj.SetGroupVersionKind(gvk)
 
@@ -0,0 +1,327 @@
+package kubeutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// FieldPath is a parsed path to the fields of an unstructured object.
+// It is parsed from a JSON pointer or a JSONPath expression and may match multiple fields.
+type FieldPath []fieldSelector
+
+type selectorType int
+
+const (
+	// selectToken selects a map key, or an array index if the token is a number (JSON pointer)
+	selectToken selectorType = iota
+	// selectKey selects a map key
+	selectKey
+	// selectIndex selects an array index
+	selectIndex
+	// selectAll selects all the map values or array items
+	selectAll
+	// selectFilter selects the array items whose field equals to the value
+	selectFilter
+)
+
+type fieldSelector struct {
+	typ         selectorType
+	key         string
+	index       int
+	filterField []string
+	filterValue string
+}
+
+// ParseJSONPointer parses RFC6901 JSON pointer such as "/spec/replicas"
+func ParseJSONPointer(pointer string) (FieldPath, error) {
+	if pointer == "" {
+		return nil, fmt.Errorf("empty JSON pointer")
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("JSON pointer must start with '/': %s", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	p := make(FieldPath, 0, len(tokens))
+	for _, t := range tokens {
+		t = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+		p = append(p, fieldSelector{typ: selectToken, key: t})
+	}
+	return p, nil
+}
+
+// ParseJSONPath parses a subset of JSONPath expression.
+// Supported syntax are child ".key" or "['key']", index "[0]", wildcard "[*]" or ".*"
+// and equality filter "[?(@.key=='value')]".
+func ParseJSONPath(expr string) (FieldPath, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(expr), "$")
+	if s == "" {
+		return nil, fmt.Errorf("empty JSONPath")
+	}
+	p := make(FieldPath, 0)
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			key := s[:end]
+			if key == "" {
+				return nil, fmt.Errorf("invalid JSONPath %s: empty key", expr)
+			}
+			if key == "*" {
+				p = append(p, fieldSelector{typ: selectAll})
+			} else {
+				p = append(p, fieldSelector{typ: selectKey, key: key})
+			}
+			s = s[end:]
+
+		case '[':
+			end := closingBracket(s)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSONPath %s: unclosed bracket", expr)
+			}
+			sel, err := parseBracket(s[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSONPath %s: %w", expr, err)
+			}
+			p = append(p, sel)
+			s = s[end+1:]
+
+		default:
+			return nil, fmt.Errorf("invalid JSONPath %s: unexpected character '%c'", expr, s[0])
+		}
+	}
+	return p, nil
+}
+
+// closingBracket returns the index of the bracket closing the one at s[0], ignoring the ones in quotes
+func closingBracket(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+func parseBracket(s string) (fieldSelector, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "*":
+		return fieldSelector{typ: selectAll}, nil
+
+	case strings.HasPrefix(s, "?(") && strings.HasSuffix(s, ")"):
+		cond := strings.TrimSpace(s[2 : len(s)-1])
+		field, value, ok := strings.Cut(cond, "==")
+		if !ok {
+			return fieldSelector{}, fmt.Errorf("only '==' is supported in filter: %s", s)
+		}
+		field = strings.TrimSpace(field)
+		if !strings.HasPrefix(field, "@.") || len(field) == 2 {
+			return fieldSelector{}, fmt.Errorf("filter must start with '@.': %s", s)
+		}
+		return fieldSelector{
+			typ:         selectFilter,
+			filterField: strings.Split(field[2:], "."),
+			filterValue: unquote(strings.TrimSpace(value)),
+		}, nil
+
+	case isQuoted(s):
+		return fieldSelector{typ: selectKey, key: unquote(s)}, nil
+
+	default:
+		i, err := strconv.Atoi(s)
+		if err != nil || i < 0 {
+			return fieldSelector{}, fmt.Errorf("invalid index: %s", s)
+		}
+		return fieldSelector{typ: selectIndex, index: i}, nil
+	}
+}
+
+func isQuoted(s string) bool {
+	return len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]
+}
+
+func unquote(s string) string {
+	if isQuoted(s) {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// matchKeys returns the keys of the map selected by the selector
+func (s fieldSelector) matchKeys(m map[string]interface{}) []string {
+	switch s.typ {
+	case selectToken, selectKey:
+		if _, ok := m[s.key]; ok {
+			return []string{s.key}
+		}
+	case selectAll:
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		return keys
+	}
+	return nil
+}
+
+// matchIndexes returns the indexes of the array selected by the selector
+func (s fieldSelector) matchIndexes(a []interface{}) []int {
+	switch s.typ {
+	case selectToken:
+		if i, err := strconv.Atoi(s.key); err == nil && i >= 0 && i < len(a) {
+			return []int{i}
+		}
+	case selectIndex:
+		if s.index < len(a) {
+			return []int{s.index}
+		}
+	case selectAll:
+		idx := make([]int, len(a))
+		for i := range a {
+			idx[i] = i
+		}
+		return idx
+	case selectFilter:
+		idx := make([]int, 0)
+		for i, v := range a {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if fv, found, _ := unstructured.NestedFieldNoCopy(m, s.filterField...); found && fmt.Sprint(fv) == s.filterValue {
+				idx = append(idx, i)
+			}
+		}
+		return idx
+	}
+	return nil
+}
+
+// RemoveFields removes the fields matched by the path from the object
+func (p FieldPath) RemoveFields(obj map[string]interface{}) {
+	if len(p) == 0 {
+		return
+	}
+	removeFields(obj, p)
+}
+
+func removeFields(node interface{}, p FieldPath) interface{} {
+	sel, last := p[0], len(p) == 1
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for _, k := range sel.matchKeys(n) {
+			if last {
+				delete(n, k)
+			} else {
+				n[k] = removeFields(n[k], p[1:])
+			}
+		}
+		return n
+
+	case []interface{}:
+		idx := sel.matchIndexes(n)
+		if !last {
+			for _, i := range idx {
+				n[i] = removeFields(n[i], p[1:])
+			}
+			return n
+		}
+		removed := make(map[int]bool, len(idx))
+		for _, i := range idx {
+			removed[i] = true
+		}
+		kept := make([]interface{}, 0, len(n))
+		for i, v := range n {
+			if !removed[i] {
+				kept = append(kept, v)
+			}
+		}
+		return kept
+	}
+	return node
+}
+
+// RestoreFields replaces the fields in dst matched by the path with the ones in src.
+// The fields not found in src are removed from dst. The fields not found in dst are not added.
+// The array items selected by a filter are paired in order of appearance.
+func (p FieldPath) RestoreFields(dst, src map[string]interface{}) {
+	if len(p) == 0 {
+		return
+	}
+	restoreFields(dst, src, p)
+}
+
+func restoreFields(dst, src interface{}, p FieldPath) interface{} {
+	sel, last := p[0], len(p) == 1
+	switch d := dst.(type) {
+	case map[string]interface{}:
+		s, _ := src.(map[string]interface{})
+		for _, k := range sel.matchKeys(d) {
+			sv, found := s[k]
+			switch {
+			case !last:
+				d[k] = restoreFields(d[k], sv, p[1:])
+			case found:
+				d[k] = runtime.DeepCopyJSONValue(sv)
+			default:
+				delete(d, k)
+			}
+		}
+		return d
+
+	case []interface{}:
+		s, _ := src.([]interface{})
+		dstIdx := sel.matchIndexes(d)
+		srcIdx := sel.matchIndexes(s)
+		removed := make(map[int]bool)
+		for n, i := range dstIdx {
+			// pair the items by index except for filter which may match different indexes
+			j, found := i, i < len(s)
+			if sel.typ == selectFilter {
+				found = n < len(srcIdx)
+				if found {
+					j = srcIdx[n]
+				}
+			}
+			var sv interface{}
+			if found {
+				sv = s[j]
+			}
+			switch {
+			case !last:
+				d[i] = restoreFields(d[i], sv, p[1:])
+			case found:
+				d[i] = runtime.DeepCopyJSONValue(sv)
+			default:
+				removed[i] = true
+			}
+		}
+		if len(removed) == 0 {
+			return d
+		}
+		kept := make([]interface{}, 0, len(d))
+		for i, v := range d {
+			if !removed[i] {
+				kept = append(kept, v)
+			}
+		}
+		return kept
+	}
+	return dst
+}
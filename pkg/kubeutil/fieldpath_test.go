@@ -0,0 +1,320 @@
+package kubeutil
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func yamlToUnstructured(t *testing.T, s string) *unstructured.Unstructured {
+	t.Helper()
+	j, err := yaml.YAMLToJSON([]byte(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var obj unstructured.Unstructured
+	if err := obj.UnmarshalJSON(j); err != nil {
+		t.Fatal(err)
+	}
+	return &obj
+}
+
+const fieldPathTestDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+  annotations:
+    example.com/a: x
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: main
+        image: main:v1
+      - name: sidecar
+        image: sidecar:v1
+`
+
+func TestParseFieldPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		pointer   string
+		jsonpath  string
+		wantError bool
+	}{
+		{name: "✅ JSON pointer", pointer: "/spec/replicas"},
+		{name: "✅ JSON pointer with escape", pointer: "/metadata/annotations/example.com~1a"},
+		{name: "❌ JSON pointer without slash", pointer: "spec/replicas", wantError: true},
+		{name: "✅ JSONPath", jsonpath: ".spec.replicas"},
+		{name: "✅ JSONPath with root", jsonpath: "$.spec.template.spec.containers[*].image"},
+		{name: "✅ JSONPath with filter", jsonpath: `.spec.template.spec.containers[?(@.name=="sidecar")].image`},
+		{name: "✅ JSONPath with quoted key", jsonpath: ".metadata.annotations['example.com/a']"},
+		{name: "❌ JSONPath unclosed bracket", jsonpath: ".spec.containers[0", wantError: true},
+		{name: "❌ JSONPath invalid index", jsonpath: ".spec.containers[a]", wantError: true},
+		{name: "❌ JSONPath unsupported filter", jsonpath: ".spec.containers[?(@.port>80)]", wantError: true},
+		{name: "❌ JSONPath empty key", jsonpath: ".spec..replicas", wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.pointer != "" {
+				_, err = ParseJSONPointer(tt.pointer)
+			} else {
+				_, err = ParseJSONPath(tt.jsonpath)
+			}
+			if (err != nil) != tt.wantError {
+				t.Errorf("parse error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestFieldPath_RemoveFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		pointer  string
+		jsonpath string
+		want     string
+	}{
+		{
+			name:    "✅ JSON pointer",
+			pointer: "/spec/replicas",
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+  annotations:
+    example.com/a: x
+spec:
+  template:
+    spec:
+      containers:
+      - name: main
+        image: main:v1
+      - name: sidecar
+        image: sidecar:v1
+`,
+		},
+		{
+			name:    "✅ JSON pointer array index",
+			pointer: "/spec/template/spec/containers/1/image",
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+  annotations:
+    example.com/a: x
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: main
+        image: main:v1
+      - name: sidecar
+`,
+		},
+		{
+			name:     "✅ JSONPath wildcard",
+			jsonpath: ".spec.template.spec.containers[*].image",
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+  annotations:
+    example.com/a: x
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: main
+      - name: sidecar
+`,
+		},
+		{
+			name:     "✅ JSONPath filter array item",
+			jsonpath: `.spec.template.spec.containers[?(@.name=="sidecar")]`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+  annotations:
+    example.com/a: x
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: main
+        image: main:v1
+`,
+		},
+		{
+			name:     "✅ JSONPath quoted key",
+			jsonpath: ".metadata.annotations['example.com/a']",
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+  annotations: {}
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: main
+        image: main:v1
+      - name: sidecar
+        image: sidecar:v1
+`,
+		},
+		{
+			name:     "✅ Not found",
+			jsonpath: ".spec.strategy.type",
+			want:     fieldPathTestDeployment,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p FieldPath
+			var err error
+			if tt.pointer != "" {
+				p, err = ParseJSONPointer(tt.pointer)
+			} else {
+				p, err = ParseJSONPath(tt.jsonpath)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			obj := yamlToUnstructured(t, fieldPathTestDeployment)
+			p.RemoveFields(obj.Object)
+
+			want := yamlToUnstructured(t, tt.want)
+			if diff := cmp.Diff(want.Object, obj.Object); diff != "" {
+				t.Errorf("RemoveFields() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFieldPath_RestoreFields(t *testing.T) {
+	live := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+spec:
+  replicas: 5
+  template:
+    spec:
+      containers:
+      - name: sidecar
+        image: sidecar:v2
+      - name: main
+        image: main:v1
+`
+	tests := []struct {
+		name     string
+		pointer  string
+		jsonpath string
+		want     string
+	}{
+		{
+			name:    "✅ Restore from live",
+			pointer: "/spec/replicas",
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+  annotations:
+    example.com/a: x
+spec:
+  replicas: 5
+  template:
+    spec:
+      containers:
+      - name: main
+        image: main:v1
+      - name: sidecar
+        image: sidecar:v1
+`,
+		},
+		{
+			name:     "✅ Restore by filter",
+			jsonpath: `.spec.template.spec.containers[?(@.name=="sidecar")].image`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+  annotations:
+    example.com/a: x
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: main
+        image: main:v1
+      - name: sidecar
+        image: sidecar:v2
+`,
+		},
+		{
+			name:     "✅ Remove if not found in live",
+			jsonpath: ".metadata.annotations",
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: main
+        image: main:v1
+      - name: sidecar
+        image: sidecar:v1
+`,
+		},
+		{
+			name:    "✅ Not found in desired",
+			pointer: "/spec/strategy",
+			want:    fieldPathTestDeployment,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p FieldPath
+			var err error
+			if tt.pointer != "" {
+				p, err = ParseJSONPointer(tt.pointer)
+			} else {
+				p, err = ParseJSONPath(tt.jsonpath)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			obj := yamlToUnstructured(t, fieldPathTestDeployment)
+			p.RestoreFields(obj.Object, yamlToUnstructured(t, live).Object)
+
+			want := yamlToUnstructured(t, tt.want)
+			if diff := cmp.Diff(want.Object, obj.Object); diff != "" {
+				t.Errorf("RestoreFields() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
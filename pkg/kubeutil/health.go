@@ -0,0 +1,87 @@
+package kubeutil
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// CheckHealth returns whether the resource is ready to be depended on by other resources.
+// Only the well-known kinds are checked, and the others are always healthy.
+//   - PersistentVolumeClaim: Bound
+//   - Deployment: Available and the latest generation is observed
+//   - StatefulSet: all the replicas are ready
+//   - Job: Complete
+//   - CustomResourceDefinition: Established
+func CheckHealth(obj *unstructured.Unstructured) (healthy bool, message string) {
+	gk := obj.GroupVersionKind().GroupKind()
+	switch gk.String() {
+	case "PersistentVolumeClaim":
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		if phase != "Bound" {
+			return false, fmt.Sprintf("%s %s is %s", gk.Kind, obj.GetName(), statusOrUnknown(phase))
+		}
+
+	case "Deployment.apps":
+		if !observedLatestGeneration(obj) {
+			return false, fmt.Sprintf("%s %s is progressing", gk.Kind, obj.GetName())
+		}
+		if status, msg := conditionStatus(obj, "Available"); status != "True" {
+			return false, fmt.Sprintf("%s %s is not available: %s", gk.Kind, obj.GetName(), msg)
+		}
+
+	case "StatefulSet.apps":
+		if !observedLatestGeneration(obj) {
+			return false, fmt.Sprintf("%s %s is progressing", gk.Kind, obj.GetName())
+		}
+		replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if !found {
+			replicas = 1
+		}
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		if ready < replicas {
+			return false, fmt.Sprintf("%s %s is not ready: %d/%d replicas", gk.Kind, obj.GetName(), ready, replicas)
+		}
+
+	case "Job.batch":
+		if status, msg := conditionStatus(obj, "Failed"); status == "True" {
+			return false, fmt.Sprintf("%s %s is failed: %s", gk.Kind, obj.GetName(), msg)
+		}
+		if status, _ := conditionStatus(obj, "Complete"); status != "True" {
+			return false, fmt.Sprintf("%s %s is not complete", gk.Kind, obj.GetName())
+		}
+
+	case "CustomResourceDefinition.apiextensions.k8s.io":
+		if status, msg := conditionStatus(obj, "Established"); status != "True" {
+			return false, fmt.Sprintf("%s %s is not established: %s", gk.Kind, obj.GetName(), msg)
+		}
+	}
+	return true, ""
+}
+
+func observedLatestGeneration(obj *unstructured.Unstructured) bool {
+	observed, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	return observed >= obj.GetGeneration()
+}
+
+// conditionStatus returns the status and the message of the condition type in status.conditions
+func conditionStatus(obj *unstructured.Unstructured, condType string) (status, message string) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != condType {
+			continue
+		}
+		status, _ = cond["status"].(string)
+		message, _ = cond["message"].(string)
+		return status, message
+	}
+	return "Unknown", fmt.Sprintf("condition %s is not reported", condType)
+}
+
+func statusOrUnknown(s string) string {
+	if s == "" {
+		return "Unknown"
+	}
+	return s
+}
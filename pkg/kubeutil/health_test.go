@@ -0,0 +1,207 @@
+package kubeutil
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestCheckHealth(t *testing.T) {
+	tests := []struct {
+		name        string
+		objYAML     string
+		wantHealthy bool
+		wantMessage string
+	}{
+		{
+			name: "✅ PVC Bound",
+			objYAML: `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: pvc1
+status:
+  phase: Bound
+`,
+			wantHealthy: true,
+		},
+		{
+			name: "❌ PVC Pending",
+			objYAML: `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: pvc1
+status:
+  phase: Pending
+`,
+			wantHealthy: false,
+			wantMessage: "PersistentVolumeClaim pvc1 is Pending",
+		},
+		{
+			name: "❌ PVC just created",
+			objYAML: `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: pvc1
+`,
+			wantHealthy: false,
+			wantMessage: "PersistentVolumeClaim pvc1 is Unknown",
+		},
+		{
+			name: "✅ Deployment Available",
+			objYAML: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+  generation: 2
+status:
+  observedGeneration: 2
+  conditions:
+  - type: Progressing
+    status: "True"
+  - type: Available
+    status: "True"
+`,
+			wantHealthy: true,
+		},
+		{
+			name: "❌ Deployment not Available",
+			objYAML: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+  generation: 2
+status:
+  observedGeneration: 2
+  conditions:
+  - type: Available
+    status: "False"
+    message: Deployment does not have minimum availability.
+`,
+			wantHealthy: false,
+			wantMessage: "Deployment deploy1 is not available: Deployment does not have minimum availability.",
+		},
+		{
+			name: "❌ Deployment generation not observed",
+			objYAML: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy1
+  generation: 3
+status:
+  observedGeneration: 2
+  conditions:
+  - type: Available
+    status: "True"
+`,
+			wantHealthy: false,
+			wantMessage: "Deployment deploy1 is progressing",
+		},
+		{
+			name: "❌ StatefulSet not ready",
+			objYAML: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: sts1
+  generation: 1
+spec:
+  replicas: 2
+status:
+  observedGeneration: 1
+  readyReplicas: 1
+`,
+			wantHealthy: false,
+			wantMessage: "StatefulSet sts1 is not ready: 1/2 replicas",
+		},
+		{
+			name: "✅ Job Complete",
+			objYAML: `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: job1
+status:
+  conditions:
+  - type: Complete
+    status: "True"
+`,
+			wantHealthy: true,
+		},
+		{
+			name: "❌ Job Failed",
+			objYAML: `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: job1
+status:
+  conditions:
+  - type: Failed
+    status: "True"
+    message: Job has reached the specified backoff limit
+`,
+			wantHealthy: false,
+			wantMessage: "Job job1 is failed: Job has reached the specified backoff limit",
+		},
+		{
+			name: "❌ Job running",
+			objYAML: `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: job1
+status:
+  active: 1
+`,
+			wantHealthy: false,
+			wantMessage: "Job job1 is not complete",
+		},
+		{
+			name: "❌ CRD not Established",
+			objYAML: `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: foos.example.com
+`,
+			wantHealthy: false,
+			wantMessage: "CustomResourceDefinition foos.example.com is not established: condition Established is not reported",
+		},
+		{
+			name: "✅ Other kinds",
+			objYAML: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+`,
+			wantHealthy: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j, err := yaml.YAMLToJSON([]byte(tt.objYAML))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var obj unstructured.Unstructured
+			if err := obj.UnmarshalJSON(j); err != nil {
+				t.Fatal(err)
+			}
+			healthy, msg := CheckHealth(&obj)
+			if healthy != tt.wantHealthy {
+				t.Errorf("CheckHealth() healthy = %v, want %v", healthy, tt.wantHealthy)
+			}
+			if msg != tt.wantMessage {
+				t.Errorf("CheckHealth() message = %v, want %v", msg, tt.wantMessage)
+			}
+		})
+	}
+}
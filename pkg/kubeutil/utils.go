@@ -2,6 +2,7 @@ package kubeutil
 
 import (
 	"fmt"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -14,6 +15,11 @@ var (
 		Version: "v1",
 		Kind:    "Deployment",
 	}
+	StatefulSetGVK = schema.GroupVersionKind{
+		Group:   appsv1.GroupName,
+		Version: "v1",
+		Kind:    "StatefulSet",
+	}
 	ServiceGVK = schema.GroupVersionKind{
 		Group:   corev1.GroupName,
 		Version: "v1",
@@ -95,6 +101,55 @@ func PodStatusReason(pod corev1.Pod) string {
 	return reason
 }
 
+// podFailureReasons are the reasons returned by PodStatusReason which the pod does not recover from without a change
+var podFailureReasons = map[string]struct{}{
+	"CrashLoopBackOff":           {},
+	"ImagePullBackOff":           {},
+	"ErrImagePull":               {},
+	"ErrImageNeverPull":          {},
+	"InvalidImageName":           {},
+	"CreateContainerConfigError": {},
+	"CreateContainerError":       {},
+	"RunContainerError":          {},
+	"ContainerCannotRun":         {},
+	"OOMKilled":                  {},
+	"Error":                      {},
+	"Evicted":                    {},
+	"Failed":                     {},
+}
+
+// IsPodFailureReason returns true if the reason returned by PodStatusReason means the pod is failing
+func IsPodFailureReason(reason string) bool {
+	reason = strings.TrimPrefix(reason, "Init:")
+	if strings.HasPrefix(reason, "ExitCode:") || strings.HasPrefix(reason, "Signal:") {
+		return true
+	}
+	_, ok := podFailureReasons[reason]
+	return ok
+}
+
+// PodStatusMessage returns the human readable message why the pod is not running
+func PodStatusMessage(pod corev1.Pod) string {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, c := range statuses {
+		if c.State.Waiting != nil && c.State.Waiting.Message != "" {
+			return fmt.Sprintf("container %s: %s", c.Name, c.State.Waiting.Message)
+		}
+		if c.State.Terminated != nil && c.State.Terminated.ExitCode != 0 {
+			if c.State.Terminated.Message != "" {
+				return fmt.Sprintf("container %s: %s", c.Name, c.State.Terminated.Message)
+			}
+			return fmt.Sprintf("container %s: exited with code %d", c.Name, c.State.Terminated.ExitCode)
+		}
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Message != "" {
+			return c.Message
+		}
+	}
+	return pod.Status.Message
+}
+
 type AnnotationHolder interface {
 	GetAnnotations() map[string]string
 	SetAnnotations(map[string]string)
@@ -129,3 +184,12 @@ func GetLabel(obj LabelHolder, key string) string {
 	}
 	return l[key]
 }
+
+func SetLabel(obj LabelHolder, key, value string) {
+	l := obj.GetLabels()
+	if l == nil {
+		l = make(map[string]string)
+	}
+	l[key] = value
+	obj.SetLabels(l)
+}
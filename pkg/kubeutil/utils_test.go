@@ -1057,3 +1057,108 @@ status:
 		})
 	}
 }
+
+func TestIsPodFailureReason(t *testing.T) {
+	tests := []struct {
+		reason string
+		want   bool
+	}{
+		{reason: "Running", want: false},
+		{reason: "Running:1/2", want: false},
+		{reason: "ContainerCreating", want: false},
+		{reason: "Init:0/1", want: false},
+		{reason: "Terminating", want: false},
+		{reason: "CrashLoopBackOff", want: true},
+		{reason: "ImagePullBackOff", want: true},
+		{reason: "Init:ErrImagePull", want: true},
+		{reason: "ExitCode:1", want: true},
+		{reason: "Init:Signal:9", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			if got := IsPodFailureReason(tt.reason); got != tt.want {
+				t.Errorf("IsPodFailureReason() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodStatusMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		podYAML string
+		want    string
+	}{
+		{
+			name: "Waiting",
+			podYAML: `
+status:
+  containerStatuses:
+  - name: code-server
+    state:
+      waiting:
+        message: Back-off pulling image "code-server:notfound"
+        reason: ImagePullBackOff
+  phase: Pending`,
+			want: `container code-server: Back-off pulling image "code-server:notfound"`,
+		},
+		{
+			name: "Init container terminated",
+			podYAML: `
+status:
+  initContainerStatuses:
+  - name: init
+    state:
+      terminated:
+        exitCode: 2
+        reason: Error
+  phase: Pending`,
+			want: "container init: exited with code 2",
+		},
+		{
+			name: "Unschedulable",
+			podYAML: `
+status:
+  conditions:
+  - type: PodScheduled
+    status: "False"
+    reason: Unschedulable
+    message: "0/1 nodes are available: 1 Insufficient cpu."
+  phase: Pending`,
+			want: "0/1 nodes are available: 1 Insufficient cpu.",
+		},
+		{
+			name: "Evicted",
+			podYAML: `
+status:
+  message: "The node was low on resource: memory."
+  reason: Evicted
+  phase: Failed`,
+			want: "The node was low on resource: memory.",
+		},
+		{
+			name: "Running",
+			podYAML: `
+status:
+  containerStatuses:
+  - name: code-server
+    ready: true
+    state:
+      running:
+        startedAt: "2022-04-16T16:14:05Z"
+  phase: Running`,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pod corev1.Pod
+			if err := yaml.Unmarshal([]byte(tt.podYAML), &pod); err != nil {
+				t.Fatal(err)
+			}
+			if got := PodStatusMessage(pod); got != tt.want {
+				t.Errorf("PodStatusMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
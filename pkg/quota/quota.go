@@ -0,0 +1,270 @@
+package quota
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+)
+
+// MatchProfile returns the QuotaProfile applied to the user.
+// If the user matches multiple profiles, the profile with the highest priority is returned and ties are broken by the name.
+// It returns nil if no profile matches.
+func MatchProfile(profiles []cosmov1alpha1.QuotaProfile, user cosmov1alpha1.User) *cosmov1alpha1.QuotaProfile {
+	matched := make([]cosmov1alpha1.QuotaProfile, 0, len(profiles))
+	for _, p := range profiles {
+		if IsProfileForRoles(p, user.Spec.Roles) {
+			matched = append(matched, p)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].Spec.Priority != matched[j].Spec.Priority {
+			return matched[i].Spec.Priority > matched[j].Spec.Priority
+		}
+		return matched[i].Name < matched[j].Name
+	})
+	return &matched[0]
+}
+
+// IsProfileForRoles returns true if one of the roles matches the profile roles
+func IsProfileForRoles(p cosmov1alpha1.QuotaProfile, roles []cosmov1alpha1.UserRole) bool {
+	if len(p.Spec.Roles) == 0 {
+		return true
+	}
+	for _, forRole := range p.Spec.Roles {
+		for _, role := range roles {
+			if matched, err := filepath.Match(forRole, role.Name); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PatchResourceQuotaAsDesired patches the ResourceQuota in the user namespace to the spec of the profile
+func PatchResourceQuotaAsDesired(rq *corev1.ResourceQuota, profile cosmov1alpha1.QuotaProfile, user cosmov1alpha1.User, scheme *runtime.Scheme) error {
+	rq.Spec = *profile.Spec.ResourceQuota.DeepCopy()
+	kubeutil.SetLabel(rq, cosmov1alpha1.LabelKeyQuotaProfile, profile.Name)
+	cosmov1alpha1.SetControllerManaged(rq)
+
+	if err := controllerutil.SetControllerReference(&user, rq, scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	return nil
+}
+
+// PatchLimitRangeAsDesired patches the LimitRange in the user namespace to the spec of the profile
+func PatchLimitRangeAsDesired(lr *corev1.LimitRange, profile cosmov1alpha1.QuotaProfile, user cosmov1alpha1.User, scheme *runtime.Scheme) error {
+	lr.Spec = *profile.Spec.LimitRange.DeepCopy()
+	kubeutil.SetLabel(lr, cosmov1alpha1.LabelKeyQuotaProfile, profile.Name)
+	cosmov1alpha1.SetControllerManaged(lr)
+
+	if err := controllerutil.SetControllerReference(&user, lr, scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	return nil
+}
+
+// RequestedResources returns the quota resources requested by the workload objects.
+// Containers without requests or limits are defaulted by the container limits in the LimitRange as the admission does.
+// PersistentVolumeClaims are counted only if includeVolumes is true.
+func RequestedResources(objects []unstructured.Unstructured, lr *corev1.LimitRange, includeVolumes bool) (corev1.ResourceList, error) {
+	total := corev1.ResourceList{}
+
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		switch {
+		case kubeutil.IsGVKEqual(gvk, kubeutil.DeploymentGVK):
+			var deploy appsv1.Deployment
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deploy); err != nil {
+				return nil, fmt.Errorf("failed to convert %s to Deployment: %w", obj.GetName(), err)
+			}
+			addPodResources(total, deploy.Spec.Template.Spec, replicas(deploy.Spec.Replicas), lr)
+
+		case kubeutil.IsGVKEqual(gvk, appsv1.SchemeGroupVersion.WithKind("StatefulSet")):
+			var sts appsv1.StatefulSet
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &sts); err != nil {
+				return nil, fmt.Errorf("failed to convert %s to StatefulSet: %w", obj.GetName(), err)
+			}
+			addPodResources(total, sts.Spec.Template.Spec, replicas(sts.Spec.Replicas), lr)
+			if includeVolumes {
+				for _, pvc := range sts.Spec.VolumeClaimTemplates {
+					for i := int64(0); i < replicas(sts.Spec.Replicas); i++ {
+						addVolumeResources(total, pvc.Spec)
+					}
+				}
+			}
+
+		case kubeutil.IsGVKEqual(gvk, corev1.SchemeGroupVersion.WithKind("Pod")):
+			var pod corev1.Pod
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pod); err != nil {
+				return nil, fmt.Errorf("failed to convert %s to Pod: %w", obj.GetName(), err)
+			}
+			addPodResources(total, pod.Spec, 1, lr)
+
+		case kubeutil.IsGVKEqual(gvk, corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim")):
+			if !includeVolumes {
+				continue
+			}
+			var pvc corev1.PersistentVolumeClaim
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pvc); err != nil {
+				return nil, fmt.Errorf("failed to convert %s to PersistentVolumeClaim: %w", obj.GetName(), err)
+			}
+			addVolumeResources(total, pvc.Spec)
+		}
+	}
+	return total, nil
+}
+
+// CheckQuota returns error if the requested resources exceed the remaining of the ResourceQuota
+func CheckQuota(rq *corev1.ResourceQuota, requested corev1.ResourceList) error {
+	hard := rq.Status.Hard
+	if len(hard) == 0 {
+		hard = rq.Spec.Hard
+	}
+
+	exceeded := make([]corev1.ResourceName, 0)
+	for name, limit := range hard {
+		req, ok := requested[name]
+		if !ok || req.IsZero() {
+			continue
+		}
+		used := rq.Status.Used[name]
+		sum := used.DeepCopy()
+		sum.Add(req)
+		if sum.Cmp(limit) > 0 {
+			exceeded = append(exceeded, name)
+		}
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+	sort.Slice(exceeded, func(i, j int) bool { return exceeded[i] < exceeded[j] })
+
+	reqs, useds, limits := make([]string, 0), make([]string, 0), make([]string, 0)
+	for _, name := range exceeded {
+		req, used, limit := requested[name], rq.Status.Used[name], hard[name]
+		reqs = append(reqs, fmt.Sprintf("%s=%s", name, req.String()))
+		useds = append(useds, fmt.Sprintf("%s=%s", name, used.String()))
+		limits = append(limits, fmt.Sprintf("%s=%s", name, limit.String()))
+	}
+	return fmt.Errorf("exceeded quota: %s, requested: %s, used: %s, limited: %s",
+		rq.Name, strings.Join(reqs, ","), strings.Join(useds, ","), strings.Join(limits, ","))
+}
+
+func replicas(r *int32) int64 {
+	if r == nil {
+		return 1
+	}
+	return int64(*r)
+}
+
+// addPodResources adds the resources of the pods to the total.
+// The effective request of the pod is the larger of the sum of the containers and the largest init container.
+func addPodResources(total corev1.ResourceList, spec corev1.PodSpec, replicas int64, lr *corev1.LimitRange) {
+	if replicas <= 0 {
+		return
+	}
+
+	podReq, podLim := corev1.ResourceList{}, corev1.ResourceList{}
+	for _, c := range spec.Containers {
+		req, lim := containerResources(c, lr)
+		addList(podReq, req, 1)
+		addList(podLim, lim, 1)
+	}
+	for _, c := range spec.InitContainers {
+		req, lim := containerResources(c, lr)
+		maxList(podReq, req)
+		maxList(podLim, lim)
+	}
+
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if v, ok := podReq[name]; ok {
+			addQuantity(total, name, v, replicas)
+			addQuantity(total, corev1.ResourceName("requests."+name), v, replicas)
+		}
+		if v, ok := podLim[name]; ok {
+			addQuantity(total, corev1.ResourceName("limits."+name), v, replicas)
+		}
+	}
+	addQuantity(total, corev1.ResourcePods, resource.MustParse("1"), replicas)
+}
+
+func addVolumeResources(total corev1.ResourceList, spec corev1.PersistentVolumeClaimSpec) {
+	if v, ok := spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		addQuantity(total, corev1.ResourceRequestsStorage, v, 1)
+	}
+	addQuantity(total, corev1.ResourcePersistentVolumeClaims, resource.MustParse("1"), 1)
+}
+
+// containerResources returns the requests and limits of the container defaulted by the LimitRange
+func containerResources(c corev1.Container, lr *corev1.LimitRange) (req, lim corev1.ResourceList) {
+	req, lim = corev1.ResourceList{}, corev1.ResourceList{}
+	for k, v := range c.Resources.Limits {
+		lim[k] = v
+	}
+	for k, v := range c.Resources.Requests {
+		req[k] = v
+	}
+
+	if lr != nil {
+		for _, item := range lr.Spec.Limits {
+			if item.Type != corev1.LimitTypeContainer {
+				continue
+			}
+			for k, v := range item.Default {
+				if _, ok := lim[k]; !ok {
+					lim[k] = v
+				}
+			}
+			for k, v := range item.DefaultRequest {
+				if _, ok := req[k]; !ok {
+					req[k] = v
+				}
+			}
+		}
+	}
+
+	// request is defaulted to the limit if not specified
+	for k, v := range lim {
+		if _, ok := req[k]; !ok {
+			req[k] = v
+		}
+	}
+	return req, lim
+}
+
+func addList(total, l corev1.ResourceList, times int64) {
+	for k, v := range l {
+		addQuantity(total, k, v, times)
+	}
+}
+
+func maxList(total, l corev1.ResourceList) {
+	for k, v := range l {
+		if cur, ok := total[k]; !ok || v.Cmp(cur) > 0 {
+			total[k] = v.DeepCopy()
+		}
+	}
+}
+
+func addQuantity(total corev1.ResourceList, name corev1.ResourceName, q resource.Quantity, times int64) {
+	sum := total[name]
+	for i := int64(0); i < times; i++ {
+		sum.Add(q)
+	}
+	total[name] = sum
+}
@@ -0,0 +1,233 @@
+package quota
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func profile(name string, priority int32, roles ...string) cosmov1alpha1.QuotaProfile {
+	return cosmov1alpha1.QuotaProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       cosmov1alpha1.QuotaProfileSpec{Roles: roles, Priority: priority},
+	}
+}
+
+func userWithRoles(roles ...string) cosmov1alpha1.User {
+	u := cosmov1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "tom"}}
+	for _, r := range roles {
+		u.Spec.Roles = append(u.Spec.Roles, cosmov1alpha1.UserRole{Name: r})
+	}
+	return u
+}
+
+func TestMatchProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		profiles []cosmov1alpha1.QuotaProfile
+		user     cosmov1alpha1.User
+		want     string
+	}{
+		{
+			name:     "✅ default profile for all users",
+			profiles: []cosmov1alpha1.QuotaProfile{profile("default", 0), profile("team-a", 10, "team-a-*")},
+			user:     userWithRoles("team-b-developer"),
+			want:     "default",
+		},
+		{
+			name:     "✅ higher priority is preferred",
+			profiles: []cosmov1alpha1.QuotaProfile{profile("default", 0), profile("team-a", 10, "team-a-*")},
+			user:     userWithRoles("team-a-developer"),
+			want:     "team-a",
+		},
+		{
+			name:     "✅ ties are broken by name",
+			profiles: []cosmov1alpha1.QuotaProfile{profile("b", 10, "dev"), profile("a", 10, "dev")},
+			user:     userWithRoles("dev"),
+			want:     "a",
+		},
+		{
+			name:     "✅ no profile matched",
+			profiles: []cosmov1alpha1.QuotaProfile{profile("team-a", 10, "team-a-*")},
+			user:     userWithRoles(),
+			want:     "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MatchProfile(tt.profiles, tt.user)
+			var gotName string
+			if got != nil {
+				gotName = got.Name
+			}
+			if gotName != tt.want {
+				t.Errorf("MatchProfile() = %v, want %v", gotName, tt.want)
+			}
+		})
+	}
+}
+
+const deployYAML = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ws1-workspace
+spec:
+  replicas: 2
+  template:
+    spec:
+      initContainers:
+      - name: init
+        resources:
+          requests:
+            cpu: "2"
+      containers:
+      - name: main
+        resources:
+          requests:
+            cpu: 500m
+            memory: 1Gi
+          limits:
+            memory: 2Gi
+      - name: sidecar
+`
+
+const pvcYAML = `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: ws1-home
+spec:
+  resources:
+    requests:
+      storage: 20Gi
+`
+
+func toUnstructured(t *testing.T, docs ...string) []unstructured.Unstructured {
+	objects := make([]unstructured.Unstructured, 0, len(docs))
+	for _, d := range docs {
+		var u unstructured.Unstructured
+		if err := yaml.Unmarshal([]byte(d), &u.Object); err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, u)
+	}
+	return objects
+}
+
+func TestRequestedResources(t *testing.T) {
+	lr := &corev1.LimitRange{
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{
+				{
+					Type:           corev1.LimitTypeContainer,
+					Default:        corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+					DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		lr             *corev1.LimitRange
+		includeVolumes bool
+		want           map[corev1.ResourceName]string
+	}{
+		{
+			name:           "✅ with volumes and limit range",
+			lr:             lr,
+			includeVolumes: true,
+			want: map[corev1.ResourceName]string{
+				// max(500m + 100m, 2) * 2
+				"requests.cpu": "4",
+				// (1Gi + 512Mi) * 2
+				"requests.memory":        "3Gi",
+				"limits.memory":          "5Gi",
+				"pods":                   "2",
+				"requests.storage":       "20Gi",
+				"persistentvolumeclaims": "1",
+			},
+		},
+		{
+			name:           "✅ without volumes and limit range",
+			includeVolumes: false,
+			want: map[corev1.ResourceName]string{
+				"requests.cpu":    "4",
+				"requests.memory": "2Gi",
+				"limits.memory":   "4Gi",
+				"pods":            "2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RequestedResources(toUnstructured(t, deployYAML, pvcYAML), tt.lr, tt.includeVolumes)
+			if err != nil {
+				t.Fatalf("RequestedResources() error = %v", err)
+			}
+			for name, want := range tt.want {
+				v, ok := got[name]
+				if !ok || v.Cmp(resource.MustParse(want)) != 0 {
+					t.Errorf("RequestedResources() %s = %v, want %v", name, v.String(), want)
+				}
+			}
+			if _, ok := tt.want["requests.storage"]; !ok {
+				if _, ok := got["requests.storage"]; ok {
+					t.Errorf("RequestedResources() requests.storage should not be counted")
+				}
+			}
+		})
+	}
+}
+
+func TestCheckQuota(t *testing.T) {
+	rq := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: cosmov1alpha1.UserResourceQuotaName},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{
+				"requests.cpu": resource.MustParse("4"),
+				"pods":         resource.MustParse("3"),
+			},
+			Used: corev1.ResourceList{
+				"requests.cpu": resource.MustParse("3"),
+				"pods":         resource.MustParse("1"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		requested corev1.ResourceList
+		wantErr   string
+	}{
+		{
+			name:      "✅ within quota",
+			requested: corev1.ResourceList{"requests.cpu": resource.MustParse("1"), "pods": resource.MustParse("1"), "requests.memory": resource.MustParse("100Gi")},
+		},
+		{
+			name:      "❌ exceeded",
+			requested: corev1.ResourceList{"requests.cpu": resource.MustParse("1500m"), "pods": resource.MustParse("1")},
+			wantErr:   "exceeded quota: cosmo-user-quota, requested: requests.cpu=1500m, used: requests.cpu=3, limited: requests.cpu=4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckQuota(rq, tt.requested)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckQuota() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckQuota() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
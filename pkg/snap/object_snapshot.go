@@ -33,6 +33,9 @@ func UserSnapshot(in *cosmov1alpha1.User) *cosmov1alpha1.User {
 			return x.Name < y.Name
 		}
 	})
+	// conditions depend on the progress of the child resources
+	obj.Status.ObservedGeneration = 0
+	obj.Status.Conditions = nil
 
 	return obj
 }
@@ -46,6 +49,8 @@ func InstanceSnapshot(in cosmov1alpha1.InstanceObject) cosmov1alpha1.InstanceObj
 		v.CreationTimestamp = nil
 		v.UID = ""
 		v.ResourceVersion = ""
+		v.Generation = 0
+		v.BuiltHash = ""
 		obj.GetStatus().LastApplied[i] = v
 	}
 	sort.Slice(obj.GetStatus().LastApplied, func(i, j int) bool {
@@ -57,6 +62,10 @@ func InstanceSnapshot(in cosmov1alpha1.InstanceObject) cosmov1alpha1.InstanceObj
 		}
 	})
 	obj.GetStatus().TemplateResourceVersion = ""
+	obj.GetStatus().LastFullSyncTime = nil
+	// conditions depend on the progress of the child resources
+	obj.GetStatus().ObservedGeneration = 0
+	obj.GetStatus().Conditions = nil
 
 	return obj
 }
@@ -3,6 +3,7 @@ package template
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -15,11 +16,19 @@ type Builder interface {
 	Build() ([]unstructured.Unstructured, error)
 }
 
-func BuildObjects(tmplSpec cosmov1alpha1.TemplateSpec, inst cosmov1alpha1.InstanceObject, domain string) (objects []unstructured.Unstructured, err error) {
+func BuildObjects(tmpl cosmov1alpha1.TemplateObject, inst cosmov1alpha1.InstanceObject, domain string, secretVars map[string]string) (objects []unstructured.Unstructured, err error) {
+	tmplSpec := tmpl.GetSpec()
 	if tmplSpec.RawYaml != "" {
-		objects, err = NewRawYAMLBuilder(tmplSpec.RawYaml).
+		rawYaml := tmplSpec.RawYaml
+		if IsGoTemplateRenderMode(tmpl) {
+			rawYaml, err = RenderGoTemplate(rawYaml, NewGoTemplateData(inst, domain, secretVars))
+			if err != nil {
+				return nil, fmt.Errorf("failed to render template: %w", err)
+			}
+		}
+		objects, err = NewRawYAMLBuilder(rawYaml).
 			ReplaceDefaultVars(inst, domain).
-			ReplaceCustomVars(inst).
+			ReplaceCustomVars(inst, secretVars).
 			Build()
 		if err != nil {
 			return nil, err
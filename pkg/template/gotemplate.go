@@ -0,0 +1,185 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/yaml"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+const goTemplateName = "rawYaml"
+
+var (
+	goTemplateErrRegexp = regexp.MustCompile(`(?s)^template: ` + goTemplateName + `:(\d+)(?::\d+)?: (?:executing "` + goTemplateName + `" at <[^>]*>: )?(.*)$`)
+	goTemplateFuncName  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// IsGoTemplateRenderMode returns whether the rawYaml of the template is rendered as Go text/template
+func IsGoTemplateRenderMode(tmpl cosmov1alpha1.TemplateObject) bool {
+	ann := tmpl.GetAnnotations()
+	if ann == nil {
+		return false
+	}
+	return ann[cosmov1alpha1.TemplateAnnKeyRenderMode] == cosmov1alpha1.TemplateRenderModeGoTemplate
+}
+
+// RenderError is an error of rendering the rawYaml with the line number in the rawYaml
+type RenderError struct {
+	Line    int
+	Message string
+}
+
+func (e *RenderError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+func toRenderError(err error) error {
+	m := goTemplateErrRegexp.FindStringSubmatch(err.Error())
+	if m == nil {
+		return err
+	}
+	line, _ := strconv.Atoi(m[1])
+	return &RenderError{Line: line, Message: m[2]}
+}
+
+// GoTemplateData is the data passed to the rawYaml rendered as Go text/template
+type GoTemplateData struct {
+	Instance  string
+	Namespace string
+	Template  string
+	Domain    string
+	// Vars are the vars of the instance including the default vars and the secret vars.
+	// The keys are the var names without braces.
+	Vars map[string]string
+}
+
+// NewGoTemplateData returns the data to render the rawYaml for the instance
+func NewGoTemplateData(inst cosmov1alpha1.InstanceObject, domain string, secretVars map[string]string) GoTemplateData {
+	d := GoTemplateData{
+		Instance: inst.GetName(),
+		Template: inst.GetSpec().Template.Name,
+		Domain:   domain,
+		Vars:     make(map[string]string),
+	}
+	d.Vars[TrimTemplateVarKey(DefaultVarsInstance)] = d.Instance
+	d.Vars[TrimTemplateVarKey(DefaultVarsTemplate)] = d.Template
+	d.Vars[TrimTemplateVarKey(DefaultVarsDomain)] = d.Domain
+	if inst.GetScope() == meta.RESTScopeNamespace {
+		d.Namespace = inst.GetNamespace()
+		d.Vars[TrimTemplateVarKey(DefaultVarsNamespace)] = d.Namespace
+	}
+	for k, v := range inst.GetSpec().Vars {
+		d.Vars[TrimTemplateVarKey(k)] = v
+	}
+	for k, v := range secretVars {
+		d.Vars[TrimTemplateVarKey(k)] = v
+	}
+	return d
+}
+
+// RenderGoTemplate renders the rawYaml as Go text/template.
+// Only the functions for text processing are available so that the template cannot access outside of the data.
+// Each var is also available as a function of its name, so that "{{VAR}}" works as same as the literal replacement.
+func RenderGoTemplate(rawYaml string, data GoTemplateData) (string, error) {
+	funcs := goTemplateFuncs()
+	for k, v := range data.Vars {
+		if _, ok := funcs[k]; ok || !goTemplateFuncName.MatchString(k) {
+			continue
+		}
+		val := v
+		funcs[k] = func() string { return val }
+	}
+
+	t, err := template.New(goTemplateName).Option("missingkey=zero").Funcs(funcs).Parse(rawYaml)
+	if err != nil {
+		return "", toRenderError(err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", toRenderError(err)
+	}
+	return buf.String(), nil
+}
+
+// ValidateGoTemplate checks the syntax of the rawYaml as Go text/template.
+// Undefined functions are not checked as the vars are given on rendering.
+func ValidateGoTemplate(rawYaml string) error {
+	tree := parse.New(goTemplateName)
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(rawYaml, "", "", make(map[string]*parse.Tree), goTemplateFuncs()); err != nil {
+		return toRenderError(err)
+	}
+	return nil
+}
+
+func goTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"default": func(def string, val ...string) string {
+			if len(val) == 0 || val[0] == "" {
+				return def
+			}
+			return val[0]
+		},
+		"empty": func(val string) bool { return val == "" },
+		"required": func(msg string, val ...string) (string, error) {
+			if len(val) == 0 || val[0] == "" {
+				return "", errors.New(msg)
+			}
+			return val[0], nil
+		},
+		"quote":  strconv.Quote,
+		"squote": func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" },
+		"toYaml": func(v any) (string, error) {
+			b, err := yaml.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimSuffix(string(b), "\n"), nil
+		},
+		"toJson": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			return string(b), nil
+		},
+		"indent": indent,
+		"nindent": func(n int, s string) string {
+			return "\n" + indent(n, s)
+		},
+		"splitList": func(sep, s string) []string {
+			list := make([]string, 0)
+			for _, v := range strings.Split(s, sep) {
+				if v = strings.TrimSpace(v); v != "" {
+					list = append(list, v)
+				}
+			}
+			return list
+		},
+		"join":      func(sep string, list []string) string { return strings.Join(list, sep) },
+		"trim":      strings.TrimSpace,
+		"upper":     strings.ToUpper,
+		"lower":     strings.ToLower,
+		"replace":   func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":  func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix": func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix": func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"atoi":      strconv.Atoi,
+		"toBool":    strconv.ParseBool,
+	}
+}
+
+func indent(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
@@ -0,0 +1,140 @@
+package template
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func TestRenderGoTemplate(t *testing.T) {
+	inst := &cosmov1alpha1.Instance{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: "cosmo-user-tom"},
+		Spec: cosmov1alpha1.InstanceSpec{
+			Template: cosmov1alpha1.TemplateRef{Name: "tmpl1"},
+			Vars: map[string]string{
+				"{{SIDECAR}}": "true",
+				"PORTS":       "8080, 9090",
+			},
+		},
+	}
+	data := NewGoTemplateData(inst, "example.com", map[string]string{"PASSWORD": "p'ss"})
+
+	tests := []struct {
+		name     string
+		rawYaml  string
+		want     string
+		wantLine int
+		wantErr  bool
+	}{
+		{
+			name:    "✅ default vars as functions",
+			rawYaml: "name: {{INSTANCE}}-{{TEMPLATE}}\nnamespace: {{NAMESPACE}}\nhost: {{DOMAIN}}",
+			want:    "name: ws1-tmpl1\nnamespace: cosmo-user-tom\nhost: example.com",
+		},
+		{
+			name:    "✅ conditional",
+			rawYaml: "{{- if eq .Vars.SIDECAR \"true\" }}sidecar: {{ .Instance }}{{ end }}",
+			want:    "sidecar: ws1",
+		},
+		{
+			name:    "✅ loop over list var",
+			rawYaml: "ports:{{ range splitList \",\" .Vars.PORTS }}\n- {{ . }}{{ end }}",
+			want:    "ports:\n- 8080\n- 9090",
+		},
+		{
+			name:    "✅ helpers",
+			rawYaml: "a: {{ .Vars.NOTFOUND | default \"def\" }}\nb: {{ PASSWORD | squote }}\nc: {{ quote .Namespace }}\nd:{{ .Vars.PORTS | splitList \",\" | toYaml | nindent 2 }}",
+			want:    "a: def\nb: 'p''ss'\nc: \"cosmo-user-tom\"\nd:\n  - \"8080\"\n  - \"9090\"",
+		},
+		{
+			name:     "❌ parse error",
+			rawYaml:  "a: 1\nb: {{ if }}",
+			wantErr:  true,
+			wantLine: 2,
+		},
+		{
+			name:     "❌ undefined function",
+			rawYaml:  "a: 1\nb: 2\nc: {{ UNDEFINED }}",
+			wantErr:  true,
+			wantLine: 3,
+		},
+		{
+			name:     "❌ execution error",
+			rawYaml:  "a: 1\nb: {{ required \"FOO is required\" .Vars.FOO }}",
+			wantErr:  true,
+			wantLine: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderGoTemplate(tt.rawYaml, data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderGoTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var renderErr *RenderError
+				if !errors.As(err, &renderErr) {
+					t.Fatalf("RenderGoTemplate() error = %v, want RenderError", err)
+				}
+				if renderErr.Line != tt.wantLine {
+					t.Errorf("RenderGoTemplate() error line = %v, want %v: %v", renderErr.Line, tt.wantLine, err)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("RenderGoTemplate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateGoTemplate(t *testing.T) {
+	if err := ValidateGoTemplate("name: {{INSTANCE}}\n{{ if .Vars.X }}x: {{ CUSTOM_VAR }}{{ end }}"); err != nil {
+		t.Errorf("ValidateGoTemplate() error = %v, want nil", err)
+	}
+	err := ValidateGoTemplate("a: 1\n{{ range }}")
+	var renderErr *RenderError
+	if !errors.As(err, &renderErr) || renderErr.Line != 2 {
+		t.Errorf("ValidateGoTemplate() error = %v, want RenderError at line 2", err)
+	}
+}
+
+func TestBuildObjectsGoTemplate(t *testing.T) {
+	tmpl := &cosmov1alpha1.Template{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tmpl1",
+			Annotations: map[string]string{cosmov1alpha1.TemplateAnnKeyRenderMode: cosmov1alpha1.TemplateRenderModeGoTemplate},
+		},
+		Spec: cosmov1alpha1.TemplateSpec{
+			RawYaml: `{{- range splitList "," .Vars.NAMES }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{INSTANCE}}-{{ . }}
+---
+{{- end }}`,
+		},
+	}
+	inst := &cosmov1alpha1.Instance{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: "default"},
+		Spec: cosmov1alpha1.InstanceSpec{
+			Template: cosmov1alpha1.TemplateRef{Name: "tmpl1"},
+			Vars:     map[string]string{"NAMES": "a,b"},
+		},
+	}
+	objects, err := BuildObjects(tmpl, inst, "", nil)
+	if err != nil {
+		t.Fatalf("BuildObjects() error = %v", err)
+	}
+	names := make([]string, len(objects))
+	for i, o := range objects {
+		names[i] = o.GetName()
+	}
+	if diff := cmp.Diff([]string{"ws1-a", "ws1-b"}, names); diff != "" {
+		t.Errorf("BuildObjects() mismatch (-want +got):\n%s", diff)
+	}
+}
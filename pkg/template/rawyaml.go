@@ -59,7 +59,13 @@ func (t *RawYAMLBuilder) ReplaceDefaultVars(inst cosmov1alpha1.InstanceObject, d
 	return t
 }
 
-func (t *RawYAMLBuilder) ReplaceCustomVars(inst cosmov1alpha1.InstanceObject) *RawYAMLBuilder {
+// ReplaceCustomVars replaces the vars of the instance and the secret vars.
+// The secret vars are replaced first as they take precedence over the vars in the instance spec.
+func (t *RawYAMLBuilder) ReplaceCustomVars(inst cosmov1alpha1.InstanceObject, secretVars map[string]string) *RawYAMLBuilder {
+	for key, val := range secretVars {
+		key = FixupTemplateVarKey(key)
+		t.rawYaml = strings.ReplaceAll(t.rawYaml, key, val)
+	}
 	if inst.GetSpec().Vars != nil {
 		for key, val := range inst.GetSpec().Vars {
 			key = FixupTemplateVarKey(key)
@@ -69,6 +75,11 @@ func (t *RawYAMLBuilder) ReplaceCustomVars(inst cosmov1alpha1.InstanceObject) *R
 	return t
 }
 
+// TrimTemplateVarKey returns the var name without braces
+func TrimTemplateVarKey(key string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(key, "{{"), "}}")
+}
+
 func FixupTemplateVarKey(key string) string {
 	if !strings.HasPrefix(key, "{{") {
 		key = "{{" + key
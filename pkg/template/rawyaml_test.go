@@ -384,14 +384,37 @@ func TestRawYAMLBuilder_ReplaceDefaultVars(t *testing.T) {
 
 func TestRawYAMLBuilder_ReplaceCustomVars(t *testing.T) {
 	type fields struct {
-		rawYaml string
-		inst    *cosmov1alpha1.Instance
+		rawYaml    string
+		inst       *cosmov1alpha1.Instance
+		secretVars map[string]string
 	}
 	tests := []struct {
 		name   string
 		fields fields
 		want   *RawYAMLBuilder
 	}{
+		{
+			name: "OK with secret vars",
+			fields: fields{
+				rawYaml: "{{TEST}}-{{PASSWORD}}",
+				inst: &cosmov1alpha1.Instance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cs1",
+						Namespace: "cosmo-user-tom",
+					},
+					Spec: cosmov1alpha1.InstanceSpec{
+						Template: cosmov1alpha1.TemplateRef{
+							Name: "code-server",
+						},
+						Vars: map[string]string{"{{TEST}}": "OK", "PASSWORD": "default"},
+					},
+				},
+				secretVars: map[string]string{"PASSWORD": "secret"},
+			},
+			want: &RawYAMLBuilder{
+				rawYaml: "OK-secret",
+			},
+		},
 		{
 			name: "OK",
 			fields: fields{
@@ -420,7 +443,7 @@ func TestRawYAMLBuilder_ReplaceCustomVars(t *testing.T) {
 			tr := &RawYAMLBuilder{
 				rawYaml: tt.fields.rawYaml,
 			}
-			if got := tr.ReplaceCustomVars(tt.fields.inst); !reflect.DeepEqual(got, tt.want) {
+			if got := tr.ReplaceCustomVars(tt.fields.inst, tt.fields.secretVars); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("RawYAMLBuilder.ReplaceCustomVars() = %v, want %v", got, tt.want)
 			}
 		})
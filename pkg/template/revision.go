@@ -0,0 +1,144 @@
+package template
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+// IsPinRevision returns true if the Template records the revisions and pins them on the new Workspaces
+func IsPinRevision(tmpl cosmov1alpha1.TemplateObject) bool {
+	ann := tmpl.GetAnnotations()
+	if ann == nil {
+		return false
+	}
+	pin, _ := strconv.ParseBool(ann[cosmov1alpha1.TemplateAnnKeyPinRevision])
+	return pin
+}
+
+// RevisionHistoryLimit returns the number of revisions to keep
+func RevisionHistoryLimit(tmpl cosmov1alpha1.TemplateObject) int {
+	ann := tmpl.GetAnnotations()
+	if ann == nil {
+		return cosmov1alpha1.DefaultRevisionHistoryLimit
+	}
+	limit, err := strconv.Atoi(ann[cosmov1alpha1.TemplateAnnKeyRevisionHistoryLimit])
+	if err != nil || limit <= 0 {
+		return cosmov1alpha1.DefaultRevisionHistoryLimit
+	}
+	return limit
+}
+
+// RevisionHash returns the hash of the spec and the annotations of the Template
+func RevisionHash(tmpl *cosmov1alpha1.Template) string {
+	b, _ := json.Marshal(struct {
+		Annotations map[string]string          `json:"annotations"`
+		Spec        cosmov1alpha1.TemplateSpec `json:"spec"`
+	}{
+		Annotations: revisionAnnotations(tmpl.GetAnnotations()),
+		Spec:        tmpl.Spec,
+	})
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])[:16]
+}
+
+// revisionAnnotations returns the annotations to be recorded in the revision
+func revisionAnnotations(ann map[string]string) map[string]string {
+	m := make(map[string]string, len(ann))
+	for k, v := range ann {
+		if k == "kubectl.kubernetes.io/last-applied-configuration" {
+			continue
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// NewTemplateRevision returns the TemplateRevision of the current Template
+func NewTemplateRevision(tmpl *cosmov1alpha1.Template, revision int64) *cosmov1alpha1.TemplateRevision {
+	labels := make(map[string]string, len(tmpl.GetLabels())+1)
+	for k, v := range tmpl.GetLabels() {
+		labels[k] = v
+	}
+	labels[cosmov1alpha1.LabelKeyTemplateName] = tmpl.Name
+
+	return &cosmov1alpha1.TemplateRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cosmov1alpha1.TemplateRevisionName(tmpl.Name, revision),
+			Labels:      labels,
+			Annotations: map[string]string{cosmov1alpha1.TemplateRevisionAnnKeyHash: RevisionHash(tmpl)},
+		},
+		Spec: cosmov1alpha1.TemplateRevisionSpec{
+			Template:     tmpl.Name,
+			Revision:     revision,
+			Annotations:  revisionAnnotations(tmpl.GetAnnotations()),
+			TemplateSpec: *tmpl.Spec.DeepCopy(),
+		},
+	}
+}
+
+// TemplateFromRevision returns the Template restored from the revision
+func TemplateFromRevision(rev *cosmov1alpha1.TemplateRevision) *cosmov1alpha1.Template {
+	labels := make(map[string]string, len(rev.GetLabels()))
+	for k, v := range rev.GetLabels() {
+		if k == cosmov1alpha1.LabelKeyTemplateName {
+			continue
+		}
+		labels[k] = v
+	}
+	annotations := make(map[string]string, len(rev.Spec.Annotations))
+	for k, v := range rev.Spec.Annotations {
+		annotations[k] = v
+	}
+
+	return &cosmov1alpha1.Template{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rev.Spec.Template,
+			Labels:          labels,
+			Annotations:     annotations,
+			ResourceVersion: rev.ResourceVersion,
+		},
+		Spec: *rev.Spec.TemplateSpec.DeepCopy(),
+	}
+}
+
+// ListTemplateRevisions returns the revisions of the Template sorted by the revision number
+func ListTemplateRevisions(ctx context.Context, c client.Client, tmplName string) ([]cosmov1alpha1.TemplateRevision, error) {
+	var revs cosmov1alpha1.TemplateRevisionList
+	if err := c.List(ctx, &revs, client.MatchingLabels{cosmov1alpha1.LabelKeyTemplateName: tmplName}); err != nil {
+		return nil, err
+	}
+	sort.Slice(revs.Items, func(i, j int) bool { return revs.Items[i].Spec.Revision < revs.Items[j].Spec.Revision })
+	return revs.Items, nil
+}
+
+// GetTemplate returns the Template referenced by the TemplateRef.
+// If the revision is pinned, the Template is restored from the TemplateRevision.
+func GetTemplate(ctx context.Context, c client.Client, ref cosmov1alpha1.TemplateRef) (*cosmov1alpha1.Template, error) {
+	if ref.Revision == "" {
+		tmpl := &cosmov1alpha1.Template{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, tmpl); err != nil {
+			return nil, err
+		}
+		return tmpl, nil
+	}
+
+	rev := &cosmov1alpha1.TemplateRevision{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Revision}, rev); err != nil {
+		return nil, err
+	}
+	if rev.Spec.Template != ref.Name {
+		return nil, fmt.Errorf("revision %s is not for template %s", ref.Revision, ref.Name)
+	}
+	return TemplateFromRevision(rev), nil
+}
@@ -0,0 +1,112 @@
+package template
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func testTemplate(rawYaml string) *cosmov1alpha1.Template {
+	return &cosmov1alpha1.Template{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "code-server",
+			Labels: map[string]string{
+				cosmov1alpha1.TemplateLabelKeyType: cosmov1alpha1.TemplateLabelEnumTypeWorkspace,
+			},
+			Annotations: map[string]string{
+				cosmov1alpha1.TemplateAnnKeyPinRevision:            "true",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+		Spec: cosmov1alpha1.TemplateSpec{RawYaml: rawYaml},
+	}
+}
+
+func TestRevisionHash(t *testing.T) {
+	a := testTemplate("v1")
+	b := testTemplate("v1")
+	b.Annotations["kubectl.kubernetes.io/last-applied-configuration"] = `{"changed":true}`
+	c := testTemplate("v2")
+
+	if RevisionHash(a) != RevisionHash(b) {
+		t.Errorf("RevisionHash() should ignore last-applied-configuration")
+	}
+	if RevisionHash(a) == RevisionHash(c) {
+		t.Errorf("RevisionHash() should differ when the spec is changed")
+	}
+}
+
+func TestTemplateFromRevision(t *testing.T) {
+	tmpl := testTemplate("v1")
+	rev := NewTemplateRevision(tmpl, 3)
+
+	if rev.Name != "code-server-3" {
+		t.Errorf("NewTemplateRevision() name = %v, want code-server-3", rev.Name)
+	}
+	if rev.Labels[cosmov1alpha1.LabelKeyTemplateName] != "code-server" {
+		t.Errorf("NewTemplateRevision() should have template label")
+	}
+
+	got := TemplateFromRevision(rev)
+	want := testTemplate("v1")
+	delete(want.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("TemplateFromRevision() diff = %s", diff)
+	}
+}
+
+func TestGetTemplate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cosmov1alpha1.AddToScheme(scheme))
+
+	tmpl := testTemplate("v2")
+	rev := NewTemplateRevision(testTemplate("v1"), 1)
+	other := NewTemplateRevision(&cosmov1alpha1.Template{ObjectMeta: metav1.ObjectMeta{Name: "other"}}, 1)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tmpl, rev, other).Build()
+
+	tests := []struct {
+		name        string
+		ref         cosmov1alpha1.TemplateRef
+		wantRawYaml string
+		wantErr     bool
+	}{
+		{
+			name:        "✅ current template",
+			ref:         cosmov1alpha1.TemplateRef{Name: "code-server"},
+			wantRawYaml: "v2",
+		},
+		{
+			name:        "✅ pinned revision",
+			ref:         cosmov1alpha1.TemplateRef{Name: "code-server", Revision: "code-server-1"},
+			wantRawYaml: "v1",
+		},
+		{
+			name:    "❌ revision of other template",
+			ref:     cosmov1alpha1.TemplateRef{Name: "code-server", Revision: "other-1"},
+			wantErr: true,
+		},
+		{
+			name:    "❌ revision not found",
+			ref:     cosmov1alpha1.TemplateRef{Name: "code-server", Revision: "code-server-9"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTemplate(context.Background(), c, tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Spec.RawYaml != tt.wantRawYaml {
+				t.Errorf("GetTemplate() rawYaml = %v, want %v", got.Spec.RawYaml, tt.wantRawYaml)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+// ValidateVarSpecs validates the required var specs of the template and their default values
+func ValidateVarSpecs(specs []cosmov1alpha1.RequiredVarSpec) error {
+	for _, v := range specs {
+		if err := ValidateVarSpec(v); err != nil {
+			return fmt.Errorf("invalid var %s: %w", v.Var, err)
+		}
+	}
+	return nil
+}
+
+// ValidateVarSpec validates the required var spec and its default value
+func ValidateVarSpec(v cosmov1alpha1.RequiredVarSpec) error {
+	switch v.Type {
+	case "", cosmov1alpha1.VarTypeString, cosmov1alpha1.VarTypeInt, cosmov1alpha1.VarTypeBool:
+	case cosmov1alpha1.VarTypeEnum:
+		if len(v.AllowedValues) == 0 {
+			return fmt.Errorf("allowedValues is required for type %s", v.Type)
+		}
+	default:
+		return fmt.Errorf("unknown type %s", v.Type)
+	}
+	if v.Pattern != "" {
+		if _, err := regexp.Compile(v.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+	if v.Minimum != nil && v.Maximum != nil && *v.Minimum > *v.Maximum {
+		return fmt.Errorf("minimum %d is greater than maximum %d", *v.Minimum, *v.Maximum)
+	}
+	if v.Default != "" {
+		if err := ValidateVar(v, v.Default); err != nil {
+			return fmt.Errorf("invalid default value: %w", err)
+		}
+	}
+	return nil
+}
+
+// ValidateVar validates the value satisfies the type and the constraints of the required var spec
+func ValidateVar(v cosmov1alpha1.RequiredVarSpec, value string) error {
+	switch v.Type {
+	case cosmov1alpha1.VarTypeInt:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("value '%s' is not an integer", value)
+		}
+		if v.Minimum != nil && i < *v.Minimum {
+			return fmt.Errorf("value %d is less than minimum %d", i, *v.Minimum)
+		}
+		if v.Maximum != nil && i > *v.Maximum {
+			return fmt.Errorf("value %d is greater than maximum %d", i, *v.Maximum)
+		}
+	case cosmov1alpha1.VarTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("value '%s' is not a boolean", value)
+		}
+	}
+
+	if len(v.AllowedValues) > 0 && !slices.Contains(v.AllowedValues, value) {
+		return fmt.Errorf("value '%s' is not one of %v", value, v.AllowedValues)
+	}
+	if v.Pattern != "" {
+		re, err := regexp.Compile(v.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("value '%s' does not match pattern '%s'", value, v.Pattern)
+		}
+	}
+	return nil
+}
+
+// ValidateVars validates the given vars satisfy the required var specs.
+// Vars not in the specs and specs not given in the vars are ignored.
+func ValidateVars(specs []cosmov1alpha1.RequiredVarSpec, vars map[string]string) error {
+	for _, v := range specs {
+		for key, value := range vars {
+			if FixupTemplateVarKey(key) != FixupTemplateVarKey(v.Var) {
+				continue
+			}
+			if err := ValidateVar(v, value); err != nil {
+				return fmt.Errorf("invalid var %s: %w", v.Var, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RedactedSecretVarValue is shown instead of the values of the secret vars
+const RedactedSecretVarValue = "********"
+
+// IsSecretVar returns whether the var is flagged as secret in the required var specs
+func IsSecretVar(specs []cosmov1alpha1.RequiredVarSpec, key string) bool {
+	for _, v := range specs {
+		if v.Secret && FixupTemplateVarKey(v.Var) == FixupTemplateVarKey(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitSecretVars splits the vars into the plain vars and the secret vars.
+// The keys of the secret vars are trimmed braces to be used as the keys of Secret.
+func SplitSecretVars(specs []cosmov1alpha1.RequiredVarSpec, vars map[string]string) (plain, secret map[string]string) {
+	for key, val := range vars {
+		if IsSecretVar(specs, key) {
+			if secret == nil {
+				secret = make(map[string]string)
+			}
+			secret[TrimTemplateVarKey(key)] = val
+		} else {
+			if plain == nil {
+				plain = make(map[string]string)
+			}
+			plain[key] = val
+		}
+	}
+	return plain, secret
+}
+
+// GetSecretVars returns the values of the secret vars from the Secret referenced by the instance.
+// The default value is used if the var is not found in the Secret.
+func GetSecretVars(ctx context.Context, c client.Client, inst cosmov1alpha1.InstanceObject, tmplSpec cosmov1alpha1.TemplateSpec) (map[string]string, error) {
+	var data map[string][]byte
+	if ref := inst.GetSpec().SecretVarsRef; ref != nil {
+		var secret corev1.Secret
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: inst.GetNamespace()}, &secret); err != nil {
+			return nil, fmt.Errorf("failed to get secret vars %s: %w", ref.Name, err)
+		}
+		data = secret.Data
+	}
+
+	vars := make(map[string]string)
+	for _, v := range tmplSpec.RequiredVars {
+		if !v.Secret {
+			continue
+		}
+		name := TrimTemplateVarKey(v.Var)
+		if val, ok := data[name]; ok {
+			vars[name] = string(val)
+		} else if v.Default != "" {
+			vars[name] = v.Default
+		}
+	}
+	return vars, nil
+}
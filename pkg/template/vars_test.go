@@ -0,0 +1,227 @@
+package template
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func TestValidateVar(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    cosmov1alpha1.RequiredVarSpec
+		value   string
+		wantErr bool
+	}{
+		{
+			name:  "✅ untyped",
+			spec:  cosmov1alpha1.RequiredVarSpec{Var: "NAME"},
+			value: "anything",
+		},
+		{
+			name:  "✅ string with pattern",
+			spec:  cosmov1alpha1.RequiredVarSpec{Var: "NAME", Type: cosmov1alpha1.VarTypeString, Pattern: "^[a-z]+$"},
+			value: "abc",
+		},
+		{
+			name:    "❌ string not match pattern",
+			spec:    cosmov1alpha1.RequiredVarSpec{Var: "NAME", Type: cosmov1alpha1.VarTypeString, Pattern: "^[a-z]+$"},
+			value:   "ABC",
+			wantErr: true,
+		},
+		{
+			name:  "✅ int in range",
+			spec:  cosmov1alpha1.RequiredVarSpec{Var: "CPU", Type: cosmov1alpha1.VarTypeInt, Minimum: ptr.To[int64](1), Maximum: ptr.To[int64](4)},
+			value: "4",
+		},
+		{
+			name:    "❌ int over maximum",
+			spec:    cosmov1alpha1.RequiredVarSpec{Var: "CPU", Type: cosmov1alpha1.VarTypeInt, Minimum: ptr.To[int64](1), Maximum: ptr.To[int64](4)},
+			value:   "5",
+			wantErr: true,
+		},
+		{
+			name:    "❌ int under minimum",
+			spec:    cosmov1alpha1.RequiredVarSpec{Var: "CPU", Type: cosmov1alpha1.VarTypeInt, Minimum: ptr.To[int64](1)},
+			value:   "0",
+			wantErr: true,
+		},
+		{
+			name:    "❌ not int",
+			spec:    cosmov1alpha1.RequiredVarSpec{Var: "CPU", Type: cosmov1alpha1.VarTypeInt},
+			value:   "1.5",
+			wantErr: true,
+		},
+		{
+			name:  "✅ bool",
+			spec:  cosmov1alpha1.RequiredVarSpec{Var: "DOCKER_ENABLED", Type: cosmov1alpha1.VarTypeBool},
+			value: "true",
+		},
+		{
+			name:    "❌ not bool",
+			spec:    cosmov1alpha1.RequiredVarSpec{Var: "DOCKER_ENABLED", Type: cosmov1alpha1.VarTypeBool},
+			value:   "yes",
+			wantErr: true,
+		},
+		{
+			name:  "✅ enum",
+			spec:  cosmov1alpha1.RequiredVarSpec{Var: "SIZE", Type: cosmov1alpha1.VarTypeEnum, AllowedValues: []string{"small", "large"}},
+			value: "large",
+		},
+		{
+			name:    "❌ not in enum",
+			spec:    cosmov1alpha1.RequiredVarSpec{Var: "SIZE", Type: cosmov1alpha1.VarTypeEnum, AllowedValues: []string{"small", "large"}},
+			value:   "medium",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateVar(tt.spec, tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateVar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateVarSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    cosmov1alpha1.RequiredVarSpec
+		wantErr bool
+	}{
+		{
+			name: "✅ OK",
+			spec: cosmov1alpha1.RequiredVarSpec{Var: "CPU", Type: cosmov1alpha1.VarTypeInt, Default: "2", Minimum: ptr.To[int64](1), Maximum: ptr.To[int64](4)},
+		},
+		{
+			name:    "❌ unknown type",
+			spec:    cosmov1alpha1.RequiredVarSpec{Var: "CPU", Type: "float"},
+			wantErr: true,
+		},
+		{
+			name:    "❌ enum without allowed values",
+			spec:    cosmov1alpha1.RequiredVarSpec{Var: "SIZE", Type: cosmov1alpha1.VarTypeEnum},
+			wantErr: true,
+		},
+		{
+			name:    "❌ invalid pattern",
+			spec:    cosmov1alpha1.RequiredVarSpec{Var: "NAME", Pattern: "[a-z"},
+			wantErr: true,
+		},
+		{
+			name:    "❌ minimum greater than maximum",
+			spec:    cosmov1alpha1.RequiredVarSpec{Var: "CPU", Type: cosmov1alpha1.VarTypeInt, Minimum: ptr.To[int64](4), Maximum: ptr.To[int64](1)},
+			wantErr: true,
+		},
+		{
+			name:    "❌ invalid default",
+			spec:    cosmov1alpha1.RequiredVarSpec{Var: "CPU", Type: cosmov1alpha1.VarTypeInt, Default: "8", Maximum: ptr.To[int64](4)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateVarSpec(tt.spec); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateVarSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateVars(t *testing.T) {
+	specs := []cosmov1alpha1.RequiredVarSpec{
+		{Var: "{{CPU}}", Type: cosmov1alpha1.VarTypeInt},
+		{Var: "SIZE", Type: cosmov1alpha1.VarTypeEnum, AllowedValues: []string{"small", "large"}},
+	}
+
+	if err := ValidateVars(specs, map[string]string{"CPU": "2", "{{SIZE}}": "small", "OTHER": "x"}); err != nil {
+		t.Errorf("ValidateVars() error = %v, want nil", err)
+	}
+	if err := ValidateVars(specs, map[string]string{"{{CPU}}": "two"}); err == nil {
+		t.Errorf("ValidateVars() error = nil, want error")
+	}
+}
+
+func TestSplitSecretVars(t *testing.T) {
+	specs := []cosmov1alpha1.RequiredVarSpec{
+		{Var: "{{CPU}}"},
+		{Var: "{{PASSWORD}}", Secret: true},
+	}
+
+	plain, secret := SplitSecretVars(specs, map[string]string{"{{CPU}}": "2", "{{PASSWORD}}": "xxx"})
+	if diff := cmp.Diff(map[string]string{"{{CPU}}": "2"}, plain); diff != "" {
+		t.Errorf("SplitSecretVars() plain mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"PASSWORD": "xxx"}, secret); diff != "" {
+		t.Errorf("SplitSecretVars() secret mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetSecretVars(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1-secret-vars", Namespace: "cosmo-user-tom"},
+		Data:       map[string][]byte{"PASSWORD": []byte("xxx")},
+	}
+	tmplSpec := cosmov1alpha1.TemplateSpec{
+		RequiredVars: []cosmov1alpha1.RequiredVarSpec{
+			{Var: "{{CPU}}", Default: "1"},
+			{Var: "{{PASSWORD}}", Secret: true},
+			{Var: "{{TOKEN}}", Secret: true, Default: "default-token"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		ref     *cosmov1alpha1.SecretVarsReference
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "✅ OK",
+			ref:  &cosmov1alpha1.SecretVarsReference{Name: "ws1-secret-vars"},
+			want: map[string]string{"PASSWORD": "xxx", "TOKEN": "default-token"},
+		},
+		{
+			name: "✅ No reference",
+			want: map[string]string{"TOKEN": "default-token"},
+		},
+		{
+			name:    "❌ Secret not found",
+			ref:     &cosmov1alpha1.SecretVarsReference{Name: "notfound"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret.DeepCopy()).Build()
+			inst := &cosmov1alpha1.Instance{
+				ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: "cosmo-user-tom"},
+				Spec:       cosmov1alpha1.InstanceSpec{SecretVarsRef: tt.ref},
+			}
+			got, err := GetSecretVars(context.TODO(), c, inst, tmplSpec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetSecretVars() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("GetSecretVars() mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
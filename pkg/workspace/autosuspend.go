@@ -0,0 +1,82 @@
+package workspace
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+)
+
+// IdleTimeout returns the idle duration after which the workspace is suspended automatically.
+// Workspace annotation takes precedence over Template annotation, and Template annotation over defaultTimeout.
+// Zero or negative duration means auto-suspend is disabled.
+func IdleTimeout(ws *cosmov1alpha1.Workspace, tmpl *cosmov1alpha1.Template, defaultTimeout time.Duration) (time.Duration, error) {
+	if v := kubeutil.GetAnnotation(ws, cosmov1alpha1.WorkspaceAnnKeyIdleTimeout); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid idle timeout on workspace annotation: %w", err)
+		}
+		return d, nil
+	}
+	if tmpl != nil {
+		if v := kubeutil.GetAnnotation(tmpl, cosmov1alpha1.WorkspaceAnnKeyIdleTimeout); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return 0, fmt.Errorf("invalid idle timeout on template annotation: %w", err)
+			}
+			return d, nil
+		}
+	}
+	return defaultTimeout, nil
+}
+
+// LastActivity returns the latest time of the workspace activity,
+// which is the latest of last-started-at, last-accessed-at and creation timestamp.
+func LastActivity(ws *cosmov1alpha1.Workspace) time.Time {
+	last := ws.CreationTimestamp.Time
+	for _, key := range []string{cosmov1alpha1.WorkspaceAnnKeyLastStartedAt, cosmov1alpha1.WorkspaceAnnKeyLastAccessedAt} {
+		v := kubeutil.GetAnnotation(ws, key)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			continue
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
+
+// FindWorkspaceByHost returns the workspace which has the URL with the given host.
+func FindWorkspaceByHost(wss []cosmov1alpha1.Workspace, host string) *cosmov1alpha1.Workspace {
+	host = hostname(host)
+	if host == "" {
+		return nil
+	}
+	for i, ws := range wss {
+		for _, u := range ws.Status.URLs {
+			parsed, err := url.Parse(u)
+			if err != nil {
+				continue
+			}
+			if hostname(parsed.Host) == host {
+				return &wss[i]
+			}
+		}
+	}
+	return nil
+}
+
+func hostname(hostport string) string {
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		hostport = h
+	}
+	return strings.ToLower(hostport)
+}
@@ -0,0 +1,187 @@
+package workspace
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func TestIdleTimeout(t *testing.T) {
+	wsWithAnn := func(v string) *cosmov1alpha1.Workspace {
+		ws := &cosmov1alpha1.Workspace{}
+		if v != "" {
+			ws.SetAnnotations(map[string]string{cosmov1alpha1.WorkspaceAnnKeyIdleTimeout: v})
+		}
+		return ws
+	}
+	tmplWithAnn := func(v string) *cosmov1alpha1.Template {
+		tmpl := &cosmov1alpha1.Template{}
+		if v != "" {
+			tmpl.SetAnnotations(map[string]string{cosmov1alpha1.WorkspaceAnnKeyIdleTimeout: v})
+		}
+		return tmpl
+	}
+	tests := []struct {
+		name           string
+		ws             *cosmov1alpha1.Workspace
+		tmpl           *cosmov1alpha1.Template
+		defaultTimeout time.Duration
+		want           time.Duration
+		wantErr        bool
+	}{
+		{
+			name:           "default",
+			ws:             wsWithAnn(""),
+			tmpl:           tmplWithAnn(""),
+			defaultTimeout: time.Hour,
+			want:           time.Hour,
+		},
+		{
+			name:           "nil template",
+			ws:             wsWithAnn(""),
+			defaultTimeout: time.Hour,
+			want:           time.Hour,
+		},
+		{
+			name:           "template override",
+			ws:             wsWithAnn(""),
+			tmpl:           tmplWithAnn("30m"),
+			defaultTimeout: time.Hour,
+			want:           30 * time.Minute,
+		},
+		{
+			name:           "workspace override",
+			ws:             wsWithAnn("2h"),
+			tmpl:           tmplWithAnn("30m"),
+			defaultTimeout: time.Hour,
+			want:           2 * time.Hour,
+		},
+		{
+			name:           "workspace disabled",
+			ws:             wsWithAnn("0"),
+			tmpl:           tmplWithAnn("30m"),
+			defaultTimeout: time.Hour,
+			want:           0,
+		},
+		{
+			name:    "invalid workspace annotation",
+			ws:      wsWithAnn("xxx"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid template annotation",
+			ws:      wsWithAnn(""),
+			tmpl:    tmplWithAnn("1day"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IdleTimeout(tt.ws, tt.tmpl, tt.defaultTimeout)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IdleTimeout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IdleTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastActivity(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		ann  map[string]string
+		want time.Time
+	}{
+		{
+			name: "creation timestamp",
+			want: created,
+		},
+		{
+			name: "last started",
+			ann: map[string]string{
+				cosmov1alpha1.WorkspaceAnnKeyLastStartedAt: "2024-01-02T00:00:00Z",
+			},
+			want: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "last accessed is later",
+			ann: map[string]string{
+				cosmov1alpha1.WorkspaceAnnKeyLastStartedAt:  "2024-01-02T00:00:00Z",
+				cosmov1alpha1.WorkspaceAnnKeyLastAccessedAt: "2024-01-03T00:00:00Z",
+			},
+			want: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "invalid value is ignored",
+			ann: map[string]string{
+				cosmov1alpha1.WorkspaceAnnKeyLastAccessedAt: "yesterday",
+			},
+			want: created,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &cosmov1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(created),
+					Annotations:       tt.ann,
+				},
+			}
+			if got := LastActivity(ws); !got.Equal(tt.want) {
+				t.Errorf("LastActivity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindWorkspaceByHost(t *testing.T) {
+	wss := []cosmov1alpha1.Workspace{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ws1"},
+			Status: cosmov1alpha1.WorkspaceStatus{
+				URLs: map[string]string{"main": "https://main-ws1-tom.example.com"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ws2"},
+			Status: cosmov1alpha1.WorkspaceStatus{
+				URLs: map[string]string{
+					"main": "https://main-ws2-tom.example.com:8443/",
+					"app":  "https://app-ws2-tom.example.com/app",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name string
+		host string
+		want string
+	}{
+		{name: "match", host: "main-ws1-tom.example.com", want: "ws1"},
+		{name: "match with port and case", host: "MAIN-WS2-TOM.example.com:8443", want: "ws2"},
+		{name: "match sub url", host: "app-ws2-tom.example.com", want: "ws2"},
+		{name: "not found", host: "main-ws3-tom.example.com"},
+		{name: "empty", host: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindWorkspaceByHost(wss, tt.host)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("FindWorkspaceByHost() = %v, want nil", got.Name)
+				}
+				return
+			}
+			if got == nil || got.Name != tt.want {
+				t.Errorf("FindWorkspaceByHost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package workspace
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+)
+
+// ClonedResourceName returns the name of the resource in the cloned workspace.
+// The resource name built from the template is prefixed with the instance name, which is same as the workspace name.
+// It returns empty if the name is not prefixed with the source workspace name.
+func ClonedResourceName(name, srcWsName, dstWsName string) string {
+	if name == srcWsName {
+		return dstWsName
+	}
+	if strings.HasPrefix(name, srcWsName+"-") {
+		return dstWsName + strings.TrimPrefix(name, srcWsName)
+	}
+	return ""
+}
+
+// ClonedPersistentVolumeClaim returns the PVC cloned from the source PVC by CSI volume cloning.
+// The owner reference and the labels are attached by the instance controller when it applies the template.
+// Cloning across namespaces requires CrossNamespaceVolumeDataSource feature and ReferenceGrant in the source namespace.
+func ClonedPersistentVolumeClaim(src *corev1.PersistentVolumeClaim, name, namespace string) *corev1.PersistentVolumeClaim {
+	spec := src.Spec.DeepCopy()
+	spec.VolumeName = ""
+	spec.DataSource = nil
+	spec.DataSourceRef = nil
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: *spec,
+	}
+	if v := kubeutil.GetAnnotation(src, cosmov1alpha1.ResourceAnnKeyDeletePolicy); v != "" {
+		kubeutil.SetAnnotation(pvc, cosmov1alpha1.ResourceAnnKeyDeletePolicy, v)
+	}
+
+	if src.Namespace == namespace {
+		pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+			Kind: "PersistentVolumeClaim",
+			Name: src.Name,
+		}
+	} else {
+		pvc.Spec.DataSourceRef = &corev1.TypedObjectReference{
+			Kind:      "PersistentVolumeClaim",
+			Name:      src.Name,
+			Namespace: ptr.To(src.Namespace),
+		}
+	}
+	return pvc
+}
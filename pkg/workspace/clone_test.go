@@ -0,0 +1,26 @@
+package workspace
+
+import "testing"
+
+func TestClonedResourceName(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		dst  string
+		want string
+	}{
+		{name: "ws1-home", src: "ws1", dst: "ws2", want: "ws2-home"},
+		{name: "ws1", src: "ws1", dst: "ws2", want: "ws2"},
+		{name: "home-ws1", src: "ws1", dst: "ws2", want: ""},
+		{name: "myws1-home", src: "ws1", dst: "ws2", want: ""},
+		{name: "ws1-ws1-home", src: "ws1", dst: "ws2", want: "ws2-ws1-home"},
+		{name: "shared-cache", src: "ws1", dst: "ws2", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClonedResourceName(tt.name, tt.src, tt.dst); got != tt.want {
+				t.Errorf("ClonedResourceName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package workspace
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 )
@@ -18,6 +20,17 @@ func SetConfigOnTemplateAnnotations(tmpl cosmov1alpha1.TemplateObject, cfg cosmo
 	ann[cosmov1alpha1.WorkspaceTemplateAnnKeyDeploymentName] = cfg.DeploymentName
 	ann[cosmov1alpha1.WorkspaceTemplateAnnKeyServiceName] = cfg.ServiceName
 	ann[cosmov1alpha1.WorkspaceTemplateAnnKeyServiceMainPort] = cfg.ServiceMainPortName
+
+	if cfg.WorkloadKind != "" {
+		ann[cosmov1alpha1.WorkspaceTemplateAnnKeyWorkloadKind] = cfg.WorkloadKind
+	} else {
+		delete(ann, cosmov1alpha1.WorkspaceTemplateAnnKeyWorkloadKind)
+	}
+	if len(cfg.AdditionalWorkloads) > 0 {
+		ann[cosmov1alpha1.WorkspaceTemplateAnnKeyAdditionalWorkloads] = FormatWorkloadRefs(cfg.AdditionalWorkloads)
+	} else {
+		delete(ann, cosmov1alpha1.WorkspaceTemplateAnnKeyAdditionalWorkloads)
+	}
 	tmpl.SetAnnotations(ann)
 }
 
@@ -42,6 +55,45 @@ func ConfigFromTemplateAnnotations(tmpl *cosmov1alpha1.Template) (cfg cosmov1alp
 		DeploymentName:      ann[cosmov1alpha1.WorkspaceTemplateAnnKeyDeploymentName],
 		ServiceName:         ann[cosmov1alpha1.WorkspaceTemplateAnnKeyServiceName],
 		ServiceMainPortName: ann[cosmov1alpha1.WorkspaceTemplateAnnKeyServiceMainPort],
+		WorkloadKind:        ann[cosmov1alpha1.WorkspaceTemplateAnnKeyWorkloadKind],
+	}
+	if cfg.WorkloadKind != "" && !cosmov1alpha1.IsValidWorkloadKind(cfg.WorkloadKind) {
+		return cfg, fmt.Errorf("invalid workload kind '%s': must be Deployment or StatefulSet", cfg.WorkloadKind)
+	}
+	if v := ann[cosmov1alpha1.WorkspaceTemplateAnnKeyAdditionalWorkloads]; v != "" {
+		cfg.AdditionalWorkloads, err = ParseWorkloadRefs(v)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid additional workloads: %w", err)
+		}
 	}
 	return cfg, nil
 }
+
+// ParseWorkloadRefs parses the comma separated list of the workloads in "KIND/NAME" format
+func ParseWorkloadRefs(s string) ([]cosmov1alpha1.WorkloadRef, error) {
+	refs := make([]cosmov1alpha1.WorkloadRef, 0)
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		kind, name, ok := strings.Cut(v, "/")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("'%s' is not in KIND/NAME format", v)
+		}
+		if !cosmov1alpha1.IsValidWorkloadKind(kind) {
+			return nil, fmt.Errorf("'%s' is not Deployment or StatefulSet", v)
+		}
+		refs = append(refs, cosmov1alpha1.WorkloadRef{Kind: kind, Name: name})
+	}
+	return refs, nil
+}
+
+// FormatWorkloadRefs formats the workloads in the comma separated list of "KIND/NAME"
+func FormatWorkloadRefs(refs []cosmov1alpha1.WorkloadRef) string {
+	s := make([]string, len(refs))
+	for i, ref := range refs {
+		s[i] = ref.String()
+	}
+	return strings.Join(s, ",")
+}
@@ -5,6 +5,7 @@ import (
 
 	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
 	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -149,3 +150,49 @@ func TestConfigFromTemplateAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestParseWorkloadRefs(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []cosmov1alpha1.WorkloadRef
+		wantErr bool
+	}{
+		{
+			name: "✅ OK",
+			s:    "Deployment/db, StatefulSet/cache",
+			want: []cosmov1alpha1.WorkloadRef{{Kind: "Deployment", Name: "db"}, {Kind: "StatefulSet", Name: "cache"}},
+		},
+		{
+			name: "✅ Empty",
+			s:    "",
+			want: []cosmov1alpha1.WorkloadRef{},
+		},
+		{
+			name:    "❌ No kind",
+			s:       "db",
+			wantErr: true,
+		},
+		{
+			name:    "❌ Unsupported kind",
+			s:       "DaemonSet/db",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWorkloadRefs(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWorkloadRefs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseWorkloadRefs() mismatch (-want +got):\n%s", diff)
+			}
+			if !tt.wantErr && len(got) > 0 {
+				if s := FormatWorkloadRefs(got); s != "Deployment/db,StatefulSet/cache" {
+					t.Errorf("FormatWorkloadRefs() = %v", s)
+				}
+			}
+		})
+	}
+}
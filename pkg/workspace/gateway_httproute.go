@@ -0,0 +1,110 @@
+package workspace
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/instance"
+)
+
+// GatewayHTTPRouteConfig is a RoutingProvider by Gateway API HTTPRoute.
+// HTTPRoute is created for each http network rule
+type GatewayHTTPRouteConfig struct {
+	RoutingConfig
+
+	// ParentRef is the reference to the Gateway which HTTPRoutes are attached to
+	ParentRef gatewayv1.ParentReference
+	// AuthFilter is the reference to the extension filter to authenticate the requests to private network rules,
+	// such as ext-auth of the Gateway implementation. It must be in the same namespace as the workspace.
+	// The dashboard auth endpoint finds the allowed users by the request host and path,
+	// so the filter is independent of the order and the headers of other filters.
+	// If empty, private network rules are not routed.
+	AuthFilter gatewayv1.LocalObjectReference
+}
+
+func (c *GatewayHTTPRouteConfig) Supports(r cosmov1alpha1.NetworkRule) bool {
+	return r.Protocol == cosmov1alpha1.NetworkRuleProtocolHTTP && (r.Public || c.AuthFilter.Name != "")
+}
+
+func (c *GatewayHTTPRouteConfig) Routes(ws cosmov1alpha1.Workspace, scheme *runtime.Scheme) []Route {
+	routes := make([]Route, 0, len(ws.Spec.Network))
+	for i, netRule := range ws.Spec.Network {
+		if !c.Supports(netRule) {
+			continue
+		}
+		hr := &gatewayv1.HTTPRoute{}
+		hr.SetName(routeName(ws, i))
+		hr.SetNamespace(ws.Namespace)
+
+		r := netRule
+		routes = append(routes, Route{
+			Object: hr,
+			Patch:  func() error { return c.PatchHTTPRouteAsDesired(hr, r, ws, scheme) },
+		})
+	}
+	return routes
+}
+
+func (c *GatewayHTTPRouteConfig) NewRouteList() client.ObjectList {
+	return &gatewayv1.HTTPRouteList{}
+}
+
+func (c *GatewayHTTPRouteConfig) PatchHTTPRouteAsDesired(hr *gatewayv1.HTTPRoute, r cosmov1alpha1.NetworkRule, ws cosmov1alpha1.Workspace, scheme *runtime.Scheme) error {
+	// metadata
+	cosmov1alpha1.SetControllerManaged(hr)
+	setWorkspaceNameLabel(hr, ws)
+
+	// spec
+	hr.Spec.ParentRefs = []gatewayv1.ParentReference{c.ParentRef}
+	hr.Spec.Hostnames = []gatewayv1.Hostname{gatewayv1.Hostname(c.Host(r, ws))}
+	hr.Spec.Rules = []gatewayv1.HTTPRouteRule{c.HTTPRouteRule(r, ws)}
+
+	if err := cosmov1alpha1.SetOwnerReferenceIfNotKeepPolicy(&ws, hr, scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	return nil
+}
+
+func (c *GatewayHTTPRouteConfig) HTTPRouteRule(r cosmov1alpha1.NetworkRule, ws cosmov1alpha1.Workspace) gatewayv1.HTTPRouteRule {
+	path := r.HTTPPath
+	if path == "" {
+		path = "/"
+	}
+
+	filters := make([]gatewayv1.HTTPRouteFilter, 0)
+	if !r.Public {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{
+			Type:         gatewayv1.HTTPRouteFilterExtensionRef,
+			ExtensionRef: c.AuthFilter.DeepCopy(),
+		})
+	}
+
+	backendSvcName := instance.InstanceResourceName(ws.Name, ws.Status.Config.ServiceName)
+
+	return gatewayv1.HTTPRouteRule{
+		Matches: []gatewayv1.HTTPRouteMatch{
+			{
+				Path: &gatewayv1.HTTPPathMatch{
+					Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+					Value: ptr.To(path),
+				},
+			},
+		},
+		Filters: filters,
+		BackendRefs: []gatewayv1.HTTPBackendRef{
+			{
+				BackendRef: gatewayv1.BackendRef{
+					BackendObjectReference: gatewayv1.BackendObjectReference{
+						Name: gatewayv1.ObjectName(backendSvcName),
+						Port: ptr.To(gatewayv1.PortNumber(r.PortNumber)),
+					},
+				},
+			},
+		},
+	}
+}
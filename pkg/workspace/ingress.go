@@ -0,0 +1,118 @@
+package workspace
+
+import (
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/instance"
+)
+
+// IngressAuthVarUsers is replaced with the comma-separated names of the owner and shared users in IngressConfig.AuthAnnotations
+const IngressAuthVarUsers = "{{USERS}}"
+
+// IngressConfig is a RoutingProvider by networking.k8s.io/v1 Ingress.
+// Ingress is created for each http network rule
+type IngressConfig struct {
+	RoutingConfig
+
+	// IngressClassName is the class of Ingresses
+	IngressClassName string
+	// AuthAnnotations are the annotations on the Ingresses of private network rules to authenticate the requests,
+	// such as nginx.ingress.kubernetes.io/auth-url of ingress-nginx.
+	// If empty, private network rules are not routed.
+	AuthAnnotations map[string]string
+}
+
+func (c *IngressConfig) Supports(r cosmov1alpha1.NetworkRule) bool {
+	return r.Protocol == cosmov1alpha1.NetworkRuleProtocolHTTP && (r.Public || len(c.AuthAnnotations) > 0)
+}
+
+func (c *IngressConfig) Routes(ws cosmov1alpha1.Workspace, scheme *runtime.Scheme) []Route {
+	routes := make([]Route, 0, len(ws.Spec.Network))
+	for i, netRule := range ws.Spec.Network {
+		if !c.Supports(netRule) {
+			continue
+		}
+		ing := &networkingv1.Ingress{}
+		ing.SetName(routeName(ws, i))
+		ing.SetNamespace(ws.Namespace)
+
+		r := netRule
+		routes = append(routes, Route{
+			Object: ing,
+			Patch:  func() error { return c.PatchIngressAsDesired(ing, r, ws, scheme) },
+		})
+	}
+	return routes
+}
+
+func (c *IngressConfig) NewRouteList() client.ObjectList {
+	return &networkingv1.IngressList{}
+}
+
+func (c *IngressConfig) PatchIngressAsDesired(ing *networkingv1.Ingress, r cosmov1alpha1.NetworkRule, ws cosmov1alpha1.Workspace, scheme *runtime.Scheme) error {
+	// metadata
+	cosmov1alpha1.SetControllerManaged(ing)
+	setWorkspaceNameLabel(ing, ws)
+
+	ann := ing.GetAnnotations()
+	if ann == nil {
+		ann = make(map[string]string)
+	}
+	users := strings.Join(AllowedUsers(r, ws), ",")
+	for k, v := range c.AuthAnnotations {
+		if r.Public {
+			delete(ann, k)
+		} else {
+			ann[k] = strings.ReplaceAll(v, IngressAuthVarUsers, users)
+		}
+	}
+	ing.SetAnnotations(ann)
+
+	// spec
+	if c.IngressClassName != "" {
+		ing.Spec.IngressClassName = ptr.To(c.IngressClassName)
+	} else {
+		ing.Spec.IngressClassName = nil
+	}
+	ing.Spec.Rules = []networkingv1.IngressRule{c.IngressRule(r, ws)}
+
+	if err := cosmov1alpha1.SetOwnerReferenceIfNotKeepPolicy(&ws, ing, scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	return nil
+}
+
+func (c *IngressConfig) IngressRule(r cosmov1alpha1.NetworkRule, ws cosmov1alpha1.Workspace) networkingv1.IngressRule {
+	path := r.HTTPPath
+	if path == "" {
+		path = "/"
+	}
+	backendSvcName := instance.InstanceResourceName(ws.Name, ws.Status.Config.ServiceName)
+
+	return networkingv1.IngressRule{
+		Host: c.Host(r, ws),
+		IngressRuleValue: networkingv1.IngressRuleValue{
+			HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{
+					{
+						Path:     path,
+						PathType: ptr.To(networkingv1.PathTypePrefix),
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: backendSvcName,
+								Port: networkingv1.ServiceBackendPort{Number: r.PortNumber},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
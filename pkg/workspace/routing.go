@@ -0,0 +1,136 @@
+package workspace
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+const (
+	RoutingProviderTraefik    = "traefik"
+	RoutingProviderGatewayAPI = "gateway-api"
+	RoutingProviderIngress    = "ingress"
+)
+
+var RoutingProviders = []string{RoutingProviderTraefik, RoutingProviderGatewayAPI, RoutingProviderIngress}
+
+// RoutingProvider builds the routing resources for the network rules of workspaces
+type RoutingProvider interface {
+	// Supports returns true if the network rule is routed by the provider
+	Supports(r cosmov1alpha1.NetworkRule) bool
+	// Routes returns the routing resources of the workspace
+	Routes(ws cosmov1alpha1.Workspace, scheme *runtime.Scheme) []Route
+	// URL returns the URL of the network rule
+	URL(r cosmov1alpha1.NetworkRule, ws cosmov1alpha1.Workspace) string
+}
+
+// RouteLister is implemented by the providers which create the routing resources for each network rule.
+// The resources labeled with the workspace name and not in the Routes are deleted
+type RouteLister interface {
+	NewRouteList() client.ObjectList
+}
+
+// Route is a routing resource of a workspace
+type Route struct {
+	// Object is the resource with name and namespace
+	Object client.Object
+	// Patch modifies Object as desired. nil means Object is not necessary and deleted if exists
+	Patch func() error
+}
+
+// RoutingConfig is the common configuration of the routing providers
+type RoutingConfig struct {
+	// URLProtocol is the protocol of http network rule URLs. http or https
+	URLProtocol string
+	// HostBase is a base of hostname
+	HostBase string
+	// Domain is a domain of hostname
+	Domain string
+	// TCPPort is the external port in the URLs of tcp network rules
+	TCPPort int32
+}
+
+func (c RoutingConfig) Host(r cosmov1alpha1.NetworkRule, ws cosmov1alpha1.Workspace) string {
+	return cosmov1alpha1.GenHost(c.HostBase, c.Domain, r.HostPrefix(), ws)
+}
+
+func (c RoutingConfig) URL(r cosmov1alpha1.NetworkRule, ws cosmov1alpha1.Workspace) string {
+	host := c.Host(r, ws)
+	if r.Protocol == cosmov1alpha1.NetworkRuleProtocolTCP {
+		if c.TCPPort > 0 {
+			host = net.JoinHostPort(host, strconv.Itoa(int(c.TCPPort)))
+		}
+		return cosmov1alpha1.GenURL(cosmov1alpha1.NetworkRuleProtocolTCP, host, "")
+	}
+	return cosmov1alpha1.GenURL(c.URLProtocol, host, r.HTTPPath)
+}
+
+// routeName returns the name of the routing resource for the network rule at the index
+func routeName(ws cosmov1alpha1.Workspace, index int) string {
+	return fmt.Sprintf("%s-%d", ws.Name, index)
+}
+
+func setWorkspaceNameLabel(obj cosmov1alpha1.LabelHolder, ws cosmov1alpha1.Workspace) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[cosmov1alpha1.LabelKeyWorkspaceName] = ws.Name
+	obj.SetLabels(labels)
+}
+
+// AllowedUsers returns the sorted names of the owner and the shared users of the network rule
+func AllowedUsers(r cosmov1alpha1.NetworkRule, ws cosmov1alpha1.Workspace) []string {
+	users := append([]string{cosmov1alpha1.UserNameByNamespace(ws.Namespace)}, r.AllowedUsers...)
+	slices.Sort(users)
+	return slices.Compact(users)
+}
+
+// FindNetworkRuleByURL returns the workspace and its http network rule routed for the request host and path.
+// The rule with the longest path is returned if the rules of the same host match.
+func FindNetworkRuleByURL(wss []cosmov1alpha1.Workspace, host, path string) (*cosmov1alpha1.Workspace, *cosmov1alpha1.NetworkRule) {
+	host = hostname(host)
+	if host == "" {
+		return nil, nil
+	}
+	if path == "" {
+		path = "/"
+	}
+	var (
+		foundWs   *cosmov1alpha1.Workspace
+		foundRule *cosmov1alpha1.NetworkRule
+		foundPath string
+	)
+	for i, ws := range wss {
+		for j, r := range ws.Spec.Network {
+			if r.Protocol == cosmov1alpha1.NetworkRuleProtocolTCP {
+				continue
+			}
+			u, ok := ws.Status.URLs[r.UniqueKey()]
+			if !ok {
+				continue
+			}
+			parsed, err := url.Parse(u)
+			if err != nil || hostname(parsed.Host) != host {
+				continue
+			}
+			rulePath := parsed.Path
+			if rulePath == "" {
+				rulePath = "/"
+			}
+			if !strings.HasPrefix(path, rulePath) || (foundRule != nil && len(rulePath) <= len(foundPath)) {
+				continue
+			}
+			foundWs, foundRule, foundPath = &wss[i], &wss[i].Spec.Network[j], rulePath
+		}
+	}
+	return foundWs, foundRule
+}
@@ -0,0 +1,252 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func routingTestWorkspace() cosmov1alpha1.Workspace {
+	return cosmov1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ws1",
+			Namespace: "cosmo-user-tom",
+		},
+		Spec: cosmov1alpha1.WorkspaceSpec{
+			Network: []cosmov1alpha1.NetworkRule{
+				{
+					Protocol:         "http",
+					PortNumber:       8080,
+					HTTPPath:         "/",
+					CustomHostPrefix: "main",
+					AllowedUsers:     []string{"jerry"},
+				},
+				{
+					Protocol:   "tcp",
+					PortNumber: 5432,
+					Public:     true,
+				},
+				{
+					Protocol:   "http",
+					PortNumber: 3000,
+					HTTPPath:   "/api",
+					Public:     true,
+				},
+			},
+		},
+		Status: cosmov1alpha1.WorkspaceStatus{
+			Config: cosmov1alpha1.Config{
+				ServiceName: "svc",
+			},
+		},
+	}
+}
+
+func routingTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cosmov1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestRoutingConfig_URL(t *testing.T) {
+	ws := routingTestWorkspace()
+	tests := []struct {
+		name string
+		cfg  RoutingConfig
+		r    cosmov1alpha1.NetworkRule
+		want string
+	}{
+		{
+			name: "✅ http",
+			cfg:  RoutingConfig{URLProtocol: "https", Domain: "example.com"},
+			r:    ws.Spec.Network[2],
+			want: "https://port3000-ws1-tom.example.com/api",
+		},
+		{
+			name: "✅ tcp",
+			cfg:  RoutingConfig{URLProtocol: "https", Domain: "example.com", TCPPort: 443},
+			r:    ws.Spec.Network[1],
+			want: "tcp://port5432-ws1-tom.example.com:443",
+		},
+		{
+			name: "✅ tcp without port",
+			cfg:  RoutingConfig{URLProtocol: "https", Domain: "example.com"},
+			r:    ws.Spec.Network[1],
+			want: "tcp://port5432-ws1-tom.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.URL(tt.r, ws); got != tt.want {
+				t.Errorf("RoutingConfig.URL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowedUsers(t *testing.T) {
+	ws := routingTestWorkspace()
+	r := cosmov1alpha1.NetworkRule{AllowedUsers: []string{"jerry", "tom", "alice"}}
+	want := []string{"alice", "jerry", "tom"}
+	if diff := cmp.Diff(want, AllowedUsers(r, ws)); diff != "" {
+		t.Errorf("AllowedUsers() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFindNetworkRuleByURL(t *testing.T) {
+	ws := routingTestWorkspace()
+	cfg := RoutingConfig{URLProtocol: "https", HostBase: "ws1-tom", Domain: "example.com", TCPPort: 443}
+	ws.Status.URLs = make(map[string]string)
+	for _, r := range ws.Spec.Network {
+		ws.Status.URLs[r.UniqueKey()] = cfg.URL(r, ws)
+	}
+	wss := []cosmov1alpha1.Workspace{{}, ws}
+
+	tests := []struct {
+		name     string
+		host     string
+		path     string
+		wantPort int32
+	}{
+		{
+			name:     "✅ root path",
+			host:     "ws1-tom.example.com",
+			path:     "/index.html",
+			wantPort: 8080,
+		},
+		{
+			name:     "✅ longest path",
+			host:     "WS1-tom.example.com:443",
+			path:     "/api/v1",
+			wantPort: 3000,
+		},
+		{
+			name: "❌ unknown host",
+			host: "ws2-tom.example.com",
+			path: "/",
+		},
+		{
+			name: "❌ empty host",
+			path: "/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotWs, got := FindNetworkRuleByURL(wss, tt.host, tt.path)
+			if tt.wantPort == 0 {
+				if got != nil {
+					t.Errorf("FindNetworkRuleByURL() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || gotWs.Name != ws.Name {
+				t.Fatalf("FindNetworkRuleByURL() not found")
+			}
+			if got.PortNumber != tt.wantPort {
+				t.Errorf("FindNetworkRuleByURL() port = %d, want %d", got.PortNumber, tt.wantPort)
+			}
+		})
+	}
+}
+
+func TestGatewayHTTPRouteConfig_Routes(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       *GatewayHTTPRouteConfig
+		wantNames []string
+	}{
+		{
+			name: "✅ with auth filter",
+			cfg: &GatewayHTTPRouteConfig{
+				RoutingConfig: RoutingConfig{Domain: "example.com"},
+				ParentRef: gatewayv1.ParentReference{
+					Name:      "eg",
+					Namespace: ptr.To(gatewayv1.Namespace("cosmo-system")),
+				},
+				AuthFilter: gatewayv1.LocalObjectReference{
+					Group: "example.com",
+					Kind:  "AuthFilter",
+					Name:  "cosmo-auth",
+				},
+			},
+			wantNames: []string{"ws1-0", "ws1-2"},
+		},
+		{
+			name: "✅ without auth filter",
+			cfg: &GatewayHTTPRouteConfig{
+				RoutingConfig: RoutingConfig{Domain: "example.com"},
+				ParentRef: gatewayv1.ParentReference{
+					Name: "eg",
+				},
+			},
+			wantNames: []string{"ws1-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes := tt.cfg.Routes(routingTestWorkspace(), routingTestScheme())
+			names := make([]string, 0, len(routes))
+			for _, r := range routes {
+				names = append(names, r.Object.GetName())
+				if err := r.Patch(); err != nil {
+					t.Errorf("Route.Patch() error = %v", err)
+				}
+				snaps.MatchJSON(t, r.Object)
+			}
+			if diff := cmp.Diff(tt.wantNames, names); diff != "" {
+				t.Errorf("GatewayHTTPRouteConfig.Routes() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIngressConfig_Routes(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       *IngressConfig
+		wantNames []string
+	}{
+		{
+			name: "✅ with auth annotations",
+			cfg: &IngressConfig{
+				RoutingConfig:    RoutingConfig{Domain: "example.com"},
+				IngressClassName: "nginx",
+				AuthAnnotations: map[string]string{
+					"nginx.ingress.kubernetes.io/auth-url": "https://auth.example.com/?users={{USERS}}",
+				},
+			},
+			wantNames: []string{"ws1-0", "ws1-2"},
+		},
+		{
+			name: "✅ without auth annotations",
+			cfg: &IngressConfig{
+				RoutingConfig: RoutingConfig{Domain: "example.com"},
+			},
+			wantNames: []string{"ws1-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes := tt.cfg.Routes(routingTestWorkspace(), routingTestScheme())
+			names := make([]string, 0, len(routes))
+			for _, r := range routes {
+				names = append(names, r.Object.GetName())
+				if err := r.Patch(); err != nil {
+					t.Errorf("Route.Patch() error = %v", err)
+				}
+				snaps.MatchJSON(t, r.Object)
+			}
+			if diff := cmp.Diff(tt.wantNames, names); diff != "" {
+				t.Errorf("IngressConfig.Routes() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+var weekdays = map[cosmov1alpha1.ScheduleDay]time.Weekday{
+	cosmov1alpha1.ScheduleDaySunday:    time.Sunday,
+	cosmov1alpha1.ScheduleDayMonday:    time.Monday,
+	cosmov1alpha1.ScheduleDayTuesday:   time.Tuesday,
+	cosmov1alpha1.ScheduleDayWednesday: time.Wednesday,
+	cosmov1alpha1.ScheduleDayThursday:  time.Thursday,
+	cosmov1alpha1.ScheduleDayFriday:    time.Friday,
+	cosmov1alpha1.ScheduleDaySaturday:  time.Saturday,
+}
+
+// ScheduleTransition is a point of time when the workspace is started or stopped by the schedule
+type ScheduleTransition struct {
+	Time  time.Time
+	Start bool
+}
+
+// ValidateSchedule validates the schedule
+func ValidateSchedule(s cosmov1alpha1.WorkspaceSchedule) error {
+	if s.StartTime == "" && s.StopTime == "" {
+		return errors.New("either startTime or stopTime is required")
+	}
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		return fmt.Errorf("invalid timeZone: %w", err)
+	}
+	for _, d := range s.Days {
+		if _, ok := weekdays[d]; !ok {
+			return fmt.Errorf("invalid day: %s", d)
+		}
+	}
+	if s.StartTime != "" {
+		if _, _, err := parseClock(s.StartTime); err != nil {
+			return fmt.Errorf("invalid startTime: %w", err)
+		}
+	}
+	if s.StopTime != "" {
+		if _, _, err := parseClock(s.StopTime); err != nil {
+			return fmt.Errorf("invalid stopTime: %w", err)
+		}
+	}
+	if s.StartTime != "" && s.StopTime != "" && atClock(time.Time{}, s.StartTime).Equal(atClock(time.Time{}, s.StopTime)) {
+		return errors.New("startTime and stopTime must be different")
+	}
+	return nil
+}
+
+// ScheduleTransitions returns the last transition at or before now and the next transition after now.
+// Either of them can be nil if there is no transition within a week.
+func ScheduleTransitions(s cosmov1alpha1.WorkspaceSchedule, now time.Time) (prev, next *ScheduleTransition, err error) {
+	if err := ValidateSchedule(s); err != nil {
+		return nil, nil, err
+	}
+	loc, _ := time.LoadLocation(s.TimeZone)
+	now = now.In(loc)
+
+	days := make([]time.Weekday, 0, len(s.Days))
+	for _, d := range s.Days {
+		days = append(days, weekdays[d])
+	}
+
+	transitions := make([]ScheduleTransition, 0)
+	for i := -8; i <= 8; i++ {
+		day := time.Date(now.Year(), now.Month(), now.Day()+i, 0, 0, 0, 0, loc)
+		if len(days) > 0 && !slices.Contains(days, day.Weekday()) {
+			continue
+		}
+		if s.StartTime != "" {
+			transitions = append(transitions, ScheduleTransition{Time: atClock(day, s.StartTime), Start: true})
+		}
+		if s.StopTime != "" {
+			stop := atClock(day, s.StopTime)
+			if s.StartTime != "" && stop.Before(atClock(day, s.StartTime)) {
+				// overnight window stops on the next day
+				stop = atClock(day.AddDate(0, 0, 1), s.StopTime)
+			}
+			transitions = append(transitions, ScheduleTransition{Time: stop, Start: false})
+		}
+	}
+	sort.SliceStable(transitions, func(i, j int) bool { return transitions[i].Time.Before(transitions[j].Time) })
+
+	for i, t := range transitions {
+		if t.Time.After(now) {
+			next = &transitions[i]
+			break
+		}
+		prev = &transitions[i]
+	}
+	return prev, next, nil
+}
+
+// ScheduleString returns the human readable string of the schedule such as "Mon,Tue 08:30-20:00 Asia/Tokyo"
+func ScheduleString(s *cosmov1alpha1.WorkspaceSchedule) string {
+	if s == nil {
+		return ""
+	}
+	days := "Everyday"
+	if len(s.Days) > 0 {
+		ds := make([]string, len(s.Days))
+		for i, d := range s.Days {
+			ds[i] = string(d)
+		}
+		days = strings.Join(ds, ",")
+	}
+	tz := s.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+	return fmt.Sprintf("%s %s-%s %s", days, s.StartTime, s.StopTime, tz)
+}
+
+func parseClock(v string) (hour, min int, err error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, 0, err
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+func atClock(day time.Time, clock string) time.Time {
+	h, m, _ := parseClock(clock)
+	return time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location())
+}
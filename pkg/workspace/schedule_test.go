@@ -0,0 +1,168 @@
+package workspace
+
+import (
+	"testing"
+	"time"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       cosmov1alpha1.WorkspaceSchedule
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    cosmov1alpha1.WorkspaceSchedule{TimeZone: "Asia/Tokyo", Days: []cosmov1alpha1.ScheduleDay{"Mon", "Fri"}, StartTime: "08:30", StopTime: "20:00"},
+		},
+		{
+			name: "OK stop only",
+			s:    cosmov1alpha1.WorkspaceSchedule{StopTime: "20:00"},
+		},
+		{
+			name:    "empty",
+			s:       cosmov1alpha1.WorkspaceSchedule{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid time zone",
+			s:       cosmov1alpha1.WorkspaceSchedule{TimeZone: "Mars/Olympus", StartTime: "08:30"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid day",
+			s:       cosmov1alpha1.WorkspaceSchedule{Days: []cosmov1alpha1.ScheduleDay{"Monday"}, StartTime: "08:30"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid time",
+			s:       cosmov1alpha1.WorkspaceSchedule{StartTime: "25:00"},
+			wantErr: true,
+		},
+		{
+			name:    "same start and stop",
+			s:       cosmov1alpha1.WorkspaceSchedule{StartTime: "08:30", StopTime: "08:30"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateSchedule(tt.s); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScheduleTransitions(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	weekdays := []cosmov1alpha1.ScheduleDay{"Mon", "Tue", "Wed", "Thu", "Fri"}
+
+	type want struct {
+		prev      time.Time
+		prevStart bool
+		next      time.Time
+		nextStart bool
+	}
+	tests := []struct {
+		name string
+		s    cosmov1alpha1.WorkspaceSchedule
+		now  time.Time
+		want want
+	}{
+		{
+			name: "in the window",
+			s:    cosmov1alpha1.WorkspaceSchedule{TimeZone: "Asia/Tokyo", Days: weekdays, StartTime: "08:30", StopTime: "20:00"},
+			// Wednesday
+			now: time.Date(2024, 5, 15, 12, 0, 0, 0, tokyo),
+			want: want{
+				prev: time.Date(2024, 5, 15, 8, 30, 0, 0, tokyo), prevStart: true,
+				next: time.Date(2024, 5, 15, 20, 0, 0, 0, tokyo), nextStart: false,
+			},
+		},
+		{
+			name: "friday night to monday morning",
+			s:    cosmov1alpha1.WorkspaceSchedule{TimeZone: "Asia/Tokyo", Days: weekdays, StartTime: "08:30", StopTime: "20:00"},
+			// Saturday
+			now: time.Date(2024, 5, 18, 12, 0, 0, 0, tokyo),
+			want: want{
+				prev: time.Date(2024, 5, 17, 20, 0, 0, 0, tokyo), prevStart: false,
+				next: time.Date(2024, 5, 20, 8, 30, 0, 0, tokyo), nextStart: true,
+			},
+		},
+		{
+			name: "just at the start time",
+			s:    cosmov1alpha1.WorkspaceSchedule{TimeZone: "Asia/Tokyo", StartTime: "08:30", StopTime: "20:00"},
+			now:  time.Date(2024, 5, 18, 8, 30, 0, 0, tokyo),
+			want: want{
+				prev: time.Date(2024, 5, 18, 8, 30, 0, 0, tokyo), prevStart: true,
+				next: time.Date(2024, 5, 18, 20, 0, 0, 0, tokyo), nextStart: false,
+			},
+		},
+		{
+			name: "overnight window",
+			s:    cosmov1alpha1.WorkspaceSchedule{Days: []cosmov1alpha1.ScheduleDay{"Fri"}, StartTime: "22:00", StopTime: "06:00"},
+			// Saturday in UTC
+			now: time.Date(2024, 5, 18, 3, 0, 0, 0, time.UTC),
+			want: want{
+				prev: time.Date(2024, 5, 17, 22, 0, 0, 0, time.UTC), prevStart: true,
+				next: time.Date(2024, 5, 18, 6, 0, 0, 0, time.UTC), nextStart: false,
+			},
+		},
+		{
+			name: "stop only",
+			s:    cosmov1alpha1.WorkspaceSchedule{StopTime: "20:00"},
+			now:  time.Date(2024, 5, 18, 3, 0, 0, 0, time.UTC),
+			want: want{
+				prev: time.Date(2024, 5, 17, 20, 0, 0, 0, time.UTC), prevStart: false,
+				next: time.Date(2024, 5, 18, 20, 0, 0, 0, time.UTC), nextStart: false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev, next, err := ScheduleTransitions(tt.s, tt.now)
+			if err != nil {
+				t.Fatalf("ScheduleTransitions() error = %v", err)
+			}
+			if prev == nil || !prev.Time.Equal(tt.want.prev) || prev.Start != tt.want.prevStart {
+				t.Errorf("ScheduleTransitions() prev = %v, want %v %v", prev, tt.want.prev, tt.want.prevStart)
+			}
+			if next == nil || !next.Time.Equal(tt.want.next) || next.Start != tt.want.nextStart {
+				t.Errorf("ScheduleTransitions() next = %v, want %v %v", next, tt.want.next, tt.want.nextStart)
+			}
+		})
+	}
+}
+
+func TestScheduleString(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *cosmov1alpha1.WorkspaceSchedule
+		want string
+	}{
+		{
+			name: "nil",
+			want: "",
+		},
+		{
+			name: "full",
+			s:    &cosmov1alpha1.WorkspaceSchedule{TimeZone: "Asia/Tokyo", Days: []cosmov1alpha1.ScheduleDay{"Mon", "Tue"}, StartTime: "08:30", StopTime: "20:00"},
+			want: "Mon,Tue 08:30-20:00 Asia/Tokyo",
+		},
+		{
+			name: "stop only",
+			s:    &cosmov1alpha1.WorkspaceSchedule{StopTime: "20:00"},
+			want: "Everyday -20:00 UTC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScheduleString(tt.s); got != tt.want {
+				t.Errorf("ScheduleString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package workspace
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+	"github.com/cosmo-workspace/cosmo/pkg/kubeutil"
+)
+
+var VolumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+// IsSuspended returns true if the workspace is stopped and has no pods
+func IsSuspended(ws *cosmov1alpha1.Workspace) bool {
+	return ptr.Deref(ws.Spec.Replicas, 1) == 0 && ws.Status.Phase == "Stopped"
+}
+
+// PersistentVolumeClaimNames returns the names of PVCs in the applied objects of the instance
+func PersistentVolumeClaimNames(lastApplied []cosmov1alpha1.ObjectRef) []string {
+	names := make([]string, 0)
+	for _, v := range lastApplied {
+		if v.APIVersion == "v1" && v.Kind == "PersistentVolumeClaim" {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
+// VolumeSnapshotName returns the name of VolumeSnapshot for the PVC
+func VolumeSnapshotName(snapshotName, pvcName string) string {
+	return fmt.Sprintf("%s-%s", snapshotName, pvcName)
+}
+
+// NewVolumeSnapshot returns snapshot.storage.k8s.io/v1 VolumeSnapshot of the PVC
+func NewVolumeSnapshot(snap *cosmov1alpha1.WorkspaceSnapshot, pvcName string) *unstructured.Unstructured {
+	vs := &unstructured.Unstructured{}
+	vs.SetGroupVersionKind(VolumeSnapshotGVK)
+	vs.SetName(VolumeSnapshotName(snap.Name, pvcName))
+	vs.SetNamespace(snap.Namespace)
+	vs.SetLabels(map[string]string{cosmov1alpha1.LabelKeyWorkspaceName: snap.Spec.Workspace})
+
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": pvcName,
+		},
+	}
+	if snap.Spec.VolumeSnapshotClassName != nil {
+		spec["volumeSnapshotClassName"] = *snap.Spec.VolumeSnapshotClassName
+	}
+	vs.Object["spec"] = spec
+	return vs
+}
+
+// VolumeSnapshotReadiness returns readyToUse and the error message in the VolumeSnapshot status
+func VolumeSnapshotReadiness(vs *unstructured.Unstructured) (ready bool, errMessage string) {
+	ready, _, _ = unstructured.NestedBool(vs.Object, "status", "readyToUse")
+	errMessage, _, _ = unstructured.NestedString(vs.Object, "status", "error", "message")
+	return ready, errMessage
+}
+
+// VolumeSnapshotSource returns the status entry of the snapshot which keeps the PVC labels and spec to restore
+func VolumeSnapshotSource(snapshotName string, pvc *corev1.PersistentVolumeClaim) cosmov1alpha1.VolumeSnapshot {
+	spec := pvc.Spec.DeepCopy()
+	spec.VolumeName = ""
+	spec.DataSource = nil
+	spec.DataSourceRef = nil
+
+	var ann map[string]string
+	for k, v := range pvc.GetAnnotations() {
+		if strings.HasPrefix(k, cosmov1alpha1.GroupVersion.Group+"/") {
+			if ann == nil {
+				ann = make(map[string]string)
+			}
+			ann[k] = v
+		}
+	}
+
+	return cosmov1alpha1.VolumeSnapshot{
+		PersistentVolumeClaimName: pvc.Name,
+		VolumeSnapshotName:        VolumeSnapshotName(snapshotName, pvc.Name),
+		Labels:                    copyMap(pvc.GetLabels()),
+		Annotations:               ann,
+		ClaimSpec:                 *spec,
+	}
+}
+
+// RestoredPersistentVolumeClaim returns the PVC whose data source is the VolumeSnapshot
+func RestoredPersistentVolumeClaim(namespace string, v cosmov1alpha1.VolumeSnapshot, restoredFrom string) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        v.PersistentVolumeClaimName,
+			Namespace:   namespace,
+			Labels:      copyMap(v.Labels),
+			Annotations: copyMap(v.Annotations),
+		},
+		Spec: *v.ClaimSpec.DeepCopy(),
+	}
+	kubeutil.SetAnnotation(pvc, cosmov1alpha1.WorkspaceSnapshotAnnKeyRestoredFrom, restoredFrom)
+	pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To(VolumeSnapshotGVK.Group),
+		Kind:     VolumeSnapshotGVK.Kind,
+		Name:     v.VolumeSnapshotName,
+	}
+	return pvc
+}
+
+// RestoreRequestedAt returns the requested time if the restore is requested and not done yet
+func RestoreRequestedAt(snap *cosmov1alpha1.WorkspaceSnapshot) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, kubeutil.GetAnnotation(snap, cosmov1alpha1.WorkspaceSnapshotAnnKeyRestoreRequestedAt))
+	if err != nil {
+		return time.Time{}, false
+	}
+	if snap.Status.LastRestoredAt != nil && !t.After(snap.Status.LastRestoredAt.Time) {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// RestoredFrom returns the value of the restored-from annotation to identify the restore request
+func RestoredFrom(snap *cosmov1alpha1.WorkspaceSnapshot, requestedAt time.Time) string {
+	return fmt.Sprintf("%s@%s", snap.Name, requestedAt.Format(time.RFC3339))
+}
@@ -0,0 +1,231 @@
+package workspace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	cosmov1alpha1 "github.com/cosmo-workspace/cosmo/api/v1alpha1"
+)
+
+func TestPersistentVolumeClaimNames(t *testing.T) {
+	ref := func(apiVersion, kind, name string) cosmov1alpha1.ObjectRef {
+		return cosmov1alpha1.ObjectRef{ObjectReference: corev1.ObjectReference{APIVersion: apiVersion, Kind: kind, Name: name}}
+	}
+	got := PersistentVolumeClaimNames([]cosmov1alpha1.ObjectRef{
+		ref("apps/v1", "Deployment", "ws"),
+		ref("v1", "PersistentVolumeClaim", "home"),
+		ref("v1", "Service", "ws"),
+		ref("v1", "PersistentVolumeClaim", "data"),
+	})
+	if diff := cmp.Diff([]string{"home", "data"}, got); diff != "" {
+		t.Errorf("PersistentVolumeClaimNames() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewVolumeSnapshot(t *testing.T) {
+	tests := []struct {
+		name string
+		snap *cosmov1alpha1.WorkspaceSnapshot
+		want map[string]interface{}
+	}{
+		{
+			name: "default class",
+			snap: &cosmov1alpha1.WorkspaceSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "snap1", Namespace: "cosmo-user-tom"},
+				Spec:       cosmov1alpha1.WorkspaceSnapshotSpec{Workspace: "ws1"},
+			},
+			want: map[string]interface{}{
+				"apiVersion": "snapshot.storage.k8s.io/v1",
+				"kind":       "VolumeSnapshot",
+				"metadata": map[string]interface{}{
+					"name":      "snap1-home",
+					"namespace": "cosmo-user-tom",
+					"labels":    map[string]interface{}{cosmov1alpha1.LabelKeyWorkspaceName: "ws1"},
+				},
+				"spec": map[string]interface{}{
+					"source": map[string]interface{}{"persistentVolumeClaimName": "home"},
+				},
+			},
+		},
+		{
+			name: "with class",
+			snap: &cosmov1alpha1.WorkspaceSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "snap1", Namespace: "cosmo-user-tom"},
+				Spec:       cosmov1alpha1.WorkspaceSnapshotSpec{Workspace: "ws1", VolumeSnapshotClassName: ptr.To("csi-snap")},
+			},
+			want: map[string]interface{}{
+				"apiVersion": "snapshot.storage.k8s.io/v1",
+				"kind":       "VolumeSnapshot",
+				"metadata": map[string]interface{}{
+					"name":      "snap1-home",
+					"namespace": "cosmo-user-tom",
+					"labels":    map[string]interface{}{cosmov1alpha1.LabelKeyWorkspaceName: "ws1"},
+				},
+				"spec": map[string]interface{}{
+					"source":                  map[string]interface{}{"persistentVolumeClaimName": "home"},
+					"volumeSnapshotClassName": "csi-snap",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewVolumeSnapshot(tt.snap, "home")
+			if diff := cmp.Diff(tt.want, got.Object); diff != "" {
+				t.Errorf("NewVolumeSnapshot() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVolumeSnapshotReadiness(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    map[string]interface{}
+		wantReady bool
+		wantErr   string
+	}{
+		{
+			name: "no status",
+		},
+		{
+			name:      "ready",
+			status:    map[string]interface{}{"readyToUse": true},
+			wantReady: true,
+		},
+		{
+			name:    "error",
+			status:  map[string]interface{}{"readyToUse": false, "error": map[string]interface{}{"message": "failed"}},
+			wantErr: "failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if tt.status != nil {
+				vs.Object["status"] = tt.status
+			}
+			ready, errMessage := VolumeSnapshotReadiness(vs)
+			if ready != tt.wantReady || errMessage != tt.wantErr {
+				t.Errorf("VolumeSnapshotReadiness() = %v, %q, want %v, %q", ready, errMessage, tt.wantReady, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVolumeSnapshotSourceAndRestore(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "home",
+			Namespace: "cosmo-user-tom",
+			Labels:    map[string]string{cosmov1alpha1.LabelKeyInstanceName: "ws1"},
+			Annotations: map[string]string{
+				cosmov1alpha1.ResourceAnnKeyDeletePolicy: cosmov1alpha1.ResourceAnnEnumDeletePolicyKeep,
+				"pv.kubernetes.io/bind-completed":        "yes",
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: ptr.To("standard"),
+			VolumeName:       "pvc-xxx",
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+	}
+
+	src := VolumeSnapshotSource("snap1", pvc)
+	wantSrc := cosmov1alpha1.VolumeSnapshot{
+		PersistentVolumeClaimName: "home",
+		VolumeSnapshotName:        "snap1-home",
+		Labels:                    map[string]string{cosmov1alpha1.LabelKeyInstanceName: "ws1"},
+		Annotations:               map[string]string{cosmov1alpha1.ResourceAnnKeyDeletePolicy: cosmov1alpha1.ResourceAnnEnumDeletePolicyKeep},
+		ClaimSpec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: ptr.To("standard"),
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+	}
+	if diff := cmp.Diff(wantSrc, src); diff != "" {
+		t.Errorf("VolumeSnapshotSource() mismatch (-want +got):\n%s", diff)
+	}
+
+	restored := RestoredPersistentVolumeClaim("cosmo-user-tom", src, "snap1@2024-01-01T00:00:00Z")
+	wantSpec := *wantSrc.ClaimSpec.DeepCopy()
+	wantSpec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To("snapshot.storage.k8s.io"),
+		Kind:     "VolumeSnapshot",
+		Name:     "snap1-home",
+	}
+	if diff := cmp.Diff(wantSpec, restored.Spec); diff != "" {
+		t.Errorf("RestoredPersistentVolumeClaim() spec mismatch (-want +got):\n%s", diff)
+	}
+	if v := restored.Annotations[cosmov1alpha1.WorkspaceSnapshotAnnKeyRestoredFrom]; v != "snap1@2024-01-01T00:00:00Z" {
+		t.Errorf("RestoredPersistentVolumeClaim() restored-from = %s", v)
+	}
+	if _, ok := src.Annotations[cosmov1alpha1.WorkspaceSnapshotAnnKeyRestoredFrom]; ok {
+		t.Errorf("RestoredPersistentVolumeClaim() modified the source annotations")
+	}
+}
+
+func TestRestoreRequestedAt(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	snapWith := func(requestedAt string, lastRestoredAt *time.Time) *cosmov1alpha1.WorkspaceSnapshot {
+		snap := &cosmov1alpha1.WorkspaceSnapshot{}
+		if requestedAt != "" {
+			snap.SetAnnotations(map[string]string{cosmov1alpha1.WorkspaceSnapshotAnnKeyRestoreRequestedAt: requestedAt})
+		}
+		if lastRestoredAt != nil {
+			snap.Status.LastRestoredAt = &metav1.Time{Time: *lastRestoredAt}
+		}
+		return snap
+	}
+	tests := []struct {
+		name     string
+		snap     *cosmov1alpha1.WorkspaceSnapshot
+		wantTime time.Time
+		wantOK   bool
+	}{
+		{
+			name: "not requested",
+			snap: snapWith("", nil),
+		},
+		{
+			name: "invalid annotation",
+			snap: snapWith("xxx", nil),
+		},
+		{
+			name:     "requested",
+			snap:     snapWith(now.Format(time.RFC3339), nil),
+			wantTime: now,
+			wantOK:   true,
+		},
+		{
+			name: "already restored",
+			snap: snapWith(now.Format(time.RFC3339), &now),
+		},
+		{
+			name:     "requested again",
+			snap:     snapWith(now.Add(time.Hour).Format(time.RFC3339), &now),
+			wantTime: now.Add(time.Hour),
+			wantOK:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := RestoreRequestedAt(tt.snap)
+			if ok != tt.wantOK || !got.Equal(tt.wantTime) {
+				t.Errorf("RestoreRequestedAt() = %v, %v, want %v, %v", got, ok, tt.wantTime, tt.wantOK)
+			}
+		})
+	}
+}
@@ -12,9 +12,14 @@ import (
 	"github.com/cosmo-workspace/cosmo/pkg/instance"
 )
 
+// TraefikIngressRouteConfig is a RoutingProvider by traefik IngressRoute and IngressRouteTCP
 type TraefikIngressRouteConfig struct {
+	RoutingConfig
+
 	// Entrypoints is the entrypoint of traefik ingress route
 	Entrypoints []string
+	// TCPEntrypoints is the entrypoint of traefik ingress route tcp for tcp network rules
+	TCPEntrypoints []string
 	// TLS is the TLS of traefik ingress route
 	TLS *traefikv1.TLS
 	// AuthenMiddleware is the name and namespace of middleware for cosmo-auth
@@ -23,11 +28,34 @@ type TraefikIngressRouteConfig struct {
 	// UserNameHeaderMiddlewareName is the name of middleware for username header
 	// Namespace must be empty to be the same as the workspace
 	UserNameHeaderMiddleware traefikv1.MiddlewareRef
+}
 
-	// HostBase is a base of hostname
-	HostBase string
-	// Domain is a domain of hostname
-	Domain string
+func (c *TraefikIngressRouteConfig) Supports(r cosmov1alpha1.NetworkRule) bool {
+	return r.Protocol == cosmov1alpha1.NetworkRuleProtocolHTTP || r.Protocol == cosmov1alpha1.NetworkRuleProtocolTCP
+}
+
+func (c *TraefikIngressRouteConfig) Routes(ws cosmov1alpha1.Workspace, scheme *runtime.Scheme) []Route {
+	ir := &traefikv1.IngressRoute{}
+	ir.SetName(ws.Name)
+	ir.SetNamespace(ws.Namespace)
+
+	irTCP := &traefikv1.IngressRouteTCP{}
+	irTCP.SetName(ws.Name)
+	irTCP.SetNamespace(ws.Namespace)
+
+	routes := []Route{
+		{
+			Object: ir,
+			Patch:  func() error { return c.PatchTraefikIngressRouteAsDesired(ir, ws, scheme) },
+		},
+		{
+			Object: irTCP,
+		},
+	}
+	if HasTCPNetworkRule(ws) {
+		routes[1].Patch = func() error { return c.PatchTraefikIngressRouteTCPAsDesired(irTCP, ws, scheme) }
+	}
+	return routes
 }
 
 func (c *TraefikIngressRouteConfig) PatchTraefikIngressRouteAsDesired(ir *traefikv1.IngressRoute, ws cosmov1alpha1.Workspace, scheme *runtime.Scheme) error {
@@ -43,6 +71,9 @@ func (c *TraefikIngressRouteConfig) PatchTraefikIngressRouteAsDesired(ir *traefi
 	// spec.routes
 	routes := make([]traefikv1.Route, 0, len(ws.Spec.Network))
 	for _, netRule := range ws.Spec.Network {
+		if netRule.Protocol == cosmov1alpha1.NetworkRuleProtocolTCP {
+			continue
+		}
 		traefikRule := c.TraefikRoute(netRule, ws)
 		routes = append(routes, traefikRule)
 	}
@@ -57,7 +88,7 @@ func (c *TraefikIngressRouteConfig) PatchTraefikIngressRouteAsDesired(ir *traefi
 func (c *TraefikIngressRouteConfig) TraefikRoute(r cosmov1alpha1.NetworkRule, ws cosmov1alpha1.Workspace) traefikv1.Route {
 	matches := []string{}
 
-	matches = append(matches, fmt.Sprintf("Host(`%s`)", cosmov1alpha1.GenHost(c.HostBase, c.Domain, r.HostPrefix(), ws)))
+	matches = append(matches, fmt.Sprintf("Host(`%s`)", c.Host(r, ws)))
 
 	if r.HTTPPath != "" && r.HTTPPath != "/" {
 		matches = append(matches, fmt.Sprintf("PathPrefix(`%s`)", r.HTTPPath))
@@ -97,3 +128,55 @@ func (c *TraefikIngressRouteConfig) TraefikRoute(r cosmov1alpha1.NetworkRule, ws
 		Middlewares: middlewares,
 	}
 }
+
+// HasTCPNetworkRule returns true if the workspace has network rules to be routed by IngressRouteTCP
+func HasTCPNetworkRule(ws cosmov1alpha1.Workspace) bool {
+	for _, netRule := range ws.Spec.Network {
+		if netRule.Protocol == cosmov1alpha1.NetworkRuleProtocolTCP {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *TraefikIngressRouteConfig) PatchTraefikIngressRouteTCPAsDesired(ir *traefikv1.IngressRouteTCP, ws cosmov1alpha1.Workspace, scheme *runtime.Scheme) error {
+	// metadata
+	cosmov1alpha1.SetControllerManaged(ir)
+
+	// spec.entrypoints
+	ir.Spec.EntryPoints = c.TCPEntrypoints
+
+	// spec.tls
+	// TLS is terminated in the workspace as the hostname in SNI is the only thing to route by
+	ir.Spec.TLS = &traefikv1.TLSTCP{Passthrough: true}
+
+	// spec.routes
+	routes := make([]traefikv1.RouteTCP, 0, len(ws.Spec.Network))
+	for _, netRule := range ws.Spec.Network {
+		if netRule.Protocol != cosmov1alpha1.NetworkRuleProtocolTCP {
+			continue
+		}
+		routes = append(routes, c.TraefikRouteTCP(netRule, ws))
+	}
+	ir.Spec.Routes = routes
+
+	if err := cosmov1alpha1.SetOwnerReferenceIfNotKeepPolicy(&ws, ir, scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	return nil
+}
+
+func (c *TraefikIngressRouteConfig) TraefikRouteTCP(r cosmov1alpha1.NetworkRule, ws cosmov1alpha1.Workspace) traefikv1.RouteTCP {
+	backendSvcName := instance.InstanceResourceName(ws.Name, ws.Status.Config.ServiceName)
+
+	return traefikv1.RouteTCP{
+		Match:    fmt.Sprintf("HostSNI(`%s`)", c.Host(r, ws)),
+		Priority: 100,
+		Services: []traefikv1.ServiceTCP{
+			{
+				Name: backendSvcName,
+				Port: intstr.FromInt(int(r.PortNumber)),
+			},
+		},
+	}
+}
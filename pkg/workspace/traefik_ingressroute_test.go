@@ -223,3 +223,52 @@ func TestTraefikIngressRouteConfig_TraefikRoute(t *testing.T) {
 		})
 	}
 }
+
+func TestTraefikIngressRouteConfig_TraefikRouteTCP(t *testing.T) {
+	type args struct {
+		r  cosmov1alpha1.NetworkRule
+		ws cosmov1alpha1.Workspace
+	}
+	tests := []struct {
+		name     string
+		hostBase string
+		domain   string
+		args     args
+	}{
+		{
+			name:     "tcp",
+			hostBase: "{{NETRULE}}-{{WORKSPACE}}-{{USER}}",
+			domain:   "example.com",
+			args: args{
+				r: cosmov1alpha1.NetworkRule{
+					Protocol:   cosmov1alpha1.NetworkRuleProtocolTCP,
+					PortNumber: 5432,
+					Public:     true,
+				},
+				ws: cosmov1alpha1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "ws1",
+						Namespace: "cosmo-user-xxx",
+					},
+					Status: cosmov1alpha1.WorkspaceStatus{
+						Config: cosmov1alpha1.Config{
+							ServiceName: "backend-svc-name",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &TraefikIngressRouteConfig{
+				RoutingConfig: RoutingConfig{
+					HostBase: tt.hostBase,
+					Domain:   tt.domain,
+				},
+			}
+			got := c.TraefikRouteTCP(tt.args.r, tt.args.ws)
+			snaps.MatchJSON(t, got)
+		})
+	}
+}
@@ -31,8 +31,9 @@ func PatchWorkspaceInstanceAsDesired(inst *cosmov1alpha1.Instance, ws *cosmov1al
 	}
 
 	inst.Spec = cosmov1alpha1.InstanceSpec{
-		Template: ws.Spec.Template,
-		Vars:     varsWithWorkspaceDefault(ws),
+		Template:      ws.Spec.Template,
+		Vars:          varsWithWorkspaceDefault(ws),
+		SecretVarsRef: ws.Spec.SecretVarsRef.DeepCopy(),
 		Override: cosmov1alpha1.OverrideSpec{
 			PatchesJson6902: []cosmov1alpha1.Json6902{
 				{
@@ -46,7 +47,7 @@ func PatchWorkspaceInstanceAsDesired(inst *cosmov1alpha1.Instance, ws *cosmov1al
 	if ws.Spec.Replicas != nil {
 		scaleTargetRef := cosmov1alpha1.ObjectRef{}
 		scaleTargetRef.SetName(ws.Status.Config.DeploymentName)
-		scaleTargetRef.SetGroupVersionKind(kubeutil.DeploymentGVK)
+		scaleTargetRef.SetGroupVersionKind(ws.Status.Config.MainWorkload().GroupVersionKind())
 
 		scalePatch, err := JSONPatch("replace", "/spec/replicas", ws.Spec.Replicas)
 		if err != nil {
@@ -57,6 +58,26 @@ func PatchWorkspaceInstanceAsDesired(inst *cosmov1alpha1.Instance, ws *cosmov1al
 			Target: scaleTargetRef,
 			Patch:  scalePatch,
 		})
+
+		// additional workloads are scaled to zero while the workspace is stopped,
+		// and run with the replicas in the template otherwise
+		if *ws.Spec.Replicas == 0 {
+			// "add" replaces the value and does not fail even if the template omits replicas
+			zeroPatch, err := JSONPatch("add", "/spec/replicas", 0)
+			if err != nil {
+				return err
+			}
+			for _, w := range ws.Status.Config.AdditionalWorkloads {
+				targetRef := cosmov1alpha1.ObjectRef{}
+				targetRef.SetName(w.Name)
+				targetRef.SetGroupVersionKind(w.GroupVersionKind())
+
+				inst.Spec.Override.PatchesJson6902 = append(inst.Spec.Override.PatchesJson6902, cosmov1alpha1.Json6902{
+					Target: targetRef,
+					Patch:  zeroPatch,
+				})
+			}
+		}
 	}
 
 	if policy := kubeutil.GetAnnotation(ws, cosmov1alpha1.ResourceAnnKeyDeletePolicy); policy != "" {
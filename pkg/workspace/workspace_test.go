@@ -4,8 +4,10 @@ import (
 	"testing"
 
 	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/utils/ptr"
 
@@ -147,6 +149,67 @@ func TestPatchWorkspaceInstanceAsDesired(t *testing.T) {
 	}
 }
 
+func TestPatchWorkspaceInstanceAsDesired_workloads(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cosmov1alpha1.AddToScheme(scheme))
+
+	target := func(kind, name string) cosmov1alpha1.ObjectRef {
+		ref := cosmov1alpha1.ObjectRef{}
+		ref.SetName(name)
+		ref.SetGroupVersionKind(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: kind})
+		return ref
+	}
+	cfg := cosmov1alpha1.Config{
+		DeploymentName:      "ws-sts",
+		WorkloadKind:        cosmov1alpha1.WorkloadKindStatefulSet,
+		ServiceName:         "ws-svc",
+		ServiceMainPortName: "main",
+		AdditionalWorkloads: []cosmov1alpha1.WorkloadRef{{Kind: "Deployment", Name: "db"}},
+	}
+
+	tests := []struct {
+		name     string
+		replicas int64
+		want     []cosmov1alpha1.Json6902
+	}{
+		{
+			name:     "✅ Running",
+			replicas: 1,
+			want: []cosmov1alpha1.Json6902{
+				{Target: target("StatefulSet", "ws-sts"), Patch: `[{"op": "replace","path": "/spec/replicas","value": 1}]`},
+			},
+		},
+		{
+			name:     "✅ Stopped",
+			replicas: 0,
+			want: []cosmov1alpha1.Json6902{
+				{Target: target("StatefulSet", "ws-sts"), Patch: `[{"op": "replace","path": "/spec/replicas","value": 0}]`},
+				{Target: target("Deployment", "db"), Patch: `[{"op": "add","path": "/spec/replicas","value": 0}]`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &cosmov1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: "cosmo-user-default"},
+				Spec: cosmov1alpha1.WorkspaceSpec{
+					Template: cosmov1alpha1.TemplateRef{Name: "tmpl1"},
+					Replicas: ptr.To(tt.replicas),
+				},
+				Status: cosmov1alpha1.WorkspaceStatus{Config: cfg},
+			}
+			inst := &cosmov1alpha1.Instance{ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: "cosmo-user-default"}}
+			if err := PatchWorkspaceInstanceAsDesired(inst, ws, scheme); err != nil {
+				t.Fatal(err)
+			}
+			// the first patch is for the service ports
+			if diff := cmp.Diff(tt.want, inst.Spec.Override.PatchesJson6902[1:]); diff != "" {
+				t.Errorf("PatchWorkspaceInstanceAsDesired() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSvcPorts(t *testing.T) {
 	netRule := func(ruleName, host, path string, portNumber, targetPortNumber int32) cosmov1alpha1.NetworkRule {
 		var targetp *int32
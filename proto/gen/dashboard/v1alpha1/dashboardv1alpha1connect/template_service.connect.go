@@ -43,6 +43,9 @@ const (
 	// TemplateServiceGetWorkspaceTemplatesProcedure is the fully-qualified name of the
 	// TemplateService's GetWorkspaceTemplates RPC.
 	TemplateServiceGetWorkspaceTemplatesProcedure = "/dashboard.v1alpha1.TemplateService/GetWorkspaceTemplates"
+	// TemplateServiceDiffWorkspaceTemplateProcedure is the fully-qualified name of the
+	// TemplateService's DiffWorkspaceTemplate RPC.
+	TemplateServiceDiffWorkspaceTemplateProcedure = "/dashboard.v1alpha1.TemplateService/DiffWorkspaceTemplate"
 )
 
 // TemplateServiceClient is a client for the dashboard.v1alpha1.TemplateService service.
@@ -51,6 +54,8 @@ type TemplateServiceClient interface {
 	GetUserAddonTemplates(context.Context, *connect_go.Request[v1alpha1.GetUserAddonTemplatesRequest]) (*connect_go.Response[v1alpha1.GetUserAddonTemplatesResponse], error)
 	// List templates typed workspace
 	GetWorkspaceTemplates(context.Context, *connect_go.Request[v1alpha1.GetWorkspaceTemplatesRequest]) (*connect_go.Response[v1alpha1.GetWorkspaceTemplatesResponse], error)
+	// Dry-run diff of workspace template changes against live workspaces
+	DiffWorkspaceTemplate(context.Context, *connect_go.Request[v1alpha1.DiffWorkspaceTemplateRequest]) (*connect_go.Response[v1alpha1.DiffWorkspaceTemplateResponse], error)
 }
 
 // NewTemplateServiceClient constructs a client for the dashboard.v1alpha1.TemplateService service.
@@ -73,6 +78,11 @@ func NewTemplateServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 			baseURL+TemplateServiceGetWorkspaceTemplatesProcedure,
 			opts...,
 		),
+		diffWorkspaceTemplate: connect_go.NewClient[v1alpha1.DiffWorkspaceTemplateRequest, v1alpha1.DiffWorkspaceTemplateResponse](
+			httpClient,
+			baseURL+TemplateServiceDiffWorkspaceTemplateProcedure,
+			opts...,
+		),
 	}
 }
 
@@ -80,6 +90,7 @@ func NewTemplateServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 type templateServiceClient struct {
 	getUserAddonTemplates *connect_go.Client[v1alpha1.GetUserAddonTemplatesRequest, v1alpha1.GetUserAddonTemplatesResponse]
 	getWorkspaceTemplates *connect_go.Client[v1alpha1.GetWorkspaceTemplatesRequest, v1alpha1.GetWorkspaceTemplatesResponse]
+	diffWorkspaceTemplate *connect_go.Client[v1alpha1.DiffWorkspaceTemplateRequest, v1alpha1.DiffWorkspaceTemplateResponse]
 }
 
 // GetUserAddonTemplates calls dashboard.v1alpha1.TemplateService.GetUserAddonTemplates.
@@ -92,12 +103,19 @@ func (c *templateServiceClient) GetWorkspaceTemplates(ctx context.Context, req *
 	return c.getWorkspaceTemplates.CallUnary(ctx, req)
 }
 
+// DiffWorkspaceTemplate calls dashboard.v1alpha1.TemplateService.DiffWorkspaceTemplate.
+func (c *templateServiceClient) DiffWorkspaceTemplate(ctx context.Context, req *connect_go.Request[v1alpha1.DiffWorkspaceTemplateRequest]) (*connect_go.Response[v1alpha1.DiffWorkspaceTemplateResponse], error) {
+	return c.diffWorkspaceTemplate.CallUnary(ctx, req)
+}
+
 // TemplateServiceHandler is an implementation of the dashboard.v1alpha1.TemplateService service.
 type TemplateServiceHandler interface {
 	// List templates typed useraddon
 	GetUserAddonTemplates(context.Context, *connect_go.Request[v1alpha1.GetUserAddonTemplatesRequest]) (*connect_go.Response[v1alpha1.GetUserAddonTemplatesResponse], error)
 	// List templates typed workspace
 	GetWorkspaceTemplates(context.Context, *connect_go.Request[v1alpha1.GetWorkspaceTemplatesRequest]) (*connect_go.Response[v1alpha1.GetWorkspaceTemplatesResponse], error)
+	// Dry-run diff of workspace template changes against live workspaces
+	DiffWorkspaceTemplate(context.Context, *connect_go.Request[v1alpha1.DiffWorkspaceTemplateRequest]) (*connect_go.Response[v1alpha1.DiffWorkspaceTemplateResponse], error)
 }
 
 // NewTemplateServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -117,6 +135,11 @@ func NewTemplateServiceHandler(svc TemplateServiceHandler, opts ...connect_go.Ha
 		svc.GetWorkspaceTemplates,
 		opts...,
 	))
+	mux.Handle(TemplateServiceDiffWorkspaceTemplateProcedure, connect_go.NewUnaryHandler(
+		TemplateServiceDiffWorkspaceTemplateProcedure,
+		svc.DiffWorkspaceTemplate,
+		opts...,
+	))
 	return "/dashboard.v1alpha1.TemplateService/", mux
 }
 
@@ -130,3 +153,7 @@ func (UnimplementedTemplateServiceHandler) GetUserAddonTemplates(context.Context
 func (UnimplementedTemplateServiceHandler) GetWorkspaceTemplates(context.Context, *connect_go.Request[v1alpha1.GetWorkspaceTemplatesRequest]) (*connect_go.Response[v1alpha1.GetWorkspaceTemplatesResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("dashboard.v1alpha1.TemplateService.GetWorkspaceTemplates is not implemented"))
 }
+
+func (UnimplementedTemplateServiceHandler) DiffWorkspaceTemplate(context.Context, *connect_go.Request[v1alpha1.DiffWorkspaceTemplateRequest]) (*connect_go.Response[v1alpha1.DiffWorkspaceTemplateResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("dashboard.v1alpha1.TemplateService.DiffWorkspaceTemplate is not implemented"))
+}
@@ -58,6 +58,18 @@ const (
 	// WorkspaceServiceDeleteNetworkRuleProcedure is the fully-qualified name of the WorkspaceService's
 	// DeleteNetworkRule RPC.
 	WorkspaceServiceDeleteNetworkRuleProcedure = "/dashboard.v1alpha1.WorkspaceService/DeleteNetworkRule"
+	// WorkspaceServiceCloneWorkspaceProcedure is the fully-qualified name of the WorkspaceService's
+	// CloneWorkspace RPC.
+	WorkspaceServiceCloneWorkspaceProcedure = "/dashboard.v1alpha1.WorkspaceService/CloneWorkspace"
+	// WorkspaceServiceCreateWorkspaceSnapshotProcedure is the fully-qualified name of the
+	// WorkspaceService's CreateWorkspaceSnapshot RPC.
+	WorkspaceServiceCreateWorkspaceSnapshotProcedure = "/dashboard.v1alpha1.WorkspaceService/CreateWorkspaceSnapshot"
+	// WorkspaceServiceGetWorkspaceSnapshotsProcedure is the fully-qualified name of the
+	// WorkspaceService's GetWorkspaceSnapshots RPC.
+	WorkspaceServiceGetWorkspaceSnapshotsProcedure = "/dashboard.v1alpha1.WorkspaceService/GetWorkspaceSnapshots"
+	// WorkspaceServiceRestoreWorkspaceSnapshotProcedure is the fully-qualified name of the
+	// WorkspaceService's RestoreWorkspaceSnapshot RPC.
+	WorkspaceServiceRestoreWorkspaceSnapshotProcedure = "/dashboard.v1alpha1.WorkspaceService/RestoreWorkspaceSnapshot"
 )
 
 // WorkspaceServiceClient is a client for the dashboard.v1alpha1.WorkspaceService service.
@@ -76,6 +88,14 @@ type WorkspaceServiceClient interface {
 	UpsertNetworkRule(context.Context, *connect_go.Request[v1alpha1.UpsertNetworkRuleRequest]) (*connect_go.Response[v1alpha1.UpsertNetworkRuleResponse], error)
 	// Remove workspace network rule
 	DeleteNetworkRule(context.Context, *connect_go.Request[v1alpha1.DeleteNetworkRuleRequest]) (*connect_go.Response[v1alpha1.DeleteNetworkRuleResponse], error)
+	// Clone workspace into a new workspace
+	CloneWorkspace(context.Context, *connect_go.Request[v1alpha1.CloneWorkspaceRequest]) (*connect_go.Response[v1alpha1.CloneWorkspaceResponse], error)
+	// Create a snapshot of workspace volumes
+	CreateWorkspaceSnapshot(context.Context, *connect_go.Request[v1alpha1.CreateWorkspaceSnapshotRequest]) (*connect_go.Response[v1alpha1.CreateWorkspaceSnapshotResponse], error)
+	// Returns an array of WorkspaceSnapshot model
+	GetWorkspaceSnapshots(context.Context, *connect_go.Request[v1alpha1.GetWorkspaceSnapshotsRequest]) (*connect_go.Response[v1alpha1.GetWorkspaceSnapshotsResponse], error)
+	// Restore workspace volumes from the snapshot. workspace must be suspended
+	RestoreWorkspaceSnapshot(context.Context, *connect_go.Request[v1alpha1.RestoreWorkspaceSnapshotRequest]) (*connect_go.Response[v1alpha1.RestoreWorkspaceSnapshotResponse], error)
 }
 
 // NewWorkspaceServiceClient constructs a client for the dashboard.v1alpha1.WorkspaceService
@@ -123,18 +143,42 @@ func NewWorkspaceServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 			baseURL+WorkspaceServiceDeleteNetworkRuleProcedure,
 			opts...,
 		),
+		cloneWorkspace: connect_go.NewClient[v1alpha1.CloneWorkspaceRequest, v1alpha1.CloneWorkspaceResponse](
+			httpClient,
+			baseURL+WorkspaceServiceCloneWorkspaceProcedure,
+			opts...,
+		),
+		createWorkspaceSnapshot: connect_go.NewClient[v1alpha1.CreateWorkspaceSnapshotRequest, v1alpha1.CreateWorkspaceSnapshotResponse](
+			httpClient,
+			baseURL+WorkspaceServiceCreateWorkspaceSnapshotProcedure,
+			opts...,
+		),
+		getWorkspaceSnapshots: connect_go.NewClient[v1alpha1.GetWorkspaceSnapshotsRequest, v1alpha1.GetWorkspaceSnapshotsResponse](
+			httpClient,
+			baseURL+WorkspaceServiceGetWorkspaceSnapshotsProcedure,
+			opts...,
+		),
+		restoreWorkspaceSnapshot: connect_go.NewClient[v1alpha1.RestoreWorkspaceSnapshotRequest, v1alpha1.RestoreWorkspaceSnapshotResponse](
+			httpClient,
+			baseURL+WorkspaceServiceRestoreWorkspaceSnapshotProcedure,
+			opts...,
+		),
 	}
 }
 
 // workspaceServiceClient implements WorkspaceServiceClient.
 type workspaceServiceClient struct {
-	createWorkspace   *connect_go.Client[v1alpha1.CreateWorkspaceRequest, v1alpha1.CreateWorkspaceResponse]
-	deleteWorkspace   *connect_go.Client[v1alpha1.DeleteWorkspaceRequest, v1alpha1.DeleteWorkspaceResponse]
-	updateWorkspace   *connect_go.Client[v1alpha1.UpdateWorkspaceRequest, v1alpha1.UpdateWorkspaceResponse]
-	getWorkspace      *connect_go.Client[v1alpha1.GetWorkspaceRequest, v1alpha1.GetWorkspaceResponse]
-	getWorkspaces     *connect_go.Client[v1alpha1.GetWorkspacesRequest, v1alpha1.GetWorkspacesResponse]
-	upsertNetworkRule *connect_go.Client[v1alpha1.UpsertNetworkRuleRequest, v1alpha1.UpsertNetworkRuleResponse]
-	deleteNetworkRule *connect_go.Client[v1alpha1.DeleteNetworkRuleRequest, v1alpha1.DeleteNetworkRuleResponse]
+	createWorkspace          *connect_go.Client[v1alpha1.CreateWorkspaceRequest, v1alpha1.CreateWorkspaceResponse]
+	deleteWorkspace          *connect_go.Client[v1alpha1.DeleteWorkspaceRequest, v1alpha1.DeleteWorkspaceResponse]
+	updateWorkspace          *connect_go.Client[v1alpha1.UpdateWorkspaceRequest, v1alpha1.UpdateWorkspaceResponse]
+	getWorkspace             *connect_go.Client[v1alpha1.GetWorkspaceRequest, v1alpha1.GetWorkspaceResponse]
+	getWorkspaces            *connect_go.Client[v1alpha1.GetWorkspacesRequest, v1alpha1.GetWorkspacesResponse]
+	upsertNetworkRule        *connect_go.Client[v1alpha1.UpsertNetworkRuleRequest, v1alpha1.UpsertNetworkRuleResponse]
+	deleteNetworkRule        *connect_go.Client[v1alpha1.DeleteNetworkRuleRequest, v1alpha1.DeleteNetworkRuleResponse]
+	cloneWorkspace           *connect_go.Client[v1alpha1.CloneWorkspaceRequest, v1alpha1.CloneWorkspaceResponse]
+	createWorkspaceSnapshot  *connect_go.Client[v1alpha1.CreateWorkspaceSnapshotRequest, v1alpha1.CreateWorkspaceSnapshotResponse]
+	getWorkspaceSnapshots    *connect_go.Client[v1alpha1.GetWorkspaceSnapshotsRequest, v1alpha1.GetWorkspaceSnapshotsResponse]
+	restoreWorkspaceSnapshot *connect_go.Client[v1alpha1.RestoreWorkspaceSnapshotRequest, v1alpha1.RestoreWorkspaceSnapshotResponse]
 }
 
 // CreateWorkspace calls dashboard.v1alpha1.WorkspaceService.CreateWorkspace.
@@ -172,6 +216,26 @@ func (c *workspaceServiceClient) DeleteNetworkRule(ctx context.Context, req *con
 	return c.deleteNetworkRule.CallUnary(ctx, req)
 }
 
+// CloneWorkspace calls dashboard.v1alpha1.WorkspaceService.CloneWorkspace.
+func (c *workspaceServiceClient) CloneWorkspace(ctx context.Context, req *connect_go.Request[v1alpha1.CloneWorkspaceRequest]) (*connect_go.Response[v1alpha1.CloneWorkspaceResponse], error) {
+	return c.cloneWorkspace.CallUnary(ctx, req)
+}
+
+// CreateWorkspaceSnapshot calls dashboard.v1alpha1.WorkspaceService.CreateWorkspaceSnapshot.
+func (c *workspaceServiceClient) CreateWorkspaceSnapshot(ctx context.Context, req *connect_go.Request[v1alpha1.CreateWorkspaceSnapshotRequest]) (*connect_go.Response[v1alpha1.CreateWorkspaceSnapshotResponse], error) {
+	return c.createWorkspaceSnapshot.CallUnary(ctx, req)
+}
+
+// GetWorkspaceSnapshots calls dashboard.v1alpha1.WorkspaceService.GetWorkspaceSnapshots.
+func (c *workspaceServiceClient) GetWorkspaceSnapshots(ctx context.Context, req *connect_go.Request[v1alpha1.GetWorkspaceSnapshotsRequest]) (*connect_go.Response[v1alpha1.GetWorkspaceSnapshotsResponse], error) {
+	return c.getWorkspaceSnapshots.CallUnary(ctx, req)
+}
+
+// RestoreWorkspaceSnapshot calls dashboard.v1alpha1.WorkspaceService.RestoreWorkspaceSnapshot.
+func (c *workspaceServiceClient) RestoreWorkspaceSnapshot(ctx context.Context, req *connect_go.Request[v1alpha1.RestoreWorkspaceSnapshotRequest]) (*connect_go.Response[v1alpha1.RestoreWorkspaceSnapshotResponse], error) {
+	return c.restoreWorkspaceSnapshot.CallUnary(ctx, req)
+}
+
 // WorkspaceServiceHandler is an implementation of the dashboard.v1alpha1.WorkspaceService service.
 type WorkspaceServiceHandler interface {
 	// Create a new Workspace
@@ -188,6 +252,14 @@ type WorkspaceServiceHandler interface {
 	UpsertNetworkRule(context.Context, *connect_go.Request[v1alpha1.UpsertNetworkRuleRequest]) (*connect_go.Response[v1alpha1.UpsertNetworkRuleResponse], error)
 	// Remove workspace network rule
 	DeleteNetworkRule(context.Context, *connect_go.Request[v1alpha1.DeleteNetworkRuleRequest]) (*connect_go.Response[v1alpha1.DeleteNetworkRuleResponse], error)
+	// Clone workspace into a new workspace
+	CloneWorkspace(context.Context, *connect_go.Request[v1alpha1.CloneWorkspaceRequest]) (*connect_go.Response[v1alpha1.CloneWorkspaceResponse], error)
+	// Create a snapshot of workspace volumes
+	CreateWorkspaceSnapshot(context.Context, *connect_go.Request[v1alpha1.CreateWorkspaceSnapshotRequest]) (*connect_go.Response[v1alpha1.CreateWorkspaceSnapshotResponse], error)
+	// Returns an array of WorkspaceSnapshot model
+	GetWorkspaceSnapshots(context.Context, *connect_go.Request[v1alpha1.GetWorkspaceSnapshotsRequest]) (*connect_go.Response[v1alpha1.GetWorkspaceSnapshotsResponse], error)
+	// Restore workspace volumes from the snapshot. workspace must be suspended
+	RestoreWorkspaceSnapshot(context.Context, *connect_go.Request[v1alpha1.RestoreWorkspaceSnapshotRequest]) (*connect_go.Response[v1alpha1.RestoreWorkspaceSnapshotResponse], error)
 }
 
 // NewWorkspaceServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -232,6 +304,26 @@ func NewWorkspaceServiceHandler(svc WorkspaceServiceHandler, opts ...connect_go.
 		svc.DeleteNetworkRule,
 		opts...,
 	))
+	mux.Handle(WorkspaceServiceCloneWorkspaceProcedure, connect_go.NewUnaryHandler(
+		WorkspaceServiceCloneWorkspaceProcedure,
+		svc.CloneWorkspace,
+		opts...,
+	))
+	mux.Handle(WorkspaceServiceCreateWorkspaceSnapshotProcedure, connect_go.NewUnaryHandler(
+		WorkspaceServiceCreateWorkspaceSnapshotProcedure,
+		svc.CreateWorkspaceSnapshot,
+		opts...,
+	))
+	mux.Handle(WorkspaceServiceGetWorkspaceSnapshotsProcedure, connect_go.NewUnaryHandler(
+		WorkspaceServiceGetWorkspaceSnapshotsProcedure,
+		svc.GetWorkspaceSnapshots,
+		opts...,
+	))
+	mux.Handle(WorkspaceServiceRestoreWorkspaceSnapshotProcedure, connect_go.NewUnaryHandler(
+		WorkspaceServiceRestoreWorkspaceSnapshotProcedure,
+		svc.RestoreWorkspaceSnapshot,
+		opts...,
+	))
 	return "/dashboard.v1alpha1.WorkspaceService/", mux
 }
 
@@ -265,3 +357,19 @@ func (UnimplementedWorkspaceServiceHandler) UpsertNetworkRule(context.Context, *
 func (UnimplementedWorkspaceServiceHandler) DeleteNetworkRule(context.Context, *connect_go.Request[v1alpha1.DeleteNetworkRuleRequest]) (*connect_go.Response[v1alpha1.DeleteNetworkRuleResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("dashboard.v1alpha1.WorkspaceService.DeleteNetworkRule is not implemented"))
 }
+
+func (UnimplementedWorkspaceServiceHandler) CloneWorkspace(context.Context, *connect_go.Request[v1alpha1.CloneWorkspaceRequest]) (*connect_go.Response[v1alpha1.CloneWorkspaceResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("dashboard.v1alpha1.WorkspaceService.CloneWorkspace is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) CreateWorkspaceSnapshot(context.Context, *connect_go.Request[v1alpha1.CreateWorkspaceSnapshotRequest]) (*connect_go.Response[v1alpha1.CreateWorkspaceSnapshotResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("dashboard.v1alpha1.WorkspaceService.CreateWorkspaceSnapshot is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) GetWorkspaceSnapshots(context.Context, *connect_go.Request[v1alpha1.GetWorkspaceSnapshotsRequest]) (*connect_go.Response[v1alpha1.GetWorkspaceSnapshotsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("dashboard.v1alpha1.WorkspaceService.GetWorkspaceSnapshots is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) RestoreWorkspaceSnapshot(context.Context, *connect_go.Request[v1alpha1.RestoreWorkspaceSnapshotRequest]) (*connect_go.Response[v1alpha1.RestoreWorkspaceSnapshotResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("dashboard.v1alpha1.WorkspaceService.RestoreWorkspaceSnapshot is not implemented"))
+}
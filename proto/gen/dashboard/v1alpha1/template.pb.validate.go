@@ -61,6 +61,22 @@ func (m *TemplateRequiredVars) validate(all bool) error {
 
 	// no validation rules for DefaultValue
 
+	// no validation rules for Type
+
+	// no validation rules for Description
+
+	// no validation rules for Pattern
+
+	// no validation rules for Secret
+
+	if m.Minimum != nil {
+		// no validation rules for Minimum
+	}
+
+	if m.Maximum != nil {
+		// no validation rules for Maximum
+	}
+
 	if len(errors) > 0 {
 		return TemplateRequiredVarsMultiError(errors)
 	}
@@ -287,3 +303,253 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = TemplateValidationError{}
+
+// Validate checks the field values on ObjectDiff with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ObjectDiff) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ObjectDiff with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ObjectDiffMultiError, or
+// nil if none found.
+func (m *ObjectDiff) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ObjectDiff) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ApiVersion
+
+	// no validation rules for Kind
+
+	// no validation rules for Name
+
+	// no validation rules for Diff
+
+	// no validation rules for Created
+
+	// no validation rules for Error
+
+	if len(errors) > 0 {
+		return ObjectDiffMultiError(errors)
+	}
+
+	return nil
+}
+
+// ObjectDiffMultiError is an error wrapping multiple validation errors
+// returned by ObjectDiff.ValidateAll() if the designated constraints aren't met.
+type ObjectDiffMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ObjectDiffMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ObjectDiffMultiError) AllErrors() []error { return m }
+
+// ObjectDiffValidationError is the validation error returned by
+// ObjectDiff.Validate if the designated constraints aren't met.
+type ObjectDiffValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ObjectDiffValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ObjectDiffValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ObjectDiffValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ObjectDiffValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ObjectDiffValidationError) ErrorName() string { return "ObjectDiffValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ObjectDiffValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sObjectDiff.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ObjectDiffValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ObjectDiffValidationError{}
+
+// Validate checks the field values on InstanceDiff with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *InstanceDiff) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on InstanceDiff with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in InstanceDiffMultiError, or
+// nil if none found.
+func (m *InstanceDiff) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *InstanceDiff) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserName
+
+	// no validation rules for WorkspaceName
+
+	for idx, item := range m.GetObjects() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, InstanceDiffValidationError{
+						field:  fmt.Sprintf("Objects[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, InstanceDiffValidationError{
+						field:  fmt.Sprintf("Objects[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return InstanceDiffValidationError{
+					field:  fmt.Sprintf("Objects[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Error
+
+	if len(errors) > 0 {
+		return InstanceDiffMultiError(errors)
+	}
+
+	return nil
+}
+
+// InstanceDiffMultiError is an error wrapping multiple validation errors
+// returned by InstanceDiff.ValidateAll() if the designated constraints aren't met.
+type InstanceDiffMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m InstanceDiffMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m InstanceDiffMultiError) AllErrors() []error { return m }
+
+// InstanceDiffValidationError is the validation error returned by
+// InstanceDiff.Validate if the designated constraints aren't met.
+type InstanceDiffValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e InstanceDiffValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e InstanceDiffValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e InstanceDiffValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e InstanceDiffValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e InstanceDiffValidationError) ErrorName() string { return "InstanceDiffValidationError" }
+
+// Error satisfies the builtin error interface
+func (e InstanceDiffValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sInstanceDiff.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = InstanceDiffValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = InstanceDiffValidationError{}
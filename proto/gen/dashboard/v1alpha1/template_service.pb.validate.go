@@ -534,3 +534,251 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = GetWorkspaceTemplatesResponseValidationError{}
+
+// Validate checks the field values on DiffWorkspaceTemplateRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DiffWorkspaceTemplateRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DiffWorkspaceTemplateRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DiffWorkspaceTemplateRequestMultiError, or nil if none found.
+func (m *DiffWorkspaceTemplateRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DiffWorkspaceTemplateRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Raw
+
+	if m.Domain != nil {
+		// no validation rules for Domain
+	}
+
+	if len(errors) > 0 {
+		return DiffWorkspaceTemplateRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DiffWorkspaceTemplateRequestMultiError is an error wrapping multiple
+// validation errors returned by DiffWorkspaceTemplateRequest.ValidateAll() if
+// the designated constraints aren't met.
+type DiffWorkspaceTemplateRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DiffWorkspaceTemplateRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DiffWorkspaceTemplateRequestMultiError) AllErrors() []error { return m }
+
+// DiffWorkspaceTemplateRequestValidationError is the validation error returned
+// by DiffWorkspaceTemplateRequest.Validate if the designated constraints
+// aren't met.
+type DiffWorkspaceTemplateRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DiffWorkspaceTemplateRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DiffWorkspaceTemplateRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DiffWorkspaceTemplateRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DiffWorkspaceTemplateRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DiffWorkspaceTemplateRequestValidationError) ErrorName() string {
+	return "DiffWorkspaceTemplateRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DiffWorkspaceTemplateRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDiffWorkspaceTemplateRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DiffWorkspaceTemplateRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DiffWorkspaceTemplateRequestValidationError{}
+
+// Validate checks the field values on DiffWorkspaceTemplateResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DiffWorkspaceTemplateResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DiffWorkspaceTemplateResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// DiffWorkspaceTemplateResponseMultiError, or nil if none found.
+func (m *DiffWorkspaceTemplateResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DiffWorkspaceTemplateResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Message
+
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DiffWorkspaceTemplateResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DiffWorkspaceTemplateResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DiffWorkspaceTemplateResponseValidationError{
+					field:  fmt.Sprintf("Items[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return DiffWorkspaceTemplateResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DiffWorkspaceTemplateResponseMultiError is an error wrapping multiple
+// validation errors returned by DiffWorkspaceTemplateResponse.ValidateAll()
+// if the designated constraints aren't met.
+type DiffWorkspaceTemplateResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DiffWorkspaceTemplateResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DiffWorkspaceTemplateResponseMultiError) AllErrors() []error { return m }
+
+// DiffWorkspaceTemplateResponseValidationError is the validation error
+// returned by DiffWorkspaceTemplateResponse.Validate if the designated
+// constraints aren't met.
+type DiffWorkspaceTemplateResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DiffWorkspaceTemplateResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DiffWorkspaceTemplateResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DiffWorkspaceTemplateResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DiffWorkspaceTemplateResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DiffWorkspaceTemplateResponseValidationError) ErrorName() string {
+	return "DiffWorkspaceTemplateResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DiffWorkspaceTemplateResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDiffWorkspaceTemplateResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DiffWorkspaceTemplateResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DiffWorkspaceTemplateResponseValidationError{}
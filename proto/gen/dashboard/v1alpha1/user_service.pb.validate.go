@@ -803,7 +803,7 @@ func (m *CreateUserRequest) validate(all bool) error {
 	if _, ok := _CreateUserRequest_AuthType_InLookup[m.GetAuthType()]; !ok {
 		err := CreateUserRequestValidationError{
 			field:  "AuthType",
-			reason: "value must be in list [ password-secret ldap]",
+			reason: "value must be in list [ password-secret ldap oidc webhook]",
 		}
 		if !all {
 			return err
@@ -929,6 +929,8 @@ var _CreateUserRequest_AuthType_InLookup = map[string]struct{}{
 	"":                {},
 	"password-secret": {},
 	"ldap":            {},
+	"oidc":            {},
+	"webhook":         {},
 }
 
 // Validate checks the field values on CreateUserResponse with the rules
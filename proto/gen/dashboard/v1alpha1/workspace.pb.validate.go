@@ -76,6 +76,17 @@ func (m *NetworkRule) validate(all bool) error {
 
 	// no validation rules for Public
 
+	if _, ok := _NetworkRule_Protocol_InLookup[m.GetProtocol()]; !ok {
+		err := NetworkRuleValidationError{
+			field:  "Protocol",
+			reason: "value must be in list [ http tcp]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if len(errors) > 0 {
 		return NetworkRuleMultiError(errors)
 	}
@@ -153,6 +164,168 @@ var _ interface {
 	ErrorName() string
 } = NetworkRuleValidationError{}
 
+var _NetworkRule_Protocol_InLookup = map[string]struct{}{
+	"":     {},
+	"http": {},
+	"tcp":  {},
+}
+
+// Validate checks the field values on WorkspaceSchedule with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *WorkspaceSchedule) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WorkspaceSchedule with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WorkspaceScheduleMultiError, or nil if none found.
+func (m *WorkspaceSchedule) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WorkspaceSchedule) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for TimeZone
+
+	for idx, item := range m.GetDays() {
+		_, _ = idx, item
+
+		if _, ok := _WorkspaceSchedule_Days_InLookup[item]; !ok {
+			err := WorkspaceScheduleValidationError{
+				field:  fmt.Sprintf("Days[%v]", idx),
+				reason: "value must be in list [Mon Tue Wed Thu Fri Sat Sun]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if !_WorkspaceSchedule_StartTime_Pattern.MatchString(m.GetStartTime()) {
+		err := WorkspaceScheduleValidationError{
+			field:  "StartTime",
+			reason: "value does not match regex pattern \"^(([01][0-9]|2[0-3]):[0-5][0-9])?$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_WorkspaceSchedule_StopTime_Pattern.MatchString(m.GetStopTime()) {
+		err := WorkspaceScheduleValidationError{
+			field:  "StopTime",
+			reason: "value does not match regex pattern \"^(([01][0-9]|2[0-3]):[0-5][0-9])?$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return WorkspaceScheduleMultiError(errors)
+	}
+
+	return nil
+}
+
+// WorkspaceScheduleMultiError is an error wrapping multiple validation errors
+// returned by WorkspaceSchedule.ValidateAll() if the designated constraints
+// aren't met.
+type WorkspaceScheduleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WorkspaceScheduleMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WorkspaceScheduleMultiError) AllErrors() []error { return m }
+
+// WorkspaceScheduleValidationError is the validation error returned by
+// WorkspaceSchedule.Validate if the designated constraints aren't met.
+type WorkspaceScheduleValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WorkspaceScheduleValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WorkspaceScheduleValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WorkspaceScheduleValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WorkspaceScheduleValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WorkspaceScheduleValidationError) ErrorName() string {
+	return "WorkspaceScheduleValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WorkspaceScheduleValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWorkspaceSchedule.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WorkspaceScheduleValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WorkspaceScheduleValidationError{}
+
+var _WorkspaceSchedule_Days_InLookup = map[string]struct{}{
+	"Mon": {},
+	"Tue": {},
+	"Wed": {},
+	"Thu": {},
+	"Fri": {},
+	"Sat": {},
+	"Sun": {},
+}
+
+var _WorkspaceSchedule_StartTime_Pattern = regexp.MustCompile("^(([01][0-9]|2[0-3]):[0-5][0-9])?$")
+
+var _WorkspaceSchedule_StopTime_Pattern = regexp.MustCompile("^(([01][0-9]|2[0-3]):[0-5][0-9])?$")
+
 // Validate checks the field values on WorkspaceSpec with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -215,6 +388,39 @@ func (m *WorkspaceSpec) validate(all bool) error {
 
 	}
 
+	if m.Schedule != nil {
+
+		if all {
+			switch v := interface{}(m.GetSchedule()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, WorkspaceSpecValidationError{
+						field:  "Schedule",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, WorkspaceSpecValidationError{
+						field:  "Schedule",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetSchedule()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return WorkspaceSpecValidationError{
+					field:  "Schedule",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return WorkspaceSpecMultiError(errors)
 	}
@@ -348,6 +554,40 @@ func (m *WorkspaceStatus) validate(all bool) error {
 		}
 	}
 
+	for idx, item := range m.GetConditions() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, WorkspaceStatusValidationError{
+						field:  fmt.Sprintf("Conditions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, WorkspaceStatusValidationError{
+						field:  fmt.Sprintf("Conditions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return WorkspaceStatusValidationError{
+					field:  fmt.Sprintf("Conditions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return WorkspaceStatusMultiError(errors)
 	}
@@ -613,3 +853,171 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = WorkspaceValidationError{}
+
+// Validate checks the field values on WorkspaceSnapshot with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *WorkspaceSnapshot) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WorkspaceSnapshot with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WorkspaceSnapshotMultiError, or nil if none found.
+func (m *WorkspaceSnapshot) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WorkspaceSnapshot) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for WsName
+
+	// no validation rules for Phase
+
+	// no validation rules for Message
+
+	if all {
+		switch v := interface{}(m.GetCreationTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, WorkspaceSnapshotValidationError{
+					field:  "CreationTimestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, WorkspaceSnapshotValidationError{
+					field:  "CreationTimestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreationTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return WorkspaceSnapshotValidationError{
+				field:  "CreationTimestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLastRestoredAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, WorkspaceSnapshotValidationError{
+					field:  "LastRestoredAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, WorkspaceSnapshotValidationError{
+					field:  "LastRestoredAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLastRestoredAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return WorkspaceSnapshotValidationError{
+				field:  "LastRestoredAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return WorkspaceSnapshotMultiError(errors)
+	}
+
+	return nil
+}
+
+// WorkspaceSnapshotMultiError is an error wrapping multiple validation errors
+// returned by WorkspaceSnapshot.ValidateAll() if the designated constraints
+// aren't met.
+type WorkspaceSnapshotMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WorkspaceSnapshotMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WorkspaceSnapshotMultiError) AllErrors() []error { return m }
+
+// WorkspaceSnapshotValidationError is the validation error returned by
+// WorkspaceSnapshot.Validate if the designated constraints aren't met.
+type WorkspaceSnapshotValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WorkspaceSnapshotValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WorkspaceSnapshotValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WorkspaceSnapshotValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WorkspaceSnapshotValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WorkspaceSnapshotValidationError) ErrorName() string {
+	return "WorkspaceSnapshotValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WorkspaceSnapshotValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWorkspaceSnapshot.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WorkspaceSnapshotValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WorkspaceSnapshotValidationError{}
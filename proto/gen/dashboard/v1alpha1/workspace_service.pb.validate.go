@@ -627,6 +627,39 @@ func (m *UpdateWorkspaceRequest) validate(all bool) error {
 
 	}
 
+	if m.Schedule != nil {
+
+		if all {
+			switch v := interface{}(m.GetSchedule()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UpdateWorkspaceRequestValidationError{
+						field:  "Schedule",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UpdateWorkspaceRequestValidationError{
+						field:  "Schedule",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetSchedule()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UpdateWorkspaceRequestValidationError{
+					field:  "Schedule",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return UpdateWorkspaceRequestMultiError(errors)
 	}
@@ -1877,3 +1910,1065 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = DeleteWorkspaceResponseValidationError{}
+
+// Validate checks the field values on CreateWorkspaceSnapshotRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateWorkspaceSnapshotRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateWorkspaceSnapshotRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// CreateWorkspaceSnapshotRequestMultiError, or nil if none found.
+func (m *CreateWorkspaceSnapshotRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateWorkspaceSnapshotRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUserName()) < 1 {
+		err := CreateWorkspaceSnapshotRequestValidationError{
+			field:  "UserName",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetWsName()) < 1 {
+		err := CreateWorkspaceSnapshotRequestValidationError{
+			field:  "WsName",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetSnapshotName()) < 1 {
+		err := CreateWorkspaceSnapshotRequestValidationError{
+			field:  "SnapshotName",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.VolumeSnapshotClassName != nil {
+		// no validation rules for VolumeSnapshotClassName
+	}
+
+	if len(errors) > 0 {
+		return CreateWorkspaceSnapshotRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateWorkspaceSnapshotRequestMultiError is an error wrapping multiple
+// validation errors returned by CreateWorkspaceSnapshotRequest.ValidateAll()
+// if the designated constraints aren't met.
+type CreateWorkspaceSnapshotRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateWorkspaceSnapshotRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateWorkspaceSnapshotRequestMultiError) AllErrors() []error { return m }
+
+// CreateWorkspaceSnapshotRequestValidationError is the validation error
+// returned by CreateWorkspaceSnapshotRequest.Validate if the designated
+// constraints aren't met.
+type CreateWorkspaceSnapshotRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateWorkspaceSnapshotRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateWorkspaceSnapshotRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateWorkspaceSnapshotRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateWorkspaceSnapshotRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateWorkspaceSnapshotRequestValidationError) ErrorName() string {
+	return "CreateWorkspaceSnapshotRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateWorkspaceSnapshotRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateWorkspaceSnapshotRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateWorkspaceSnapshotRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateWorkspaceSnapshotRequestValidationError{}
+
+// Validate checks the field values on CreateWorkspaceSnapshotResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateWorkspaceSnapshotResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateWorkspaceSnapshotResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// CreateWorkspaceSnapshotResponseMultiError, or nil if none found.
+func (m *CreateWorkspaceSnapshotResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateWorkspaceSnapshotResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Message
+
+	if all {
+		switch v := interface{}(m.GetSnapshot()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateWorkspaceSnapshotResponseValidationError{
+					field:  "Snapshot",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateWorkspaceSnapshotResponseValidationError{
+					field:  "Snapshot",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSnapshot()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateWorkspaceSnapshotResponseValidationError{
+				field:  "Snapshot",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CreateWorkspaceSnapshotResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateWorkspaceSnapshotResponseMultiError is an error wrapping multiple
+// validation errors returned by CreateWorkspaceSnapshotResponse.ValidateAll()
+// if the designated constraints aren't met.
+type CreateWorkspaceSnapshotResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateWorkspaceSnapshotResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateWorkspaceSnapshotResponseMultiError) AllErrors() []error { return m }
+
+// CreateWorkspaceSnapshotResponseValidationError is the validation error
+// returned by CreateWorkspaceSnapshotResponse.Validate if the designated
+// constraints aren't met.
+type CreateWorkspaceSnapshotResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateWorkspaceSnapshotResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateWorkspaceSnapshotResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateWorkspaceSnapshotResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateWorkspaceSnapshotResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateWorkspaceSnapshotResponseValidationError) ErrorName() string {
+	return "CreateWorkspaceSnapshotResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateWorkspaceSnapshotResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateWorkspaceSnapshotResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateWorkspaceSnapshotResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateWorkspaceSnapshotResponseValidationError{}
+
+// Validate checks the field values on GetWorkspaceSnapshotsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetWorkspaceSnapshotsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetWorkspaceSnapshotsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetWorkspaceSnapshotsRequestMultiError, or nil if none found.
+func (m *GetWorkspaceSnapshotsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetWorkspaceSnapshotsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUserName()) < 1 {
+		err := GetWorkspaceSnapshotsRequestValidationError{
+			field:  "UserName",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for WsName
+
+	if len(errors) > 0 {
+		return GetWorkspaceSnapshotsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetWorkspaceSnapshotsRequestMultiError is an error wrapping multiple
+// validation errors returned by GetWorkspaceSnapshotsRequest.ValidateAll() if
+// the designated constraints aren't met.
+type GetWorkspaceSnapshotsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetWorkspaceSnapshotsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetWorkspaceSnapshotsRequestMultiError) AllErrors() []error { return m }
+
+// GetWorkspaceSnapshotsRequestValidationError is the validation error returned
+// by GetWorkspaceSnapshotsRequest.Validate if the designated constraints
+// aren't met.
+type GetWorkspaceSnapshotsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetWorkspaceSnapshotsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetWorkspaceSnapshotsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetWorkspaceSnapshotsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetWorkspaceSnapshotsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetWorkspaceSnapshotsRequestValidationError) ErrorName() string {
+	return "GetWorkspaceSnapshotsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetWorkspaceSnapshotsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetWorkspaceSnapshotsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetWorkspaceSnapshotsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetWorkspaceSnapshotsRequestValidationError{}
+
+// Validate checks the field values on GetWorkspaceSnapshotsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetWorkspaceSnapshotsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetWorkspaceSnapshotsResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetWorkspaceSnapshotsResponseMultiError, or nil if none found.
+func (m *GetWorkspaceSnapshotsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetWorkspaceSnapshotsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Message
+
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetWorkspaceSnapshotsResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetWorkspaceSnapshotsResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetWorkspaceSnapshotsResponseValidationError{
+					field:  fmt.Sprintf("Items[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetWorkspaceSnapshotsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetWorkspaceSnapshotsResponseMultiError is an error wrapping multiple
+// validation errors returned by GetWorkspaceSnapshotsResponse.ValidateAll()
+// if the designated constraints aren't met.
+type GetWorkspaceSnapshotsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetWorkspaceSnapshotsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetWorkspaceSnapshotsResponseMultiError) AllErrors() []error { return m }
+
+// GetWorkspaceSnapshotsResponseValidationError is the validation error
+// returned by GetWorkspaceSnapshotsResponse.Validate if the designated
+// constraints aren't met.
+type GetWorkspaceSnapshotsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetWorkspaceSnapshotsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetWorkspaceSnapshotsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetWorkspaceSnapshotsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetWorkspaceSnapshotsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetWorkspaceSnapshotsResponseValidationError) ErrorName() string {
+	return "GetWorkspaceSnapshotsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetWorkspaceSnapshotsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetWorkspaceSnapshotsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetWorkspaceSnapshotsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetWorkspaceSnapshotsResponseValidationError{}
+
+// Validate checks the field values on RestoreWorkspaceSnapshotRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RestoreWorkspaceSnapshotRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreWorkspaceSnapshotRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RestoreWorkspaceSnapshotRequestMultiError, or nil if none found.
+func (m *RestoreWorkspaceSnapshotRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreWorkspaceSnapshotRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUserName()) < 1 {
+		err := RestoreWorkspaceSnapshotRequestValidationError{
+			field:  "UserName",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetSnapshotName()) < 1 {
+		err := RestoreWorkspaceSnapshotRequestValidationError{
+			field:  "SnapshotName",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RestoreWorkspaceSnapshotRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RestoreWorkspaceSnapshotRequestMultiError is an error wrapping multiple
+// validation errors returned by RestoreWorkspaceSnapshotRequest.ValidateAll()
+// if the designated constraints aren't met.
+type RestoreWorkspaceSnapshotRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreWorkspaceSnapshotRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreWorkspaceSnapshotRequestMultiError) AllErrors() []error { return m }
+
+// RestoreWorkspaceSnapshotRequestValidationError is the validation error
+// returned by RestoreWorkspaceSnapshotRequest.Validate if the designated
+// constraints aren't met.
+type RestoreWorkspaceSnapshotRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreWorkspaceSnapshotRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreWorkspaceSnapshotRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreWorkspaceSnapshotRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreWorkspaceSnapshotRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreWorkspaceSnapshotRequestValidationError) ErrorName() string {
+	return "RestoreWorkspaceSnapshotRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreWorkspaceSnapshotRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreWorkspaceSnapshotRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreWorkspaceSnapshotRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreWorkspaceSnapshotRequestValidationError{}
+
+// Validate checks the field values on RestoreWorkspaceSnapshotResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *RestoreWorkspaceSnapshotResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreWorkspaceSnapshotResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RestoreWorkspaceSnapshotResponseMultiError, or nil if none found.
+func (m *RestoreWorkspaceSnapshotResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreWorkspaceSnapshotResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Message
+
+	if all {
+		switch v := interface{}(m.GetSnapshot()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RestoreWorkspaceSnapshotResponseValidationError{
+					field:  "Snapshot",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RestoreWorkspaceSnapshotResponseValidationError{
+					field:  "Snapshot",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSnapshot()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RestoreWorkspaceSnapshotResponseValidationError{
+				field:  "Snapshot",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RestoreWorkspaceSnapshotResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RestoreWorkspaceSnapshotResponseMultiError is an error wrapping multiple
+// validation errors returned by
+// RestoreWorkspaceSnapshotResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RestoreWorkspaceSnapshotResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreWorkspaceSnapshotResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreWorkspaceSnapshotResponseMultiError) AllErrors() []error { return m }
+
+// RestoreWorkspaceSnapshotResponseValidationError is the validation error
+// returned by RestoreWorkspaceSnapshotResponse.Validate if the designated
+// constraints aren't met.
+type RestoreWorkspaceSnapshotResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreWorkspaceSnapshotResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreWorkspaceSnapshotResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreWorkspaceSnapshotResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreWorkspaceSnapshotResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreWorkspaceSnapshotResponseValidationError) ErrorName() string {
+	return "RestoreWorkspaceSnapshotResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreWorkspaceSnapshotResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreWorkspaceSnapshotResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreWorkspaceSnapshotResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreWorkspaceSnapshotResponseValidationError{}
+
+// Validate checks the field values on CloneWorkspaceRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CloneWorkspaceRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CloneWorkspaceRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CloneWorkspaceRequestMultiError, or nil if none found.
+func (m *CloneWorkspaceRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CloneWorkspaceRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUserName()) < 1 {
+		err := CloneWorkspaceRequestValidationError{
+			field:  "UserName",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetWsName()) < 1 {
+		err := CloneWorkspaceRequestValidationError{
+			field:  "WsName",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetDstWsName()) < 1 {
+		err := CloneWorkspaceRequestValidationError{
+			field:  "DstWsName",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.DstUserName != nil {
+		// no validation rules for DstUserName
+	}
+
+	if len(errors) > 0 {
+		return CloneWorkspaceRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CloneWorkspaceRequestMultiError is an error wrapping multiple validation
+// errors returned by CloneWorkspaceRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CloneWorkspaceRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CloneWorkspaceRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CloneWorkspaceRequestMultiError) AllErrors() []error { return m }
+
+// CloneWorkspaceRequestValidationError is the validation error returned by
+// CloneWorkspaceRequest.Validate if the designated constraints aren't met.
+type CloneWorkspaceRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CloneWorkspaceRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CloneWorkspaceRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CloneWorkspaceRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CloneWorkspaceRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CloneWorkspaceRequestValidationError) ErrorName() string {
+	return "CloneWorkspaceRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CloneWorkspaceRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCloneWorkspaceRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CloneWorkspaceRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CloneWorkspaceRequestValidationError{}
+
+// Validate checks the field values on CloneWorkspaceResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CloneWorkspaceResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CloneWorkspaceResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CloneWorkspaceResponseMultiError, or nil if none found.
+func (m *CloneWorkspaceResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CloneWorkspaceResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Message
+
+	if all {
+		switch v := interface{}(m.GetWorkspace()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CloneWorkspaceResponseValidationError{
+					field:  "Workspace",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CloneWorkspaceResponseValidationError{
+					field:  "Workspace",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetWorkspace()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CloneWorkspaceResponseValidationError{
+				field:  "Workspace",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CloneWorkspaceResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CloneWorkspaceResponseMultiError is an error wrapping multiple validation
+// errors returned by CloneWorkspaceResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CloneWorkspaceResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CloneWorkspaceResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CloneWorkspaceResponseMultiError) AllErrors() []error { return m }
+
+// CloneWorkspaceResponseValidationError is the validation error returned by
+// CloneWorkspaceResponse.Validate if the designated constraints aren't met.
+type CloneWorkspaceResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CloneWorkspaceResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CloneWorkspaceResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CloneWorkspaceResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CloneWorkspaceResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CloneWorkspaceResponseValidationError) ErrorName() string {
+	return "CloneWorkspaceResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CloneWorkspaceResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCloneWorkspaceResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CloneWorkspaceResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CloneWorkspaceResponseValidationError{}
@@ -0,0 +1,107 @@
+package cosmoauth
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// activityReporter reports proxied accesses to workspaces to cosmo controller-manager,
+// which records the last accessed time used for idle auto-suspend.
+type activityReporter struct {
+	url      string
+	token    string
+	interval time.Duration
+	client   *http.Client
+
+	mu           sync.Mutex
+	lastReported map[string]time.Time
+	lastEvicted  time.Time
+}
+
+type activity struct {
+	UserName string `json:"userName"`
+	Host     string `json:"host"`
+}
+
+// newActivityReporter creates the reporter. rootCAs verifies the server certificate of the endpoint,
+// and the system roots are used if it is nil.
+func newActivityReporter(url, token string, interval time.Duration, rootCAs *x509.CertPool) *activityReporter {
+	return &activityReporter{
+		url:      url,
+		token:    token,
+		interval: interval,
+		client: &http.Client{
+			Timeout: 5 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: rootCAs},
+			},
+		},
+		lastReported: make(map[string]time.Time),
+	}
+}
+
+// Report sends the activity asynchronously at most once per interval for each user and host
+func (a *activityReporter) Report(userName, host string) {
+	if !a.shouldReport(userName, host, time.Now()) {
+		return
+	}
+	go a.post(activity{UserName: userName, Host: host})
+}
+
+func (a *activityReporter) shouldReport(userName, host string, now time.Time) bool {
+	key := userName + "/" + host
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.evict(now)
+
+	if last, ok := a.lastReported[key]; ok && now.Sub(last) < a.interval {
+		return false
+	}
+	a.lastReported[key] = now
+	return true
+}
+
+// evict removes the entries reported before the interval not to grow lastReported without bound.
+// It runs at most once per interval
+func (a *activityReporter) evict(now time.Time) {
+	if now.Sub(a.lastEvicted) < a.interval {
+		return
+	}
+	for key, last := range a.lastReported {
+		if now.Sub(last) >= a.interval {
+			delete(a.lastReported, key)
+		}
+	}
+	a.lastEvicted = now
+}
+
+func (a *activityReporter) post(act activity) {
+	body, err := json.Marshal(act)
+	if err != nil {
+		LoggerERROR.Printf("failed to marshal activity. err=%s", err)
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		LoggerERROR.Printf("failed to create activity request. err=%s", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if a.token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.token)
+	}
+	res, err := a.client.Do(req)
+	if err != nil {
+		LoggerERROR.Printf("failed to report activity. err=%s", err)
+		return
+	}
+	defer res.Body.Close()
+	LoggerDEBUG.Printf("activity reported: user=%s host=%s status=%d", act.UserName, act.Host, res.StatusCode)
+}
@@ -0,0 +1,33 @@
+package cosmoauth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActivityReporter_shouldReport(t *testing.T) {
+	a := newActivityReporter("http://localhost", "", time.Minute, nil)
+	now := time.Now()
+
+	if !a.shouldReport("user1", "host1", now) {
+		t.Error("first report is skipped")
+	}
+	if a.shouldReport("user1", "host1", now.Add(30*time.Second)) {
+		t.Error("reported within the interval")
+	}
+	if !a.shouldReport("user2", "host2", now.Add(30*time.Second)) {
+		t.Error("report of other host is skipped")
+	}
+	if !a.shouldReport("user1", "host1", now.Add(time.Minute)) {
+		t.Error("report after the interval is skipped")
+	}
+
+	// entries older than the interval are evicted
+	a.shouldReport("user3", "host3", now.Add(2*time.Minute))
+	if _, ok := a.lastReported["user2/host2"]; ok {
+		t.Error("expired entry is not evicted")
+	}
+	if len(a.lastReported) != 1 {
+		t.Errorf("len(lastReported) = %d, want 1", len(a.lastReported))
+	}
+}
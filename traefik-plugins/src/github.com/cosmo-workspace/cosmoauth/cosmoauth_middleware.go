@@ -2,6 +2,7 @@ package cosmoauth
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"log"
@@ -39,6 +40,11 @@ type Config struct {
 	CookieHashKey     string `json:"cookieHashKey,omitempty" yaml:"cookieHashKey,omitempty"`
 	CookieBlockKey    string `json:"cookieBlockKey,omitempty" yaml:"cookieBlockKey,omitempty"`
 	SignInUrl         string `json:"signInUrl,omitempty" yaml:"signInUrl,omitempty"`
+
+	ActivityReportUrl             string `json:"activityReportUrl,omitempty" yaml:"activityReportUrl,omitempty"`
+	ActivityReportIntervalSeconds int    `json:"activityReportIntervalSeconds,omitempty" yaml:"activityReportIntervalSeconds,omitempty"`
+	ActivityReportCAFile          string `json:"activityReportCAFile,omitempty" yaml:"activityReportCAFile,omitempty"`
+	ActivityReportToken           string `json:"activityReportToken,omitempty" yaml:"activityReportToken,omitempty"`
 }
 
 // CreateConfig creates the default plugin configuration.
@@ -50,6 +56,9 @@ func CreateConfig() *Config {
 		CookieHashKey:     "----+----X----+----X----+----X----+----X----+----X----+----X----",
 		CookieBlockKey:    "----+----X----+----X----+----X--",
 		SignInUrl:         "",
+
+		ActivityReportUrl:             "",
+		ActivityReportIntervalSeconds: 60,
 	}
 }
 
@@ -60,6 +69,8 @@ type CosmoAuth struct {
 	RedirectPath string
 
 	SessionStore sessions.Store
+
+	activity *activityReporter
 }
 
 // New created a new Demo plugin.
@@ -74,6 +85,11 @@ func New(ctx context.Context, next http.Handler, config *Config, name string) (h
 		CookieHashKey:     os.ExpandEnv(config.CookieHashKey),
 		CookieBlockKey:    os.ExpandEnv(config.CookieBlockKey),
 		SignInUrl:         os.ExpandEnv(config.SignInUrl),
+
+		ActivityReportUrl:             os.ExpandEnv(config.ActivityReportUrl),
+		ActivityReportIntervalSeconds: config.ActivityReportIntervalSeconds,
+		ActivityReportCAFile:          os.ExpandEnv(config.ActivityReportCAFile),
+		ActivityReportToken:           os.ExpandEnv(config.ActivityReportToken),
 	}
 
 	p := &CosmoAuth{
@@ -83,6 +99,22 @@ func New(ctx context.Context, next http.Handler, config *Config, name string) (h
 		SessionStore: sessions.NewCookieStore([]byte(conf.CookieHashKey), []byte(conf.CookieBlockKey)),
 	}
 
+	if conf.ActivityReportUrl != "" {
+		var rootCAs *x509.CertPool
+		if conf.ActivityReportCAFile != "" {
+			caCert, err := os.ReadFile(conf.ActivityReportCAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read activity report CA file: %w", err)
+			}
+			rootCAs = x509.NewCertPool()
+			if !rootCAs.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("no valid certificates in activity report CA file: %s", conf.ActivityReportCAFile)
+			}
+		}
+		interval := time.Duration(conf.ActivityReportIntervalSeconds) * time.Second
+		p.activity = newActivityReporter(conf.ActivityReportUrl, conf.ActivityReportToken, interval, rootCAs)
+	}
+
 	return p, nil
 }
 
@@ -151,6 +183,9 @@ func (p *CosmoAuth) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	accessLog(r, http.StatusOK, sesInfo, "access is allowed")
+	if p.activity != nil && userName != "" {
+		p.activity.Report(userName, r.Host)
+	}
 	p.next.ServeHTTP(w, r.WithContext(ctx))
 	w.Header().Set("X-Cosmo-UserName", sesInfo.UserName)
 }
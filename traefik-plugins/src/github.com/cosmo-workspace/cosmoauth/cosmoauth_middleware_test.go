@@ -2,8 +2,12 @@ package cosmoauth_test
 
 import (
 	"context"
+	"encoding/json"
+	"encoding/pem"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -150,3 +154,82 @@ func TestCosmoAuth_ServeHTTP(t *testing.T) {
 		})
 	}
 }
+
+func TestCosmoAuth_ActivityReport(t *testing.T) {
+	reported := make(chan string, 10)
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		reported <- body["userName"] + "@" + body["host"]
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &cosmoauth.Config{
+		LogLevel:                      "DEBUG",
+		CookieSessionName:             "sessionName",
+		CookieDomain:                  "domain.com",
+		CookieHashKey:                 "12345678901234567890123456789012",
+		CookieBlockKey:                "abcdefghijklmnopqrstuABCDEFGHIJK",
+		SignInUrl:                     "https://xxxx.domain.com",
+		ActivityReportUrl:             ts.URL,
+		ActivityReportIntervalSeconds: 60,
+		ActivityReportCAFile:          caFile,
+		ActivityReportToken:           "token",
+	}
+	handler, err := cosmoauth.New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), cfg, "cosmo-auth-middleware")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := sessions.NewCookieStore([]byte(cfg.CookieHashKey), []byte(cfg.CookieBlockKey))
+	newRequest := func() *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://main-ws1-user1.domain.com", nil)
+		tempRes := httptest.NewRecorder()
+		ses, _ := store.New(req, cfg.CookieSessionName)
+		ses = session.Set(ses, session.Info{UserName: "user1"})
+		ses.Save(req, tempRes)
+		req.Header.Set("Cookie", tempRes.Header().Get("Set-Cookie"))
+		req.Header.Set("X-Cosmo-UserName", "user1")
+		return req
+	}
+
+	// reported once within the interval
+	for i := 0; i < 3; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest())
+	}
+
+	select {
+	case got := <-reported:
+		if got != "user1@main-ws1-user1.domain.com" {
+			t.Errorf("reported activity = %s", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("activity is not reported")
+	}
+	select {
+	case got := <-reported:
+		t.Errorf("activity is reported more than once: %s", got)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func TestCosmoAuth_ActivityReportInvalidCAFile(t *testing.T) {
+	cfg := cosmoauth.CreateConfig()
+	cfg.ActivityReportUrl = "https://cosmo-webhook-service.cosmo-system.svc/workspace-activity"
+	cfg.ActivityReportCAFile = filepath.Join(t.TempDir(), "notfound.crt")
+
+	_, err := cosmoauth.New(context.Background(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), cfg, "cosmo-auth-middleware")
+	if err == nil {
+		t.Error("New() error = nil, want error")
+	}
+}
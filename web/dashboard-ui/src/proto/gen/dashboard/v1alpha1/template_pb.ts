@@ -24,6 +24,41 @@ export class TemplateRequiredVars extends Message<TemplateRequiredVars> {
    */
   defaultValue = "";
 
+  /**
+   * @generated from field: string type = 3;
+   */
+  type = "";
+
+  /**
+   * @generated from field: string description = 4;
+   */
+  description = "";
+
+  /**
+   * @generated from field: string pattern = 5;
+   */
+  pattern = "";
+
+  /**
+   * @generated from field: optional int64 minimum = 6;
+   */
+  minimum?: bigint;
+
+  /**
+   * @generated from field: optional int64 maximum = 7;
+   */
+  maximum?: bigint;
+
+  /**
+   * @generated from field: repeated string allowed_values = 8;
+   */
+  allowedValues: string[] = [];
+
+  /**
+   * @generated from field: bool secret = 9;
+   */
+  secret = false;
+
   constructor(data?: PartialMessage<TemplateRequiredVars>) {
     super();
     proto3.util.initPartial(data, this);
@@ -34,6 +69,13 @@ export class TemplateRequiredVars extends Message<TemplateRequiredVars> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "var_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "default_value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "pattern", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "minimum", kind: "scalar", T: 3 /* ScalarType.INT64 */, opt: true },
+    { no: 7, name: "maximum", kind: "scalar", T: 3 /* ScalarType.INT64 */, opt: true },
+    { no: 8, name: "allowed_values", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 9, name: "secret", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): TemplateRequiredVars {
@@ -132,3 +174,125 @@ export class Template extends Message<Template> {
   }
 }
 
+/**
+ * @generated from message dashboard.v1alpha1.ObjectDiff
+ */
+export class ObjectDiff extends Message<ObjectDiff> {
+  /**
+   * @generated from field: string api_version = 1;
+   */
+  apiVersion = "";
+
+  /**
+   * @generated from field: string kind = 2;
+   */
+  kind = "";
+
+  /**
+   * @generated from field: string name = 3;
+   */
+  name = "";
+
+  /**
+   * @generated from field: string diff = 4;
+   */
+  diff = "";
+
+  /**
+   * @generated from field: bool created = 5;
+   */
+  created = false;
+
+  /**
+   * @generated from field: string error = 6;
+   */
+  error = "";
+
+  constructor(data?: PartialMessage<ObjectDiff>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.ObjectDiff";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "api_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "kind", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "diff", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "created", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 6, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ObjectDiff {
+    return new ObjectDiff().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ObjectDiff {
+    return new ObjectDiff().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ObjectDiff {
+    return new ObjectDiff().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ObjectDiff | PlainMessage<ObjectDiff> | undefined, b: ObjectDiff | PlainMessage<ObjectDiff> | undefined): boolean {
+    return proto3.util.equals(ObjectDiff, a, b);
+  }
+}
+
+/**
+ * @generated from message dashboard.v1alpha1.InstanceDiff
+ */
+export class InstanceDiff extends Message<InstanceDiff> {
+  /**
+   * @generated from field: string user_name = 1;
+   */
+  userName = "";
+
+  /**
+   * @generated from field: string workspace_name = 2;
+   */
+  workspaceName = "";
+
+  /**
+   * @generated from field: repeated dashboard.v1alpha1.ObjectDiff objects = 3;
+   */
+  objects: ObjectDiff[] = [];
+
+  /**
+   * @generated from field: string error = 4;
+   */
+  error = "";
+
+  constructor(data?: PartialMessage<InstanceDiff>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.InstanceDiff";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "workspace_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "objects", kind: "message", T: ObjectDiff, repeated: true },
+    { no: 4, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): InstanceDiff {
+    return new InstanceDiff().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): InstanceDiff {
+    return new InstanceDiff().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): InstanceDiff {
+    return new InstanceDiff().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: InstanceDiff | PlainMessage<InstanceDiff> | undefined, b: InstanceDiff | PlainMessage<InstanceDiff> | undefined): boolean {
+    return proto3.util.equals(InstanceDiff, a, b);
+  }
+}
+
@@ -7,7 +7,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { GetUserAddonTemplatesRequest, GetUserAddonTemplatesResponse, GetWorkspaceTemplatesRequest, GetWorkspaceTemplatesResponse } from "./template_service_pb.js";
+import { DiffWorkspaceTemplateRequest, DiffWorkspaceTemplateResponse, GetUserAddonTemplatesRequest, GetUserAddonTemplatesResponse, GetWorkspaceTemplatesRequest, GetWorkspaceTemplatesResponse } from "./template_service_pb.js";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -38,6 +38,17 @@ export const TemplateService = {
       O: GetWorkspaceTemplatesResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Dry-run diff of workspace template changes against live workspaces
+     *
+     * @generated from rpc dashboard.v1alpha1.TemplateService.DiffWorkspaceTemplate
+     */
+    diffWorkspaceTemplate: {
+      name: "DiffWorkspaceTemplate",
+      I: DiffWorkspaceTemplateRequest,
+      O: DiffWorkspaceTemplateResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
@@ -9,7 +9,7 @@
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
 import { Message, proto3 } from "@bufbuild/protobuf";
-import { Template } from "./template_pb.js";
+import { InstanceDiff, Template } from "./template_pb.js";
 
 /**
  * @generated from message dashboard.v1alpha1.GetUserAddonTemplatesRequest
@@ -183,3 +183,89 @@ export class GetWorkspaceTemplatesResponse extends Message<GetWorkspaceTemplates
   }
 }
 
+/**
+ * @generated from message dashboard.v1alpha1.DiffWorkspaceTemplateRequest
+ */
+export class DiffWorkspaceTemplateRequest extends Message<DiffWorkspaceTemplateRequest> {
+  /**
+   * @generated from field: string raw = 1;
+   */
+  raw = "";
+
+  /**
+   * @generated from field: optional string domain = 2;
+   */
+  domain?: string;
+
+  constructor(data?: PartialMessage<DiffWorkspaceTemplateRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.DiffWorkspaceTemplateRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "raw", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "domain", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DiffWorkspaceTemplateRequest {
+    return new DiffWorkspaceTemplateRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DiffWorkspaceTemplateRequest {
+    return new DiffWorkspaceTemplateRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DiffWorkspaceTemplateRequest {
+    return new DiffWorkspaceTemplateRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DiffWorkspaceTemplateRequest | PlainMessage<DiffWorkspaceTemplateRequest> | undefined, b: DiffWorkspaceTemplateRequest | PlainMessage<DiffWorkspaceTemplateRequest> | undefined): boolean {
+    return proto3.util.equals(DiffWorkspaceTemplateRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message dashboard.v1alpha1.DiffWorkspaceTemplateResponse
+ */
+export class DiffWorkspaceTemplateResponse extends Message<DiffWorkspaceTemplateResponse> {
+  /**
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  /**
+   * @generated from field: repeated dashboard.v1alpha1.InstanceDiff items = 2;
+   */
+  items: InstanceDiff[] = [];
+
+  constructor(data?: PartialMessage<DiffWorkspaceTemplateResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.DiffWorkspaceTemplateResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "items", kind: "message", T: InstanceDiff, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DiffWorkspaceTemplateResponse {
+    return new DiffWorkspaceTemplateResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DiffWorkspaceTemplateResponse {
+    return new DiffWorkspaceTemplateResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DiffWorkspaceTemplateResponse {
+    return new DiffWorkspaceTemplateResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DiffWorkspaceTemplateResponse | PlainMessage<DiffWorkspaceTemplateResponse> | undefined, b: DiffWorkspaceTemplateResponse | PlainMessage<DiffWorkspaceTemplateResponse> | undefined): boolean {
+    return proto3.util.equals(DiffWorkspaceTemplateResponse, a, b);
+  }
+}
+
@@ -8,7 +8,7 @@
 // @ts-nocheck
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
-import { Message, proto3 } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64, Timestamp } from "@bufbuild/protobuf";
 
 /**
  * @generated from enum dashboard.v1alpha1.DeletePolicy
@@ -79,6 +79,11 @@ export class User extends Message<User> {
    */
   deletePolicy?: DeletePolicy;
 
+  /**
+   * @generated from field: repeated dashboard.v1alpha1.Condition conditions = 10;
+   */
+  conditions: Condition[] = [];
+
   constructor(data?: PartialMessage<User>) {
     super();
     proto3.util.initPartial(data, this);
@@ -96,6 +101,7 @@ export class User extends Message<User> {
     { no: 7, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 8, name: "raw", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
     { no: 9, name: "delete_policy", kind: "enum", T: proto3.getEnumType(DeletePolicy), opt: true },
+    { no: 10, name: "conditions", kind: "message", T: Condition, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): User {
@@ -170,3 +176,69 @@ export class UserAddon extends Message<UserAddon> {
   }
 }
 
+/**
+ * @generated from message dashboard.v1alpha1.Condition
+ */
+export class Condition extends Message<Condition> {
+  /**
+   * @generated from field: string type = 1;
+   */
+  type = "";
+
+  /**
+   * @generated from field: string status = 2;
+   */
+  status = "";
+
+  /**
+   * @generated from field: string reason = 3;
+   */
+  reason = "";
+
+  /**
+   * @generated from field: string message = 4;
+   */
+  message = "";
+
+  /**
+   * @generated from field: int64 observed_generation = 5;
+   */
+  observedGeneration = protoInt64.zero;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_transition_time = 6;
+   */
+  lastTransitionTime?: Timestamp;
+
+  constructor(data?: PartialMessage<Condition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.Condition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "observed_generation", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "last_transition_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Condition {
+    return new Condition().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Condition {
+    return new Condition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Condition {
+    return new Condition().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Condition | PlainMessage<Condition> | undefined, b: Condition | PlainMessage<Condition> | undefined): boolean {
+    return proto3.util.equals(Condition, a, b);
+  }
+}
@@ -9,7 +9,7 @@
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
 import { Message, proto3, protoInt64, Timestamp } from "@bufbuild/protobuf";
-import { DeletePolicy } from "./user_pb.js";
+import { Condition, DeletePolicy } from "./user_pb.js";
 
 /**
  * @generated from message dashboard.v1alpha1.NetworkRule
@@ -45,6 +45,13 @@ export class NetworkRule extends Message<NetworkRule> {
    */
   allowedUsers: string[] = [];
 
+  /**
+   * http or tcp. empty means http
+   *
+   * @generated from field: string protocol = 7;
+   */
+  protocol = "";
+
   constructor(data?: PartialMessage<NetworkRule>) {
     super();
     proto3.util.initPartial(data, this);
@@ -59,6 +66,7 @@ export class NetworkRule extends Message<NetworkRule> {
     { no: 4, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 5, name: "public", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
     { no: 6, name: "allowed_users", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 7, name: "protocol", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): NetworkRule {
@@ -78,6 +86,69 @@ export class NetworkRule extends Message<NetworkRule> {
   }
 }
 
+/**
+ * @generated from message dashboard.v1alpha1.WorkspaceSchedule
+ */
+export class WorkspaceSchedule extends Message<WorkspaceSchedule> {
+  /**
+   * IANA time zone name such as "Asia/Tokyo". default is UTC
+   *
+   * @generated from field: string time_zone = 1;
+   */
+  timeZone = "";
+
+  /**
+   * days of the week such as "Mon". empty means every day
+   *
+   * @generated from field: repeated string days = 2;
+   */
+  days: string[] = [];
+
+  /**
+   * time to start workspace in "HH:MM" format
+   *
+   * @generated from field: string start_time = 3;
+   */
+  startTime = "";
+
+  /**
+   * time to stop workspace in "HH:MM" format
+   *
+   * @generated from field: string stop_time = 4;
+   */
+  stopTime = "";
+
+  constructor(data?: PartialMessage<WorkspaceSchedule>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.WorkspaceSchedule";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "time_zone", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "days", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 3, name: "start_time", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "stop_time", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): WorkspaceSchedule {
+    return new WorkspaceSchedule().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): WorkspaceSchedule {
+    return new WorkspaceSchedule().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): WorkspaceSchedule {
+    return new WorkspaceSchedule().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: WorkspaceSchedule | PlainMessage<WorkspaceSchedule> | undefined, b: WorkspaceSchedule | PlainMessage<WorkspaceSchedule> | undefined): boolean {
+    return proto3.util.equals(WorkspaceSchedule, a, b);
+  }
+}
+
 /**
  * @generated from message dashboard.v1alpha1.WorkspaceSpec
  */
@@ -102,6 +173,11 @@ export class WorkspaceSpec extends Message<WorkspaceSpec> {
    */
   network: NetworkRule[] = [];
 
+  /**
+   * @generated from field: optional dashboard.v1alpha1.WorkspaceSchedule schedule = 5;
+   */
+  schedule?: WorkspaceSchedule;
+
   constructor(data?: PartialMessage<WorkspaceSpec>) {
     super();
     proto3.util.initPartial(data, this);
@@ -114,6 +190,7 @@ export class WorkspaceSpec extends Message<WorkspaceSpec> {
     { no: 2, name: "replicas", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
     { no: 3, name: "vars", kind: "map", K: 9 /* ScalarType.STRING */, V: {kind: "scalar", T: 9 /* ScalarType.STRING */} },
     { no: 4, name: "network", kind: "message", T: NetworkRule, repeated: true },
+    { no: 5, name: "schedule", kind: "message", T: WorkspaceSchedule, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): WorkspaceSpec {
@@ -152,6 +229,11 @@ export class WorkspaceStatus extends Message<WorkspaceStatus> {
    */
   lastStartedAt?: Timestamp;
 
+  /**
+   * @generated from field: repeated dashboard.v1alpha1.Condition conditions = 4;
+   */
+  conditions: Condition[] = [];
+
   constructor(data?: PartialMessage<WorkspaceStatus>) {
     super();
     proto3.util.initPartial(data, this);
@@ -163,6 +245,7 @@ export class WorkspaceStatus extends Message<WorkspaceStatus> {
     { no: 1, name: "phase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "main_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "last_started_at", kind: "message", T: Timestamp },
+    { no: 4, name: "conditions", kind: "message", T: Condition, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): WorkspaceStatus {
@@ -261,3 +344,76 @@ export class Workspace extends Message<Workspace> {
   }
 }
 
+
+/**
+ * @generated from message dashboard.v1alpha1.WorkspaceSnapshot
+ */
+export class WorkspaceSnapshot extends Message<WorkspaceSnapshot> {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * @generated from field: string ws_name = 2;
+   */
+  wsName = "";
+
+  /**
+   * @generated from field: string phase = 3;
+   */
+  phase = "";
+
+  /**
+   * @generated from field: string message = 4;
+   */
+  message = "";
+
+  /**
+   * @generated from field: repeated string volumes = 5;
+   */
+  volumes: string[] = [];
+
+  /**
+   * @generated from field: google.protobuf.Timestamp creation_timestamp = 6;
+   */
+  creationTimestamp?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_restored_at = 7;
+   */
+  lastRestoredAt?: Timestamp;
+
+  constructor(data?: PartialMessage<WorkspaceSnapshot>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.WorkspaceSnapshot";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "ws_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "phase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "volumes", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 6, name: "creation_timestamp", kind: "message", T: Timestamp },
+    { no: 7, name: "last_restored_at", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): WorkspaceSnapshot {
+    return new WorkspaceSnapshot().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): WorkspaceSnapshot {
+    return new WorkspaceSnapshot().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): WorkspaceSnapshot {
+    return new WorkspaceSnapshot().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: WorkspaceSnapshot | PlainMessage<WorkspaceSnapshot> | undefined, b: WorkspaceSnapshot | PlainMessage<WorkspaceSnapshot> | undefined): boolean {
+    return proto3.util.equals(WorkspaceSnapshot, a, b);
+  }
+}
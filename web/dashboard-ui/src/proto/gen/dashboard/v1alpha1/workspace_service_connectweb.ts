@@ -7,7 +7,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { CreateWorkspaceRequest, CreateWorkspaceResponse, DeleteNetworkRuleRequest, DeleteNetworkRuleResponse, DeleteWorkspaceRequest, DeleteWorkspaceResponse, GetWorkspaceRequest, GetWorkspaceResponse, GetWorkspacesRequest, GetWorkspacesResponse, UpdateWorkspaceRequest, UpdateWorkspaceResponse, UpsertNetworkRuleRequest, UpsertNetworkRuleResponse } from "./workspace_service_pb.js";
+import { CloneWorkspaceRequest, CloneWorkspaceResponse, CreateWorkspaceRequest, CreateWorkspaceResponse, CreateWorkspaceSnapshotRequest, CreateWorkspaceSnapshotResponse, DeleteNetworkRuleRequest, DeleteNetworkRuleResponse, DeleteWorkspaceRequest, DeleteWorkspaceResponse, GetWorkspaceRequest, GetWorkspaceResponse, GetWorkspaceSnapshotsRequest, GetWorkspaceSnapshotsResponse, GetWorkspacesRequest, GetWorkspacesResponse, RestoreWorkspaceSnapshotRequest, RestoreWorkspaceSnapshotResponse, UpdateWorkspaceRequest, UpdateWorkspaceResponse, UpsertNetworkRuleRequest, UpsertNetworkRuleResponse } from "./workspace_service_pb.js";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -93,6 +93,50 @@ export const WorkspaceService = {
       O: DeleteNetworkRuleResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Clone workspace into a new workspace
+     *
+     * @generated from rpc dashboard.v1alpha1.WorkspaceService.CloneWorkspace
+     */
+    cloneWorkspace: {
+      name: "CloneWorkspace",
+      I: CloneWorkspaceRequest,
+      O: CloneWorkspaceResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Create a snapshot of workspace volumes
+     *
+     * @generated from rpc dashboard.v1alpha1.WorkspaceService.CreateWorkspaceSnapshot
+     */
+    createWorkspaceSnapshot: {
+      name: "CreateWorkspaceSnapshot",
+      I: CreateWorkspaceSnapshotRequest,
+      O: CreateWorkspaceSnapshotResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Returns an array of WorkspaceSnapshot model
+     *
+     * @generated from rpc dashboard.v1alpha1.WorkspaceService.GetWorkspaceSnapshots
+     */
+    getWorkspaceSnapshots: {
+      name: "GetWorkspaceSnapshots",
+      I: GetWorkspaceSnapshotsRequest,
+      O: GetWorkspaceSnapshotsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Restore workspace volumes from the snapshot. workspace must be suspended
+     *
+     * @generated from rpc dashboard.v1alpha1.WorkspaceService.RestoreWorkspaceSnapshot
+     */
+    restoreWorkspaceSnapshot: {
+      name: "RestoreWorkspaceSnapshot",
+      I: RestoreWorkspaceSnapshotRequest,
+      O: RestoreWorkspaceSnapshotResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
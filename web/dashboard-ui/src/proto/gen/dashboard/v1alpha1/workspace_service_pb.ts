@@ -9,7 +9,7 @@
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
 import { Message, proto3 } from "@bufbuild/protobuf";
-import { NetworkRule, Workspace } from "./workspace_pb.js";
+import { NetworkRule, Workspace, WorkspaceSchedule, WorkspaceSnapshot } from "./workspace_pb.js";
 import { DeletePolicy } from "./user_pb.js";
 
 /**
@@ -225,6 +225,13 @@ export class UpdateWorkspaceRequest extends Message<UpdateWorkspaceRequest> {
    */
   deletePolicy?: DeletePolicy;
 
+  /**
+   * schedule to start and stop workspace. empty schedule removes the schedule
+   *
+   * @generated from field: optional dashboard.v1alpha1.WorkspaceSchedule schedule = 6;
+   */
+  schedule?: WorkspaceSchedule;
+
   constructor(data?: PartialMessage<UpdateWorkspaceRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -238,6 +245,7 @@ export class UpdateWorkspaceRequest extends Message<UpdateWorkspaceRequest> {
     { no: 3, name: "replicas", kind: "scalar", T: 3 /* ScalarType.INT64 */, opt: true },
     { no: 4, name: "vars", kind: "map", K: 9 /* ScalarType.STRING */, V: {kind: "scalar", T: 9 /* ScalarType.STRING */} },
     { no: 5, name: "delete_policy", kind: "enum", T: proto3.getEnumType(DeletePolicy), opt: true },
+    { no: 6, name: "schedule", kind: "message", T: WorkspaceSchedule, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateWorkspaceRequest {
@@ -670,3 +678,377 @@ export class DeleteWorkspaceResponse extends Message<DeleteWorkspaceResponse> {
   }
 }
 
+
+/**
+ * @generated from message dashboard.v1alpha1.CreateWorkspaceSnapshotRequest
+ */
+export class CreateWorkspaceSnapshotRequest extends Message<CreateWorkspaceSnapshotRequest> {
+  /**
+   * @generated from field: string user_name = 1;
+   */
+  userName = "";
+
+  /**
+   * @generated from field: string ws_name = 2;
+   */
+  wsName = "";
+
+  /**
+   * @generated from field: string snapshot_name = 3;
+   */
+  snapshotName = "";
+
+  /**
+   * @generated from field: optional string volume_snapshot_class_name = 4;
+   */
+  volumeSnapshotClassName?: string;
+
+  constructor(data?: PartialMessage<CreateWorkspaceSnapshotRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.CreateWorkspaceSnapshotRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "ws_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "snapshot_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "volume_snapshot_class_name", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateWorkspaceSnapshotRequest {
+    return new CreateWorkspaceSnapshotRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateWorkspaceSnapshotRequest {
+    return new CreateWorkspaceSnapshotRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateWorkspaceSnapshotRequest {
+    return new CreateWorkspaceSnapshotRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateWorkspaceSnapshotRequest | PlainMessage<CreateWorkspaceSnapshotRequest> | undefined, b: CreateWorkspaceSnapshotRequest | PlainMessage<CreateWorkspaceSnapshotRequest> | undefined): boolean {
+    return proto3.util.equals(CreateWorkspaceSnapshotRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message dashboard.v1alpha1.CreateWorkspaceSnapshotResponse
+ */
+export class CreateWorkspaceSnapshotResponse extends Message<CreateWorkspaceSnapshotResponse> {
+  /**
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  /**
+   * @generated from field: dashboard.v1alpha1.WorkspaceSnapshot snapshot = 2;
+   */
+  snapshot?: WorkspaceSnapshot;
+
+  constructor(data?: PartialMessage<CreateWorkspaceSnapshotResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.CreateWorkspaceSnapshotResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "snapshot", kind: "message", T: WorkspaceSnapshot },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateWorkspaceSnapshotResponse {
+    return new CreateWorkspaceSnapshotResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateWorkspaceSnapshotResponse {
+    return new CreateWorkspaceSnapshotResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateWorkspaceSnapshotResponse {
+    return new CreateWorkspaceSnapshotResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateWorkspaceSnapshotResponse | PlainMessage<CreateWorkspaceSnapshotResponse> | undefined, b: CreateWorkspaceSnapshotResponse | PlainMessage<CreateWorkspaceSnapshotResponse> | undefined): boolean {
+    return proto3.util.equals(CreateWorkspaceSnapshotResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message dashboard.v1alpha1.GetWorkspaceSnapshotsRequest
+ */
+export class GetWorkspaceSnapshotsRequest extends Message<GetWorkspaceSnapshotsRequest> {
+  /**
+   * @generated from field: string user_name = 1;
+   */
+  userName = "";
+
+  /**
+   * returns all snapshots of the user if empty
+   *
+   * @generated from field: string ws_name = 2;
+   */
+  wsName = "";
+
+  constructor(data?: PartialMessage<GetWorkspaceSnapshotsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.GetWorkspaceSnapshotsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "ws_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetWorkspaceSnapshotsRequest {
+    return new GetWorkspaceSnapshotsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetWorkspaceSnapshotsRequest {
+    return new GetWorkspaceSnapshotsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetWorkspaceSnapshotsRequest {
+    return new GetWorkspaceSnapshotsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetWorkspaceSnapshotsRequest | PlainMessage<GetWorkspaceSnapshotsRequest> | undefined, b: GetWorkspaceSnapshotsRequest | PlainMessage<GetWorkspaceSnapshotsRequest> | undefined): boolean {
+    return proto3.util.equals(GetWorkspaceSnapshotsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message dashboard.v1alpha1.GetWorkspaceSnapshotsResponse
+ */
+export class GetWorkspaceSnapshotsResponse extends Message<GetWorkspaceSnapshotsResponse> {
+  /**
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  /**
+   * @generated from field: repeated dashboard.v1alpha1.WorkspaceSnapshot items = 2;
+   */
+  items: WorkspaceSnapshot[] = [];
+
+  constructor(data?: PartialMessage<GetWorkspaceSnapshotsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.GetWorkspaceSnapshotsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "items", kind: "message", T: WorkspaceSnapshot, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetWorkspaceSnapshotsResponse {
+    return new GetWorkspaceSnapshotsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetWorkspaceSnapshotsResponse {
+    return new GetWorkspaceSnapshotsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetWorkspaceSnapshotsResponse {
+    return new GetWorkspaceSnapshotsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetWorkspaceSnapshotsResponse | PlainMessage<GetWorkspaceSnapshotsResponse> | undefined, b: GetWorkspaceSnapshotsResponse | PlainMessage<GetWorkspaceSnapshotsResponse> | undefined): boolean {
+    return proto3.util.equals(GetWorkspaceSnapshotsResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message dashboard.v1alpha1.RestoreWorkspaceSnapshotRequest
+ */
+export class RestoreWorkspaceSnapshotRequest extends Message<RestoreWorkspaceSnapshotRequest> {
+  /**
+   * @generated from field: string user_name = 1;
+   */
+  userName = "";
+
+  /**
+   * @generated from field: string snapshot_name = 2;
+   */
+  snapshotName = "";
+
+  constructor(data?: PartialMessage<RestoreWorkspaceSnapshotRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.RestoreWorkspaceSnapshotRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "snapshot_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RestoreWorkspaceSnapshotRequest {
+    return new RestoreWorkspaceSnapshotRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RestoreWorkspaceSnapshotRequest {
+    return new RestoreWorkspaceSnapshotRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RestoreWorkspaceSnapshotRequest {
+    return new RestoreWorkspaceSnapshotRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RestoreWorkspaceSnapshotRequest | PlainMessage<RestoreWorkspaceSnapshotRequest> | undefined, b: RestoreWorkspaceSnapshotRequest | PlainMessage<RestoreWorkspaceSnapshotRequest> | undefined): boolean {
+    return proto3.util.equals(RestoreWorkspaceSnapshotRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message dashboard.v1alpha1.RestoreWorkspaceSnapshotResponse
+ */
+export class RestoreWorkspaceSnapshotResponse extends Message<RestoreWorkspaceSnapshotResponse> {
+  /**
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  /**
+   * @generated from field: dashboard.v1alpha1.WorkspaceSnapshot snapshot = 2;
+   */
+  snapshot?: WorkspaceSnapshot;
+
+  constructor(data?: PartialMessage<RestoreWorkspaceSnapshotResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.RestoreWorkspaceSnapshotResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "snapshot", kind: "message", T: WorkspaceSnapshot },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RestoreWorkspaceSnapshotResponse {
+    return new RestoreWorkspaceSnapshotResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RestoreWorkspaceSnapshotResponse {
+    return new RestoreWorkspaceSnapshotResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RestoreWorkspaceSnapshotResponse {
+    return new RestoreWorkspaceSnapshotResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RestoreWorkspaceSnapshotResponse | PlainMessage<RestoreWorkspaceSnapshotResponse> | undefined, b: RestoreWorkspaceSnapshotResponse | PlainMessage<RestoreWorkspaceSnapshotResponse> | undefined): boolean {
+    return proto3.util.equals(RestoreWorkspaceSnapshotResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message dashboard.v1alpha1.CloneWorkspaceRequest
+ */
+export class CloneWorkspaceRequest extends Message<CloneWorkspaceRequest> {
+  /**
+   * owner of the source workspace
+   *
+   * @generated from field: string user_name = 1;
+   */
+  userName = "";
+
+  /**
+   * @generated from field: string ws_name = 2;
+   */
+  wsName = "";
+
+  /**
+   * owner of the new workspace. default is same as user_name
+   *
+   * @generated from field: optional string dst_user_name = 3;
+   */
+  dstUserName?: string;
+
+  /**
+   * @generated from field: string dst_ws_name = 4;
+   */
+  dstWsName = "";
+
+  constructor(data?: PartialMessage<CloneWorkspaceRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.CloneWorkspaceRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "ws_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "dst_user_name", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 4, name: "dst_ws_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CloneWorkspaceRequest {
+    return new CloneWorkspaceRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CloneWorkspaceRequest {
+    return new CloneWorkspaceRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CloneWorkspaceRequest {
+    return new CloneWorkspaceRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CloneWorkspaceRequest | PlainMessage<CloneWorkspaceRequest> | undefined, b: CloneWorkspaceRequest | PlainMessage<CloneWorkspaceRequest> | undefined): boolean {
+    return proto3.util.equals(CloneWorkspaceRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message dashboard.v1alpha1.CloneWorkspaceResponse
+ */
+export class CloneWorkspaceResponse extends Message<CloneWorkspaceResponse> {
+  /**
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  /**
+   * @generated from field: dashboard.v1alpha1.Workspace workspace = 2;
+   */
+  workspace?: Workspace;
+
+  constructor(data?: PartialMessage<CloneWorkspaceResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "dashboard.v1alpha1.CloneWorkspaceResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "workspace", kind: "message", T: Workspace },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CloneWorkspaceResponse {
+    return new CloneWorkspaceResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CloneWorkspaceResponse {
+    return new CloneWorkspaceResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CloneWorkspaceResponse {
+    return new CloneWorkspaceResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CloneWorkspaceResponse | PlainMessage<CloneWorkspaceResponse> | undefined, b: CloneWorkspaceResponse | PlainMessage<CloneWorkspaceResponse> | undefined): boolean {
+    return proto3.util.equals(CloneWorkspaceResponse, a, b);
+  }
+}
@@ -557,6 +557,26 @@ export const UserCreateDialog: React.VFC<{ onClose: () => void }> = ({
                   <div>ldap</div>
                 </Tooltip>
               </MenuItem>
+              <MenuItem key={"oidc"} value={"oidc"}>
+                <Tooltip
+                  title={"Authentication by OpenID Connect"}
+                  placement="right"
+                  arrow
+                  enterDelay={500}
+                >
+                  <div>oidc</div>
+                </Tooltip>
+              </MenuItem>
+              <MenuItem key={"webhook"} value={"webhook"}>
+                <Tooltip
+                  title={"Authentication by webhook"}
+                  placement="right"
+                  arrow
+                  enterDelay={500}
+                >
+                  <div>webhook</div>
+                </Tooltip>
+              </MenuItem>
             </TextField>
             <Typography
               color="text.secondary"